@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+const (
+	defaultMinConcurrency = 1
+	defaultDecreaseRatio  = 0.5
+)
+
+// AdaptiveConcurrencySettings defines configuration for adapting the number of concurrent requests sent to the
+// backend, between MinConcurrency and QueueSettings.NumConsumers, with an additive increase/multiplicative
+// decrease (AIMD) algorithm.
+type AdaptiveConcurrencySettings struct {
+	// Enabled indicates whether to adapt the concurrency instead of always using NumConsumers concurrent requests.
+	Enabled bool `mapstructure:"enabled"`
+	// MinConcurrency is the lower bound of the concurrency, and the concurrency used at start. Zero means 1.
+	MinConcurrency int `mapstructure:"min_concurrency"`
+	// LatencyThreshold is the latency of an attempt above which the backend is considered overloaded,
+	// in addition to retryable errors. Zero means that only the errors decrease the concurrency.
+	LatencyThreshold time.Duration `mapstructure:"latency_threshold"`
+	// DecreaseRatio is the factor applied to the concurrency when the backend is overloaded. Zero means 0.5.
+	DecreaseRatio float64 `mapstructure:"decrease_ratio"`
+}
+
+// concurrencyLimiter is a request sender that limits the number of attempts in flight to the next sender.
+// The limit grows by one after a full limit of successful attempts, and is multiplied by DecreaseRatio
+// after a retryable error or an attempt slower than LatencyThreshold.
+type concurrencyLimiter struct {
+	cfg            AdaptiveConcurrencySettings
+	maxConcurrency int
+	nextSender     requestSender
+	stopCh         chan struct{}
+	logger         *zap.Logger
+
+	mu       sync.Mutex
+	limit    int
+	inFlight int
+	// successes is the number of successful attempts since the limit last changed.
+	successes int
+	// lastDecrease is the time of the last decrease, attempts started before it do not decrease the limit again
+	// since they were sent with the old limit.
+	lastDecrease time.Time
+	// releasedCh is closed and replaced every time an attempt finishes, to wake up the waiting attempts.
+	releasedCh chan struct{}
+}
+
+func newConcurrencyLimiter(cfg AdaptiveConcurrencySettings, maxConcurrency int, nextSender requestSender, stopCh chan struct{}, logger *zap.Logger) *concurrencyLimiter {
+	if cfg.MinConcurrency < 1 {
+		cfg.MinConcurrency = defaultMinConcurrency
+	}
+	if cfg.DecreaseRatio <= 0 || cfg.DecreaseRatio >= 1 {
+		cfg.DecreaseRatio = defaultDecreaseRatio
+	}
+	if maxConcurrency < cfg.MinConcurrency {
+		maxConcurrency = cfg.MinConcurrency
+	}
+	return &concurrencyLimiter{
+		cfg:            cfg,
+		maxConcurrency: maxConcurrency,
+		nextSender:     nextSender,
+		stopCh:         stopCh,
+		logger:         logger,
+		limit:          cfg.MinConcurrency,
+		releasedCh:     make(chan struct{}),
+	}
+}
+
+// send implements the requestSender interface
+func (cl *concurrencyLimiter) send(req request) error {
+	if err := cl.acquire(req); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err := cl.nextSender.send(req)
+	cl.release(start, time.Since(start), err)
+	return err
+}
+
+// acquire waits until an attempt can be sent without exceeding the limit, but gets interrupted when shutting
+// down or request is cancelled or timed out.
+func (cl *concurrencyLimiter) acquire(req request) error {
+	for {
+		cl.mu.Lock()
+		if cl.inFlight < cl.limit {
+			cl.inFlight++
+			cl.mu.Unlock()
+			return nil
+		}
+		releasedCh := cl.releasedCh
+		cl.mu.Unlock()
+
+		select {
+		case <-req.context().Done():
+			return fmt.Errorf("request is cancelled or timed out while waiting for concurrency: %w", req.context().Err())
+		case <-cl.stopCh:
+			return errors.New("interrupted due to shutdown while waiting for concurrency")
+		case <-releasedCh:
+		}
+	}
+}
+
+// release frees the slot of a finished attempt and adapts the limit with its result.
+func (cl *concurrencyLimiter) release(start time.Time, latency time.Duration, err error) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	cl.inFlight--
+	overloaded := (err != nil && !consumererror.IsPermanent(err)) ||
+		(cl.cfg.LatencyThreshold > 0 && latency > cl.cfg.LatencyThreshold)
+	switch {
+	case !overloaded:
+		// Additive increase, by one after a full limit of successful attempts.
+		cl.successes++
+		if cl.successes >= cl.limit && cl.limit < cl.maxConcurrency {
+			cl.limit++
+			cl.successes = 0
+		}
+	case start.After(cl.lastDecrease):
+		// Multiplicative decrease.
+		cl.limit = int(float64(cl.limit) * cl.cfg.DecreaseRatio)
+		if cl.limit < cl.cfg.MinConcurrency {
+			cl.limit = cl.cfg.MinConcurrency
+		}
+		cl.successes = 0
+		cl.lastDecrease = time.Now()
+		cl.logger.Debug(
+			"Backend is overloaded, decreased the concurrency.",
+			zap.Int("concurrency", cl.limit),
+			zap.Duration("latency", latency),
+			zap.Error(err),
+		)
+	}
+
+	close(cl.releasedCh)
+	cl.releasedCh = make(chan struct{})
+}
+
+// currentConcurrency returns the current limit of attempts in flight.
+func (cl *concurrencyLimiter) currentConcurrency() int {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	return cl.limit
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+type blockingAttemptSender struct {
+	started chan struct{}
+	unblock chan struct{}
+}
+
+func (bas *blockingAttemptSender) send(request) error {
+	bas.started <- struct{}{}
+	<-bas.unblock
+	return nil
+}
+
+func TestConcurrencyLimiter_AdditiveIncrease(t *testing.T) {
+	cl := newConcurrencyLimiter(AdaptiveConcurrencySettings{Enabled: true}, 4, newMockAttemptSender(nil), make(chan struct{}), zap.NewNop())
+	assert.Equal(t, 1, cl.currentConcurrency())
+
+	req := newMockRequest(context.Background(), 2, nil)
+	// One success at 1, two at 2 and three at 3 grow the limit by one each time.
+	for _, want := range []int{2, 2, 3, 3, 3, 4} {
+		require.NoError(t, cl.send(req))
+		assert.Equal(t, want, cl.currentConcurrency())
+	}
+
+	// Never above the maximum.
+	for i := 0; i < 10; i++ {
+		require.NoError(t, cl.send(req))
+	}
+	assert.Equal(t, 4, cl.currentConcurrency())
+}
+
+func TestConcurrencyLimiter_MultiplicativeDecrease(t *testing.T) {
+	cfg := AdaptiveConcurrencySettings{Enabled: true, MinConcurrency: 2}
+	next := newMockAttemptSender(nil)
+	cl := newConcurrencyLimiter(cfg, 8, next, make(chan struct{}), zap.NewNop())
+	cl.limit = 8
+
+	req := newMockRequest(context.Background(), 2, nil)
+	next.setError(consumererror.Permanent(errors.New("bad data")))
+	assert.Error(t, cl.send(req))
+	assert.Equal(t, 8, cl.currentConcurrency())
+
+	next.setError(errors.New("transient error"))
+	assert.Error(t, cl.send(req))
+	assert.Equal(t, 4, cl.currentConcurrency())
+	assert.Error(t, cl.send(req))
+	assert.Equal(t, 2, cl.currentConcurrency())
+	// Never below the minimum.
+	assert.Error(t, cl.send(req))
+	assert.Equal(t, 2, cl.currentConcurrency())
+}
+
+func TestConcurrencyLimiter_OneDecreasePerCongestion(t *testing.T) {
+	cl := newConcurrencyLimiter(AdaptiveConcurrencySettings{Enabled: true}, 8, newMockAttemptSender(nil), make(chan struct{}), zap.NewNop())
+	cl.limit = 8
+	cl.inFlight = 2
+
+	// Both attempts were in flight when the backend failed, only the first one decreases the limit.
+	start := time.Now()
+	cl.release(start, time.Millisecond, errors.New("transient error"))
+	cl.release(start, time.Millisecond, errors.New("transient error"))
+	assert.Equal(t, 4, cl.currentConcurrency())
+	assert.Equal(t, 0, cl.inFlight)
+}
+
+func TestConcurrencyLimiter_LatencyThreshold(t *testing.T) {
+	cfg := AdaptiveConcurrencySettings{Enabled: true, LatencyThreshold: time.Second, DecreaseRatio: 0.75}
+	cl := newConcurrencyLimiter(cfg, 8, newMockAttemptSender(nil), make(chan struct{}), zap.NewNop())
+	cl.limit = 8
+
+	cl.inFlight = 1
+	cl.release(time.Now(), 10*time.Millisecond, nil)
+	assert.Equal(t, 8, cl.currentConcurrency())
+
+	cl.inFlight = 1
+	cl.release(time.Now(), 2*time.Second, nil)
+	assert.Equal(t, 6, cl.currentConcurrency())
+}
+
+func TestConcurrencyLimiter_WaitUntilShutdown(t *testing.T) {
+	next := &blockingAttemptSender{started: make(chan struct{}), unblock: make(chan struct{})}
+	cl := newConcurrencyLimiter(AdaptiveConcurrencySettings{Enabled: true}, 4, next, make(chan struct{}), zap.NewNop())
+
+	req := newMockRequest(context.Background(), 2, nil)
+	first := make(chan error)
+	go func() {
+		first <- cl.send(req)
+	}()
+	<-next.started
+
+	second := make(chan error)
+	go func() {
+		second <- cl.send(req)
+	}()
+	select {
+	case <-second:
+		require.Fail(t, "send must wait while the limit is reached")
+	case <-next.started:
+		require.Fail(t, "send must wait while the limit is reached")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(cl.stopCh)
+	assert.Error(t, <-second)
+	close(next.unblock)
+	assert.NoError(t, <-first)
+}
+
+func TestConcurrencyLimiter_MetricAndZPages(t *testing.T) {
+	qCfg := DefaultQueueSettings()
+	qCfg.AdaptiveConcurrency.Enabled = true
+	qCfg.AdaptiveConcurrency.MinConcurrency = 3
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithQueue(qCfg)), "", nil)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	checkValueForProducer(t, defaultExporterTags, int64(3), "exporter/concurrency")
+	assert.Contains(t, be.ZPagesStatus(), [2]string{"Concurrency", "3"})
+
+	assert.NoError(t, be.Shutdown(context.Background()))
+	checkValueForProducer(t, defaultExporterTags, int64(0), "exporter/concurrency")
+}
@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// errCircuitBreakerOpen is returned when a request is not sent because the circuit breaker is open.
+var errCircuitBreakerOpen = errors.New("circuit breaker is open")
+
+// CircuitBreakerSettings defines configuration for the circuit breaker that stops sending data to a backend
+// that keeps failing.
+type CircuitBreakerSettings struct {
+	// Enabled indicates whether to use the circuit breaker.
+	Enabled bool `mapstructure:"enabled"`
+	// FailureThreshold is the number of consecutive failed attempts that opens the circuit.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	// OpenDuration is the time the circuit stays open before a single attempt is let through to probe the backend.
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+	// FailFast indicates whether to drop the data while the circuit is open. Otherwise the attempts wait until
+	// the backend recovers, and the data is held in the sending queue.
+	FailFast bool `mapstructure:"fail_fast"`
+}
+
+// DefaultCircuitBreakerSettings returns the default settings for CircuitBreakerSettings.
+func DefaultCircuitBreakerSettings() CircuitBreakerSettings {
+	return CircuitBreakerSettings{
+		Enabled:          false,
+		FailureThreshold: 5,
+		OpenDuration:     30 * time.Second,
+		FailFast:         false,
+	}
+}
+
+// circuitBreakerState is the state of the circuit breaker, the values are reported by the state metric.
+type circuitBreakerState int64
+
+const (
+	// circuitClosed lets all the attempts through.
+	circuitClosed circuitBreakerState = iota
+	// circuitOpen does not let any attempt through.
+	circuitOpen
+	// circuitHalfOpen lets one attempt through to probe the backend.
+	circuitHalfOpen
+)
+
+func (s circuitBreakerState) String() string {
+	switch s {
+	case circuitClosed:
+		return "closed"
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("unknown(%d)", int64(s))
+}
+
+// circuitBreakerSender is a request sender that stops sending attempts to the next sender after
+// FailureThreshold consecutive failures, and probes the backend every OpenDuration until it recovers.
+type circuitBreakerSender struct {
+	cfg            CircuitBreakerSettings
+	traceAttribute trace.Attribute
+	nextSender     requestSender
+	stopCh         chan struct{}
+	logger         *zap.Logger
+
+	mu       sync.Mutex
+	state    circuitBreakerState
+	failures int
+	// openUntil is the time when the open circuit allows the next probe.
+	openUntil time.Time
+	// probing is true while the half-open probe is in flight.
+	probing bool
+	// changedCh is closed and replaced every time the state changes, to wake up the waiting attempts.
+	changedCh chan struct{}
+}
+
+func newCircuitBreakerSender(cfg CircuitBreakerSettings, traceAttribute trace.Attribute, nextSender requestSender, stopCh chan struct{}, logger *zap.Logger) *circuitBreakerSender {
+	if cfg.FailureThreshold < 1 {
+		cfg.FailureThreshold = 1
+	}
+	return &circuitBreakerSender{
+		cfg:            cfg,
+		traceAttribute: traceAttribute,
+		nextSender:     nextSender,
+		stopCh:         stopCh,
+		logger:         logger,
+		state:          circuitClosed,
+		changedCh:      make(chan struct{}),
+	}
+}
+
+// send implements the requestSender interface
+func (cbs *circuitBreakerSender) send(req request) error {
+	for {
+		wait, changedCh, allowed := cbs.allow()
+		if allowed {
+			break
+		}
+
+		span := trace.FromContext(req.context())
+		if cbs.cfg.FailFast {
+			span.Annotate([]trace.Attribute{cbs.traceAttribute}, "Dropped request, circuit breaker is open.")
+			return consumererror.Permanent(errCircuitBreakerOpen)
+		}
+
+		span.Annotate([]trace.Attribute{cbs.traceAttribute}, "Circuit breaker is open. Waiting for the backend to recover.")
+		// Wait for the next probe, but get interrupted when shutting down or request is cancelled or timed out.
+		select {
+		case <-req.context().Done():
+			return fmt.Errorf("request is cancelled or timed out %w", errCircuitBreakerOpen)
+		case <-cbs.stopCh:
+			return fmt.Errorf("interrupted due to shutdown %w", errCircuitBreakerOpen)
+		case <-changedCh:
+		case <-time.After(wait):
+		}
+	}
+
+	err := cbs.nextSender.send(req)
+	cbs.record(err)
+	return err
+}
+
+// allow returns whether an attempt can be sent now. If not, it returns how long to wait for the next probe, and
+// a channel closed when the state changes.
+func (cbs *circuitBreakerSender) allow() (time.Duration, <-chan struct{}, bool) {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+
+	switch cbs.state {
+	case circuitOpen:
+		wait := time.Until(cbs.openUntil)
+		if wait > 0 {
+			return wait, cbs.changedCh, false
+		}
+		cbs.setState(circuitHalfOpen)
+		cbs.probing = true
+		return 0, nil, true
+	case circuitHalfOpen:
+		if cbs.probing {
+			return cbs.cfg.OpenDuration, cbs.changedCh, false
+		}
+		cbs.probing = true
+		return 0, nil, true
+	}
+	return 0, nil, true
+}
+
+// record updates the state with the result of an attempt. Permanent errors mean that the backend
+// is reachable but rejected the data, so they do not count as failures.
+func (cbs *circuitBreakerSender) record(err error) {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+
+	if err == nil || consumererror.IsPermanent(err) {
+		cbs.failures = 0
+		cbs.probing = false
+		if cbs.state != circuitClosed {
+			cbs.setState(circuitClosed)
+		}
+		return
+	}
+
+	switch cbs.state {
+	case circuitClosed:
+		cbs.failures++
+		if cbs.failures >= cbs.cfg.FailureThreshold {
+			cbs.open(err)
+		}
+	case circuitHalfOpen:
+		cbs.probing = false
+		cbs.open(err)
+	}
+}
+
+// open must be called with the lock held.
+func (cbs *circuitBreakerSender) open(err error) {
+	cbs.openUntil = time.Now().Add(cbs.cfg.OpenDuration)
+	cbs.setState(circuitOpen)
+	cbs.logger.Warn(
+		"Circuit breaker opened, stop sending data to the backend.",
+		zap.Error(err),
+		zap.String("open_duration", cbs.cfg.OpenDuration.String()),
+	)
+}
+
+// setState must be called with the lock held.
+func (cbs *circuitBreakerSender) setState(state circuitBreakerState) {
+	if state == circuitClosed && cbs.state != circuitClosed {
+		cbs.logger.Info("Circuit breaker closed, the backend recovered.")
+	}
+	cbs.state = state
+	close(cbs.changedCh)
+	cbs.changedCh = make(chan struct{})
+}
+
+// currentState returns the current state of the circuit breaker.
+func (cbs *circuitBreakerSender) currentState() circuitBreakerState {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	return cbs.state
+}
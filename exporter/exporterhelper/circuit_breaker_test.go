@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+type mockAttemptSender struct {
+	calls int64
+	err   atomic.Value
+}
+
+func newMockAttemptSender(err error) *mockAttemptSender {
+	mas := &mockAttemptSender{}
+	mas.setError(err)
+	return mas
+}
+
+func (mas *mockAttemptSender) setError(err error) {
+	mas.err.Store(&err)
+}
+
+func (mas *mockAttemptSender) send(request) error {
+	atomic.AddInt64(&mas.calls, 1)
+	return *mas.err.Load().(*error)
+}
+
+func newTestCircuitBreaker(cfg CircuitBreakerSettings, next requestSender) *circuitBreakerSender {
+	return newCircuitBreakerSender(cfg, trace.StringAttribute("exporter", "test"), next, make(chan struct{}), zap.NewNop())
+}
+
+func TestCircuitBreaker_FailFast(t *testing.T) {
+	cfg := DefaultCircuitBreakerSettings()
+	cfg.Enabled = true
+	cfg.FailureThreshold = 2
+	cfg.OpenDuration = time.Hour
+	cfg.FailFast = true
+	next := newMockAttemptSender(errors.New("transient error"))
+	cbs := newTestCircuitBreaker(cfg, next)
+
+	req := newMockRequest(context.Background(), 2, nil)
+	assert.Error(t, cbs.send(req))
+	assert.Equal(t, circuitClosed, cbs.currentState())
+	assert.Error(t, cbs.send(req))
+	assert.Equal(t, circuitOpen, cbs.currentState())
+
+	err := cbs.send(req)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.True(t, errors.Is(err, errCircuitBreakerOpen))
+	assert.EqualValues(t, 2, atomic.LoadInt64(&next.calls))
+}
+
+func TestCircuitBreaker_PermanentErrorsDoNotOpen(t *testing.T) {
+	cfg := DefaultCircuitBreakerSettings()
+	cfg.Enabled = true
+	cfg.FailureThreshold = 1
+	next := newMockAttemptSender(consumererror.Permanent(errors.New("bad data")))
+	cbs := newTestCircuitBreaker(cfg, next)
+
+	for i := 0; i < 3; i++ {
+		assert.Error(t, cbs.send(newMockRequest(context.Background(), 2, nil)))
+	}
+	assert.Equal(t, circuitClosed, cbs.currentState())
+	assert.EqualValues(t, 3, atomic.LoadInt64(&next.calls))
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	cfg := DefaultCircuitBreakerSettings()
+	cfg.Enabled = true
+	cfg.FailureThreshold = 1
+	cfg.OpenDuration = 10 * time.Millisecond
+	next := newMockAttemptSender(errors.New("transient error"))
+	cbs := newTestCircuitBreaker(cfg, next)
+
+	req := newMockRequest(context.Background(), 2, nil)
+	assert.Error(t, cbs.send(req))
+	assert.Equal(t, circuitOpen, cbs.currentState())
+
+	// The probe waits for the open duration, fails and opens the circuit again.
+	start := time.Now()
+	assert.Error(t, cbs.send(req))
+	assert.True(t, time.Since(start) >= cfg.OpenDuration)
+	assert.Equal(t, circuitOpen, cbs.currentState())
+	assert.EqualValues(t, 2, atomic.LoadInt64(&next.calls))
+
+	// The backend recovers, the probe succeeds and closes the circuit.
+	next.setError(nil)
+	assert.NoError(t, cbs.send(req))
+	assert.Equal(t, circuitClosed, cbs.currentState())
+	assert.NoError(t, cbs.send(req))
+	assert.EqualValues(t, 4, atomic.LoadInt64(&next.calls))
+}
+
+func TestCircuitBreaker_HoldUntilShutdown(t *testing.T) {
+	cfg := DefaultCircuitBreakerSettings()
+	cfg.Enabled = true
+	cfg.FailureThreshold = 1
+	cfg.OpenDuration = time.Hour
+	next := newMockAttemptSender(errors.New("transient error"))
+	cbs := newTestCircuitBreaker(cfg, next)
+
+	req := newMockRequest(context.Background(), 2, nil)
+	assert.Error(t, cbs.send(req))
+
+	done := make(chan error)
+	go func() {
+		done <- cbs.send(req)
+	}()
+	select {
+	case <-done:
+		require.Fail(t, "send must wait while the circuit is open")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(cbs.stopCh)
+	err := <-done
+	assert.True(t, errors.Is(err, errCircuitBreakerOpen))
+	assert.False(t, consumererror.IsPermanent(err))
+	assert.EqualValues(t, 1, atomic.LoadInt64(&next.calls))
+}
+
+func TestCircuitBreaker_StateMetricAndZPages(t *testing.T) {
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request go straight to the queue
+	cbCfg := DefaultCircuitBreakerSettings()
+	cbCfg.Enabled = true
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithQueue(qCfg), WithCircuitBreaker(cbCfg)), "", nil)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, nil)))
+	checkValueForProducer(t, defaultExporterTags, int64(circuitClosed), "exporter/circuit_breaker_state")
+	assert.Equal(t, [][2]string{{"Queue size (batches)", "1"}, {"Circuit breaker", "closed"}}, be.ZPagesStatus())
+
+	assert.NoError(t, be.Shutdown(context.Background()))
+}
+
+func TestCircuitBreakerState_String(t *testing.T) {
+	assert.Equal(t, "closed", circuitClosed.String())
+	assert.Equal(t, "open", circuitOpen.String())
+	assert.Equal(t, "half-open", circuitHalfOpen.String())
+	assert.Equal(t, "unknown(7)", circuitBreakerState(7).String())
+}
@@ -16,6 +16,7 @@ package exporterhelper
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"go.uber.org/zap"
@@ -25,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumerhelper"
+	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
 // TimeoutSettings for timeout. The timeout applies to individual attempts to send data to the backend.
@@ -52,6 +54,14 @@ type request interface {
 	onError(error) request
 	// Returns the count of spans/metric points or log records.
 	count() int
+	// Returns the size in bytes of the OTLP protobuf encoding of the data.
+	size() int
+	// marshal returns the serialized data of the request, used to store the request in the persistent queue.
+	marshal() ([]byte, error)
+	// split returns two requests with about half of the data each, or false if the data cannot be split.
+	split() (request, request, bool)
+	// deadLetter sends a copy of the data to the given exporter, after calling annotate for every resource.
+	deadLetter(ctx context.Context, exporter component.Exporter, annotate func(pdata.Resource)) error
 }
 
 // requestSender is an abstraction of a sender for a request independent of the type of the data (traces, metrics, logs).
@@ -79,6 +89,8 @@ type baseSettings struct {
 	TimeoutSettings
 	QueueSettings
 	RetrySettings
+	CircuitBreakerSettings
+	DeadLetterSettings
 	ResourceToTelemetrySettings
 }
 
@@ -91,6 +103,7 @@ func fromOptions(options ...Option) *baseSettings {
 		QueueSettings: QueueSettings{Enabled: false},
 		// TODO: Enable retry by default (call DefaultRetrySettings)
 		RetrySettings:               RetrySettings{Enabled: false},
+		CircuitBreakerSettings:      DefaultCircuitBreakerSettings(),
 		ResourceToTelemetrySettings: defaultResourceToTelemetrySettings(),
 	}
 
@@ -144,6 +157,22 @@ func WithQueue(queueSettings QueueSettings) Option {
 	}
 }
 
+// WithCircuitBreaker overrides the default CircuitBreakerSettings for an exporter.
+// The default CircuitBreakerSettings is to disable the circuit breaker.
+func WithCircuitBreaker(circuitBreakerSettings CircuitBreakerSettings) Option {
+	return func(o *baseSettings) {
+		o.CircuitBreakerSettings = circuitBreakerSettings
+	}
+}
+
+// WithDeadLetter overrides the default DeadLetterSettings for an exporter.
+// The default DeadLetterSettings is to drop the data that cannot be exported.
+func WithDeadLetter(deadLetterSettings DeadLetterSettings) Option {
+	return func(o *baseSettings) {
+		o.DeadLetterSettings = deadLetterSettings
+	}
+}
+
 // WithCapabilities overrides the default Capabilities() function for a Consumer.
 // The default is non-mutable data.
 // TODO: Verify if we can change the default to be mutable as we do for processors.
@@ -164,16 +193,18 @@ func WithResourceToTelemetryConversion(resourceToTelemetrySettings ResourceToTel
 // baseExporter contains common fields between different exporter types.
 type baseExporter struct {
 	component.Component
-	sender   requestSender
-	qrSender *queuedRetrySender
+	sender     requestSender
+	qrSender   *queuedRetrySender
+	deadLetter *deadLetterSender
 }
 
-func newBaseExporter(cfg config.Exporter, logger *zap.Logger, bs *baseSettings) *baseExporter {
+func newBaseExporter(cfg config.Exporter, logger *zap.Logger, bs *baseSettings, dataType config.DataType, reqUnmarshaler requestUnmarshaler) *baseExporter {
 	be := &baseExporter{
 		Component: componenthelper.New(bs.componentOptions...),
 	}
 
-	be.qrSender = newQueuedRetrySender(cfg.ID().String(), bs.QueueSettings, bs.RetrySettings, &timeoutSender{cfg: bs.TimeoutSettings}, logger)
+	be.deadLetter = newDeadLetterSender(bs.DeadLetterSettings, cfg.ID(), dataType, logger)
+	be.qrSender = newQueuedRetrySender(cfg.ID().String(), dataType, bs.QueueSettings, bs.RetrySettings, bs.CircuitBreakerSettings, be.deadLetter, reqUnmarshaler, &timeoutSender{cfg: bs.TimeoutSettings}, logger)
 	be.sender = be.qrSender
 
 	return be
@@ -192,10 +223,30 @@ func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 
+	// The dead letter exporter must be known before any request can fail.
+	if err := be.deadLetter.start(host); err != nil {
+		return err
+	}
+
 	// If no error then start the queuedRetrySender.
 	return be.qrSender.start()
 }
 
+// ZPagesStatus returns the status of the senders, displayed by the pipelines zPage.
+func (be *baseExporter) ZPagesStatus() [][2]string {
+	var status [][2]string
+	if be.qrSender.cfg.Enabled {
+		status = append(status, [2]string{"Queue size (batches)", strconv.Itoa(be.qrSender.queue.Size())})
+	}
+	if be.qrSender.limiter != nil {
+		status = append(status, [2]string{"Concurrency", strconv.Itoa(be.qrSender.limiter.currentConcurrency())})
+	}
+	if be.qrSender.circuitBreaker != nil {
+		status = append(status, [2]string{"Circuit breaker", be.qrSender.circuitBreaker.currentState().String()})
+	}
+	return status
+}
+
 // Shutdown all senders and exporter and is invoked during service shutdown.
 func (be *baseExporter) Shutdown(ctx context.Context) error {
 	// First shutdown the queued retry sender
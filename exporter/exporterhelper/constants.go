@@ -29,4 +29,8 @@ var (
 	errNilPushMetricsData = errors.New("nil PushMetrics")
 	// errNilPushLogsData is returned when a nil PushLogs is given.
 	errNilPushLogsData = errors.New("nil PushLogs")
+	// errNilRequestUnmarshaler is returned when the persistent queue is enabled for an exporter without a requestUnmarshaler.
+	errNilRequestUnmarshaler = errors.New("nil requestUnmarshaler")
+	// errDeadLetterDataType is returned when the dead letter exporter does not support the data type of the request.
+	errDeadLetterDataType = errors.New("dead_letter exporter does not support the data type")
 )
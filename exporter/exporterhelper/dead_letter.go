@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	// DeadLetterExporterAttribute is the resource attribute set on dead letter data with the ID of the exporter
+	// that failed to export it.
+	DeadLetterExporterAttribute = "dead_letter.exporter"
+	// DeadLetterReasonAttribute is the resource attribute set on dead letter data with the export error.
+	DeadLetterReasonAttribute = "dead_letter.reason"
+)
+
+// DeadLetterSettings defines configuration for the data that is dropped because it cannot be exported.
+type DeadLetterSettings struct {
+	// Exporter is the ID of the exporter that receives the data dropped after a permanent error, after
+	// all the retries were exhausted or without retries, when the sending_queue is full and when the
+	// retries are interrupted by the shutdown. Empty disables the dead letter.
+	// The exporter must be part of a pipeline with the same data type, e.g. a "file" exporter.
+	Exporter string `mapstructure:"exporter"`
+}
+
+// deadLetterSender forwards the requests that could not be exported to the dead letter exporter.
+type deadLetterSender struct {
+	cfg      DeadLetterSettings
+	id       config.ComponentID
+	dataType config.DataType
+	exporter component.Exporter
+	logger   *zap.Logger
+}
+
+func newDeadLetterSender(cfg DeadLetterSettings, id config.ComponentID, dataType config.DataType, logger *zap.Logger) *deadLetterSender {
+	return &deadLetterSender{
+		cfg:      cfg,
+		id:       id,
+		dataType: dataType,
+		logger:   logger,
+	}
+}
+
+// start looks up the dead letter exporter, it must be called before any request is sent.
+func (dls *deadLetterSender) start(host component.Host) error {
+	if dls.cfg.Exporter == "" {
+		return nil
+	}
+
+	id, err := config.NewIDFromString(dls.cfg.Exporter)
+	if err != nil {
+		return fmt.Errorf("invalid dead_letter exporter %q: %w", dls.cfg.Exporter, err)
+	}
+	if id == dls.id {
+		return fmt.Errorf("dead_letter exporter %q cannot be the exporter itself", dls.cfg.Exporter)
+	}
+
+	exporter, ok := host.GetExporters()[dls.dataType][id]
+	if !ok {
+		return fmt.Errorf("dead_letter exporter %q is not used in any %s pipeline", dls.cfg.Exporter, dls.dataType)
+	}
+	dls.exporter = exporter
+	return nil
+}
+
+// send forwards the request, annotated with the export error, to the dead letter exporter if configured.
+func (dls *deadLetterSender) send(req request, reason error) {
+	if dls == nil || dls.exporter == nil {
+		return
+	}
+
+	annotate := func(resource pdata.Resource) {
+		resource.Attributes().UpsertString(DeadLetterExporterAttribute, dls.id.String())
+		resource.Attributes().UpsertString(DeadLetterReasonAttribute, reason.Error())
+	}
+	if err := req.deadLetter(req.context(), dls.exporter, annotate); err != nil {
+		dls.logger.Error(
+			"Failed to send dropped data to the dead_letter exporter.",
+			zap.String("dead_letter", dls.cfg.Exporter),
+			zap.Error(err),
+			zap.Int("dropped_items", req.count()),
+		)
+		return
+	}
+	dls.logger.Debug(
+		"Sent dropped data to the dead_letter exporter.",
+		zap.String("dead_letter", dls.cfg.Exporter),
+		zap.Int("items", req.count()),
+	)
+}
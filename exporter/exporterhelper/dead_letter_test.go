@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+var deadLetterID = config.NewIDWithName("file", "dead_letter")
+
+type deadLetterHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *deadLetterHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+func newDeadLetterHost(t *testing.T, sink *consumertest.TracesSink) component.Host {
+	exp, err := NewTracesExporter(&defaultExporterCfg, zap.NewNop(), sink.ConsumeTraces)
+	require.NoError(t, err)
+	return &deadLetterHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.TracesDataType: {deadLetterID: exp},
+		},
+	}
+}
+
+func TestDeadLetter_PermanentError(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	wantErr := consumererror.Permanent(errors.New("bad data"))
+	te, err := NewTracesExporter(&fakeTracesExporterConfig, zap.NewNop(), newTraceDataPusher(wantErr),
+		WithRetry(DefaultRetrySettings()), WithDeadLetter(DeadLetterSettings{Exporter: deadLetterID.String()}))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), newDeadLetterHost(t, sink)))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	td := testdata.GenerateTracesOneSpan()
+	assert.Equal(t, wantErr, te.ConsumeTraces(context.Background(), td))
+
+	require.Len(t, sink.AllTraces(), 1)
+	got := sink.AllTraces()[0]
+	assert.Equal(t, 1, got.SpanCount())
+	attrs := got.ResourceSpans().At(0).Resource().Attributes()
+	exporterAttr, _ := attrs.Get(DeadLetterExporterAttribute)
+	assert.Equal(t, fakeTracesExporterName.String(), exporterAttr.StringVal())
+	reasonAttr, _ := attrs.Get(DeadLetterReasonAttribute)
+	assert.Equal(t, wantErr.Error(), reasonAttr.StringVal())
+
+	// The original data is not modified.
+	_, ok := td.ResourceSpans().At(0).Resource().Attributes().Get(DeadLetterReasonAttribute)
+	assert.False(t, ok)
+}
+
+func TestDeadLetter_MaxElapsedTime(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.MaxElapsedTime = 10 * time.Millisecond
+	te, err := NewTracesExporter(&fakeTracesExporterConfig, zap.NewNop(), newTraceDataPusher(errors.New("transient error")),
+		WithRetry(rCfg), WithDeadLetter(DeadLetterSettings{Exporter: deadLetterID.String()}))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), newDeadLetterHost(t, sink)))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	assert.Error(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResource()))
+	assert.Equal(t, 2, sink.SpansCount())
+}
+
+func TestDeadLetter_RetryDisabled(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	rCfg := DefaultRetrySettings()
+	rCfg.Enabled = false
+	te, err := NewTracesExporter(&fakeTracesExporterConfig, zap.NewNop(), newTraceDataPusher(errors.New("transient error")),
+		WithRetry(rCfg), WithDeadLetter(DeadLetterSettings{Exporter: deadLetterID.String()}))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), newDeadLetterHost(t, sink)))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	assert.Error(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResource()))
+	require.Len(t, sink.AllTraces(), 1)
+	reasonAttr, _ := sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Get(DeadLetterReasonAttribute)
+	assert.Equal(t, "transient error", reasonAttr.StringVal())
+}
+
+func TestDeadLetter_QueueFull(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request go straight to the queue
+	qCfg.QueueSize = 1
+	te, err := NewTracesExporter(&fakeTracesExporterConfig, zap.NewNop(), newTraceDataPusher(nil),
+		WithQueue(qCfg), WithDeadLetter(DeadLetterSettings{Exporter: deadLetterID.String()}))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), newDeadLetterHost(t, sink)))
+
+	assert.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, 0, sink.SpansCount())
+	assert.Error(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResource()))
+	assert.Equal(t, 2, sink.SpansCount())
+	reasonAttr, _ := sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Get(DeadLetterReasonAttribute)
+	assert.Equal(t, "sending_queue is full", reasonAttr.StringVal())
+
+	// The request left in the queue is sent when the queue is drained.
+	assert.NoError(t, te.Shutdown(context.Background()))
+	assert.Equal(t, 2, sink.SpansCount())
+}
+
+func TestDeadLetter_Shutdown(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = time.Hour
+	attempted := make(chan struct{}, 1)
+	te, err := NewTracesExporter(&fakeTracesExporterConfig, zap.NewNop(), func(context.Context, pdata.Traces) error {
+		select {
+		case attempted <- struct{}{}:
+		default:
+		}
+		return errors.New("transient error")
+	}, WithQueue(qCfg), WithRetry(rCfg), WithDeadLetter(DeadLetterSettings{Exporter: deadLetterID.String()}))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), newDeadLetterHost(t, sink)))
+
+	assert.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	<-attempted
+	assert.NoError(t, te.Shutdown(context.Background()))
+	assert.Equal(t, 1, sink.SpansCount())
+}
+
+func TestDeadLetter_NoDeadLetterOnSuccess(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	te, err := NewTracesExporter(&fakeTracesExporterConfig, zap.NewNop(), newTraceDataPusher(nil),
+		WithRetry(DefaultRetrySettings()), WithDeadLetter(DeadLetterSettings{Exporter: deadLetterID.String()}))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), newDeadLetterHost(t, sink)))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	assert.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, 0, sink.SpansCount())
+}
+
+func TestDeadLetter_StartErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+	}{
+		{name: "invalid", exporter: "/invalid"},
+		{name: "not_found", exporter: "file/missing"},
+		{name: "itself", exporter: fakeTracesExporterName.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			te, err := NewTracesExporter(&fakeTracesExporterConfig, zap.NewNop(), newTraceDataPusher(nil),
+				WithDeadLetter(DeadLetterSettings{Exporter: tt.exporter}))
+			require.NoError(t, err)
+			assert.Error(t, te.Start(context.Background(), newDeadLetterHost(t, new(consumertest.TracesSink))))
+		})
+	}
+}
+
+func TestDeadLetter_WrongDataType(t *testing.T) {
+	annotate := func(pdata.Resource) {}
+	tracesExporter, err := NewTracesExporter(&defaultExporterCfg, zap.NewNop(), newTraceDataPusher(nil))
+	require.NoError(t, err)
+
+	assert.NoError(t, newTracesRequest(context.Background(), testdata.GenerateTracesOneSpan(), nil).deadLetter(context.Background(), tracesExporter, annotate))
+	assert.Equal(t, errDeadLetterDataType, newMetricsRequest(context.Background(), testdata.GenerateMetricsOneMetric(), nil).deadLetter(context.Background(), tracesExporter, annotate))
+	assert.Equal(t, errDeadLetterDataType, newLogsRequest(context.Background(), testdata.GenerateLogsOneLogRecord(), nil).deadLetter(context.Background(), tracesExporter, annotate))
+}
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumerhelper"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/batchsplit"
 	"go.opentelemetry.io/collector/obsreport"
 )
 
@@ -43,6 +44,16 @@ func newLogsRequest(ctx context.Context, ld pdata.Logs, pusher consumerhelper.Co
 	}
 }
 
+func newLogsRequestUnmarshalerFunc(pusher consumerhelper.ConsumeLogsFunc) requestUnmarshaler {
+	return func(bytes []byte) (request, error) {
+		ld, err := pdata.LogsFromOtlpProtoBytes(bytes)
+		if err != nil {
+			return nil, err
+		}
+		return newLogsRequest(context.Background(), ld, pusher), nil
+	}
+}
+
 func (req *logsRequest) onError(err error) request {
 	var logError consumererror.Logs
 	if consumererror.AsLogs(err, &logError) {
@@ -59,6 +70,38 @@ func (req *logsRequest) count() int {
 	return req.ld.LogRecordCount()
 }
 
+func (req *logsRequest) size() int {
+	return req.ld.OtlpProtoSize()
+}
+
+func (req *logsRequest) marshal() ([]byte, error) {
+	return req.ld.ToOtlpProtoBytes()
+}
+
+func (req *logsRequest) split() (request, request, bool) {
+	n := req.ld.LogRecordCount()
+	if n < 2 {
+		return nil, nil, false
+	}
+	// The data may be shared with other consumers, so split a copy.
+	second := req.ld.Clone()
+	first := batchsplit.SplitLogs(n/2, second)
+	return newLogsRequest(req.ctx, first, req.pusher), newLogsRequest(req.ctx, second, req.pusher), true
+}
+
+func (req *logsRequest) deadLetter(ctx context.Context, exporter component.Exporter, annotate func(pdata.Resource)) error {
+	next, ok := exporter.(consumer.Logs)
+	if !ok {
+		return errDeadLetterDataType
+	}
+	ld := req.ld.Clone()
+	rs := ld.ResourceLogs()
+	for i := 0; i < rs.Len(); i++ {
+		annotate(rs.At(i).Resource())
+	}
+	return next.ConsumeLogs(ctx, ld)
+}
+
 type logsExporter struct {
 	*baseExporter
 	consumer.Logs
@@ -84,7 +127,7 @@ func NewLogsExporter(
 	}
 
 	bs := fromOptions(options...)
-	be := newBaseExporter(cfg, logger, bs)
+	be := newBaseExporter(cfg, logger, bs, config.LogsDataType, newLogsRequestUnmarshalerFunc(pusher))
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &logsExporterWithObservability{
 			obsrep: obsreport.NewExporter(obsreport.ExporterSettings{
@@ -56,6 +56,19 @@ func TestLogsRequest(t *testing.T) {
 	)
 }
 
+func TestLogsRequest_Split(t *testing.T) {
+	data := testdata.GenerateLogsManyLogRecordsSameResource(5)
+	first, second, ok := newLogsRequest(context.Background(), data, nil).split()
+	require.True(t, ok)
+	assert.Equal(t, 2, first.(*logsRequest).ld.LogRecordCount())
+	assert.Equal(t, 3, second.(*logsRequest).ld.LogRecordCount())
+	// The original data is not modified.
+	assert.Equal(t, 5, data.LogRecordCount())
+
+	_, _, ok = newLogsRequest(context.Background(), testdata.GenerateLogsOneLogRecord(), nil).split()
+	assert.False(t, ok)
+}
+
 func TestLogsExporter_InvalidName(t *testing.T) {
 	le, err := NewLogsExporter(nil, zap.NewNop(), newPushLogsData(nil))
 	require.Nil(t, le)
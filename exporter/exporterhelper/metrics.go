@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumerhelper"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/batchsplit"
 	"go.opentelemetry.io/collector/obsreport"
 )
 
@@ -43,6 +44,16 @@ func newMetricsRequest(ctx context.Context, md pdata.Metrics, pusher consumerhel
 	}
 }
 
+func newMetricsRequestUnmarshalerFunc(pusher consumerhelper.ConsumeMetricsFunc) requestUnmarshaler {
+	return func(bytes []byte) (request, error) {
+		md, err := pdata.MetricsFromOtlpProtoBytes(bytes)
+		if err != nil {
+			return nil, err
+		}
+		return newMetricsRequest(context.Background(), md, pusher), nil
+	}
+}
+
 func (req *metricsRequest) onError(err error) request {
 	var metricsError consumererror.Metrics
 	if consumererror.AsMetrics(err, &metricsError) {
@@ -60,6 +71,80 @@ func (req *metricsRequest) count() int {
 	return numPoints
 }
 
+func (req *metricsRequest) size() int {
+	return req.md.OtlpProtoSize()
+}
+
+func (req *metricsRequest) marshal() ([]byte, error) {
+	return req.md.ToOtlpProtoBytes()
+}
+
+func (req *metricsRequest) split() (request, request, bool) {
+	n, numPoints := req.md.MetricAndDataPointCount()
+	if n < 2 {
+		// A single metric with too many data points is split by data points.
+		if numPoints < 2 {
+			return nil, nil, false
+		}
+		first, second := req.md.Clone(), req.md.Clone()
+		removeDataPoints(first, func(i int) bool { return i >= numPoints/2 })
+		removeDataPoints(second, func(i int) bool { return i < numPoints/2 })
+		return newMetricsRequest(req.ctx, first, req.pusher), newMetricsRequest(req.ctx, second, req.pusher), true
+	}
+	// The data may be shared with other consumers, so split a copy.
+	second := req.md.Clone()
+	first := batchsplit.SplitMetrics(n/2, second)
+	return newMetricsRequest(req.ctx, first, req.pusher), newMetricsRequest(req.ctx, second, req.pusher), true
+}
+
+// removeDataPoints removes the data points for which remove returns true, given their index in the metrics.
+func removeDataPoints(md pdata.Metrics, remove func(i int) bool) {
+	i := 0
+	removeNext := func() bool {
+		i++
+		return remove(i - 1)
+	}
+	rms := md.ResourceMetrics()
+	for j := 0; j < rms.Len(); j++ {
+		ilms := rms.At(j).InstrumentationLibraryMetrics()
+		for k := 0; k < ilms.Len(); k++ {
+			ms := ilms.At(k).Metrics()
+			for l := 0; l < ms.Len(); l++ {
+				m := ms.At(l)
+				switch m.DataType() {
+				case pdata.MetricDataTypeIntGauge:
+					m.IntGauge().DataPoints().RemoveIf(func(pdata.IntDataPoint) bool { return removeNext() })
+				case pdata.MetricDataTypeDoubleGauge:
+					m.DoubleGauge().DataPoints().RemoveIf(func(pdata.DoubleDataPoint) bool { return removeNext() })
+				case pdata.MetricDataTypeIntSum:
+					m.IntSum().DataPoints().RemoveIf(func(pdata.IntDataPoint) bool { return removeNext() })
+				case pdata.MetricDataTypeDoubleSum:
+					m.DoubleSum().DataPoints().RemoveIf(func(pdata.DoubleDataPoint) bool { return removeNext() })
+				case pdata.MetricDataTypeIntHistogram:
+					m.IntHistogram().DataPoints().RemoveIf(func(pdata.IntHistogramDataPoint) bool { return removeNext() })
+				case pdata.MetricDataTypeHistogram:
+					m.Histogram().DataPoints().RemoveIf(func(pdata.HistogramDataPoint) bool { return removeNext() })
+				case pdata.MetricDataTypeSummary:
+					m.Summary().DataPoints().RemoveIf(func(pdata.SummaryDataPoint) bool { return removeNext() })
+				}
+			}
+		}
+	}
+}
+
+func (req *metricsRequest) deadLetter(ctx context.Context, exporter component.Exporter, annotate func(pdata.Resource)) error {
+	next, ok := exporter.(consumer.Metrics)
+	if !ok {
+		return errDeadLetterDataType
+	}
+	md := req.md.Clone()
+	rs := md.ResourceMetrics()
+	for i := 0; i < rs.Len(); i++ {
+		annotate(rs.At(i).Resource())
+	}
+	return next.ConsumeMetrics(ctx, md)
+}
+
 type metricsExporter struct {
 	*baseExporter
 	consumer.Metrics
@@ -85,7 +170,7 @@ func NewMetricsExporter(
 	}
 
 	bs := fromOptions(options...)
-	be := newBaseExporter(cfg, logger, bs)
+	be := newBaseExporter(cfg, logger, bs, config.MetricsDataType, newMetricsRequestUnmarshalerFunc(pusher))
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &metricsSenderWithObservability{
 			obsrep: obsreport.NewExporter(obsreport.ExporterSettings{
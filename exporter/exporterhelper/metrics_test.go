@@ -55,6 +55,29 @@ func TestMetricsRequest(t *testing.T) {
 	)
 }
 
+func TestMetricsRequest_Split(t *testing.T) {
+	data := testdata.GenerateMetricsManyMetricsSameResource(5)
+	first, second, ok := newMetricsRequest(context.Background(), data, nil).split()
+	require.True(t, ok)
+	assert.Equal(t, 2, first.(*metricsRequest).md.MetricCount())
+	assert.Equal(t, 3, second.(*metricsRequest).md.MetricCount())
+	// The original data is not modified.
+	assert.Equal(t, 5, data.MetricCount())
+
+	// A single metric is split by data points.
+	data = testdata.GenerateMetricsOneMetric()
+	first, second, ok = newMetricsRequest(context.Background(), data, nil).split()
+	require.True(t, ok)
+	assert.Equal(t, 1, first.count())
+	assert.Equal(t, 1, second.count())
+	assert.Equal(t, int64(123), first.(*metricsRequest).md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().DataPoints().At(0).Value())
+	assert.Equal(t, int64(456), second.(*metricsRequest).md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().DataPoints().At(0).Value())
+	assert.Equal(t, 2, newMetricsRequest(context.Background(), data, nil).count())
+
+	_, _, ok = newMetricsRequest(context.Background(), testdata.GenerateMetricsOneMetricOneDataPoint(), nil).split()
+	assert.False(t, ok)
+}
+
 func TestMetricsExporter_InvalidName(t *testing.T) {
 	me, err := NewMetricsExporter(nil, zap.NewNop(), newPushMetricsData(nil))
 	require.Nil(t, me)
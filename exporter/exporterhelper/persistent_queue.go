@@ -0,0 +1,271 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+const (
+	persistentItemExt    = ".req"
+	persistentItemTmpExt = ".tmp"
+)
+
+// consumersQueue is the queue used by the queuedRetrySender to buffer requests before they are sent.
+// It is implemented by the in-memory queue.BoundedQueue and by the persistentQueue.
+type consumersQueue interface {
+	// StartConsumers starts the given number of goroutines that call the callback for every item in the queue.
+	StartConsumers(num int, callback func(item interface{}))
+	// Produce adds an item to the queue, returns false if the item was not accepted.
+	Produce(item interface{}) bool
+	// Stop stops all the consumers.
+	Stop()
+	// Size returns the current number of items in the queue.
+	Size() int
+}
+
+// requestUnmarshaler recreates a request from the bytes produced by request.marshal.
+type requestUnmarshaler func([]byte) (request, error)
+
+// persistentItem is a request stored in the persistentQueue together with the file that backs it.
+type persistentItem struct {
+	path string
+	req  request
+}
+
+// persistentQueue is a consumersQueue that writes every request to a file in a local directory before
+// making it available to the consumers. The file is removed once the request was processed, so the requests
+// that are still in the queue when the collector stops (or crashes) are replayed by the next start.
+//
+// Requests that are being processed while the queue is stopped are kept on disk as well, which means the
+// delivery guarantee is at-least-once.
+type persistentQueue struct {
+	// nextSeq is the sequence number of the next produced request, first field to be 64-bit aligned for atomic operations.
+	nextSeq uint64
+	// loadedBytes is the size of the requests loaded from the directory at creation.
+	loadedBytes int64
+	logger      *zap.Logger
+	dir         string
+	capacity    int
+	unmarshaler requestUnmarshaler
+	items       chan *persistentItem
+	stopping    int32
+	stopCh      chan struct{}
+	stopOnce    sync.Once
+	stopWG      sync.WaitGroup
+}
+
+// newPersistentQueue creates a persistentQueue backed by the given directory, and loads all the requests
+// left in the directory by a previous run.
+func newPersistentQueue(dir string, capacity int, unmarshaler requestUnmarshaler, logger *zap.Logger) (*persistentQueue, error) {
+	if unmarshaler == nil {
+		return nil, errNilRequestUnmarshaler
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	loaded, err := loadPersistentItems(dir, unmarshaler, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	pq := &persistentQueue{
+		logger:      logger,
+		dir:         dir,
+		capacity:    capacity,
+		unmarshaler: unmarshaler,
+		// Make sure all the items left by a previous run fit, even if the capacity was decreased in between.
+		items:  make(chan *persistentItem, maxInt(capacity, len(loaded))),
+		stopCh: make(chan struct{}),
+	}
+	for _, item := range loaded {
+		pq.items <- item
+		pq.loadedBytes += int64(item.req.size())
+		if seq, _ := parseSequence(item.path); seq >= pq.nextSeq {
+			pq.nextSeq = seq + 1
+		}
+	}
+	if len(loaded) > 0 {
+		logger.Info("Loaded requests from the persistent sending_queue", zap.String("directory", dir), zap.Int("requests", len(loaded)))
+	}
+	return pq, nil
+}
+
+// loadPersistentItems reads all the requests stored in the directory, in the order they were produced.
+// Requests that cannot be read are logged and removed.
+func loadPersistentItems(dir string, unmarshaler requestUnmarshaler, logger *zap.Logger) ([]*persistentItem, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch filepath.Ext(entry.Name()) {
+		case persistentItemExt:
+			names = append(names, entry.Name())
+		case persistentItemTmpExt:
+			// Incomplete write from a previous run, the request was never accepted.
+			_ = os.Remove(filepath.Join(dir, entry.Name()))
+		}
+	}
+	// File names are fixed width, so the lexical order is the production order.
+	sort.Strings(names)
+
+	items := make([]*persistentItem, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		req, err := unmarshaler(buf)
+		if err != nil {
+			logger.Error("Dropping corrupted request from the persistent sending_queue", zap.String("file", path), zap.Error(err))
+			_ = os.Remove(path)
+			continue
+		}
+		items = append(items, &persistentItem{path: path, req: req})
+	}
+	return items, nil
+}
+
+// StartConsumers implements the consumersQueue interface.
+func (pq *persistentQueue) StartConsumers(num int, callback func(item interface{})) {
+	for i := 0; i < num; i++ {
+		pq.stopWG.Add(1)
+		go func() {
+			defer pq.stopWG.Done()
+			for {
+				select {
+				case item := <-pq.items:
+					// The item taken after prepareStop stays on disk, it is sent by the next start.
+					if atomic.LoadInt32(&pq.stopping) != 0 {
+						return
+					}
+					callback(item.req)
+					pq.finish(item)
+				case <-pq.stopCh:
+					return
+				}
+			}
+		}()
+	}
+}
+
+// finish removes the file of a processed item, unless the queue is stopping in which case the processing
+// may have been interrupted and the item has to be replayed on the next start.
+func (pq *persistentQueue) finish(item *persistentItem) {
+	if atomic.LoadInt32(&pq.stopping) != 0 {
+		return
+	}
+	if err := os.Remove(item.path); err != nil {
+		pq.logger.Error("Failed to remove request from the persistent sending_queue", zap.String("file", item.path), zap.Error(err))
+	}
+}
+
+// Produce implements the consumersQueue interface.
+func (pq *persistentQueue) Produce(item interface{}) bool {
+	if atomic.LoadInt32(&pq.stopping) != 0 || len(pq.items) >= pq.capacity {
+		return false
+	}
+
+	req := item.(request)
+	buf, err := req.marshal()
+	if err != nil {
+		pq.logger.Error("Failed to marshal request for the persistent sending_queue", zap.Error(err))
+		return false
+	}
+
+	path := filepath.Join(pq.dir, formatSequence(atomic.AddUint64(&pq.nextSeq, 1)-1))
+	// Write to a temporary file first, so that a crash never leaves a partially written request behind.
+	tmpPath := strings.TrimSuffix(path, persistentItemExt) + persistentItemTmpExt
+	if err = ioutil.WriteFile(tmpPath, buf, 0600); err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		pq.logger.Error("Failed to write request to the persistent sending_queue", zap.Error(err))
+		_ = os.Remove(tmpPath)
+		return false
+	}
+
+	select {
+	case pq.items <- &persistentItem{path: path, req: req}:
+		return true
+	default:
+		// Lost the race for the last free slot.
+		_ = os.Remove(path)
+		return false
+	}
+}
+
+// prepareStop marks the queue as stopping, so that the requests that are processed from now on stay on disk.
+// It must be called before interrupting the consumers.
+func (pq *persistentQueue) prepareStop() {
+	atomic.StoreInt32(&pq.stopping, 1)
+}
+
+// Stop implements the consumersQueue interface. Unlike the in-memory queue, the remaining items are not
+// drained, they stay on disk and are loaded by the next start.
+func (pq *persistentQueue) Stop() {
+	pq.prepareStop()
+	pq.stopOnce.Do(func() {
+		close(pq.stopCh)
+	})
+	pq.stopWG.Wait()
+}
+
+// Size implements the consumersQueue interface.
+func (pq *persistentQueue) Size() int {
+	return len(pq.items)
+}
+
+func formatSequence(seq uint64) string {
+	return fmt.Sprintf("%020d%s", seq, persistentItemExt)
+}
+
+func parseSequence(path string) (uint64, error) {
+	return strconv.ParseUint(strings.TrimSuffix(filepath.Base(path), persistentItemExt), 10, 64)
+}
+
+// persistentQueueDirectory returns the directory used by the exporter with the given full name for the given
+// data type. Every exporter and data type needs its own directory, since the same exporter configuration can be
+// used in multiple pipelines.
+func persistentQueueDirectory(baseDir string, fullName string, dataType string) string {
+	return filepath.Join(baseDir, strings.ReplaceAll(fullName, "/", "_")+"_"+dataType)
+}
+
+// maxInt returns the larger of x or y.
+func maxInt(x, y int) int {
+	if x < y {
+		return y
+	}
+	return x
+}
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func newPersistentQueueSettings(dir string) QueueSettings {
+	qCfg := DefaultQueueSettings()
+	qCfg.PersistentDirectory = dir
+	return qCfg
+}
+
+func countingTracesPusher(spans *int64, err error) func(context.Context, pdata.Traces) error {
+	return func(_ context.Context, td pdata.Traces) error {
+		atomic.AddInt64(spans, int64(td.SpanCount()))
+		return err
+	}
+}
+
+func TestPersistentQueue_ReplayAfterRestart(t *testing.T) {
+	qCfg := newPersistentQueueSettings(t.TempDir())
+	qCfg.NumConsumers = 0 // keep every request in the queue
+
+	var sentSpans int64
+	te, err := NewTracesExporter(&defaultExporterCfg, zap.NewNop(), countingTracesPusher(&sentSpans, nil), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResource()))
+	}
+	require.NoError(t, te.Shutdown(context.Background()))
+	assert.EqualValues(t, 0, atomic.LoadInt64(&sentSpans))
+
+	qCfg.NumConsumers = 1
+	te, err = NewTracesExporter(&defaultExporterCfg, zap.NewNop(), countingTracesPusher(&sentSpans, nil), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt64(&sentSpans) == 6
+	}, time.Second, time.Millisecond)
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	dir := persistentQueueDirectory(qCfg.PersistentDirectory, defaultExporterCfg.ID().String(), string(config.TracesDataType))
+	assertPersistentFiles(t, dir, 0)
+}
+
+func TestPersistentQueue_KeepInterruptedRequests(t *testing.T) {
+	qCfg := newPersistentQueueSettings(t.TempDir())
+	qCfg.NumConsumers = 1
+
+	var sentSpans int64
+	te, err := NewTracesExporter(&defaultExporterCfg, zap.NewNop(), countingTracesPusher(&sentSpans, errors.New("transient error")),
+		WithQueue(qCfg), WithRetry(DefaultRetrySettings()))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+
+	// Wait for the first attempt, the retry then waits for the backoff and gets interrupted by the shutdown.
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt64(&sentSpans) == 1
+	}, time.Second, time.Millisecond)
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	dir := persistentQueueDirectory(qCfg.PersistentDirectory, defaultExporterCfg.ID().String(), string(config.TracesDataType))
+	assertPersistentFiles(t, dir, 1)
+}
+
+func TestPersistentQueue_DropCorruptedAndIncompleteFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, formatSequence(0)), []byte("not a proto"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "00000000000000000001"+persistentItemTmpExt), []byte{}, 0600))
+	buf, err := testdata.GenerateTracesOneSpan().ToOtlpProtoBytes()
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, formatSequence(7)), buf, 0600))
+
+	pq, err := newPersistentQueue(dir, 10, newTracesRequestUnmarshalerFunc(nil), zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, 1, pq.Size())
+	assert.EqualValues(t, 8, pq.nextSeq)
+	assert.EqualValues(t, len(buf), pq.loadedBytes)
+	assertPersistentFiles(t, dir, 1)
+	pq.Stop()
+}
+
+func TestPersistentQueue_Full(t *testing.T) {
+	dir := t.TempDir()
+	pq, err := newPersistentQueue(dir, 1, newTracesRequestUnmarshalerFunc(nil), zap.NewNop())
+	require.NoError(t, err)
+
+	assert.True(t, pq.Produce(newTracesRequest(context.Background(), testdata.GenerateTracesOneSpan(), nil)))
+	assert.False(t, pq.Produce(newTracesRequest(context.Background(), testdata.GenerateTracesOneSpan(), nil)))
+	assert.Equal(t, 1, pq.Size())
+	assertPersistentFiles(t, dir, 1)
+
+	pq.Stop()
+	assert.False(t, pq.Produce(newTracesRequest(context.Background(), testdata.GenerateTracesOneSpan(), nil)))
+}
+
+func TestPersistentQueue_NoConsumeWhenStopping(t *testing.T) {
+	dir := t.TempDir()
+	pq, err := newPersistentQueue(dir, 10, newTracesRequestUnmarshalerFunc(nil), zap.NewNop())
+	require.NoError(t, err)
+	require.True(t, pq.Produce(newTracesRequest(context.Background(), testdata.GenerateTracesOneSpan(), nil)))
+
+	// The items taken by the consumers once the queue is stopping are not sent, since they stay on disk.
+	pq.prepareStop()
+	var consumed int64
+	pq.StartConsumers(1, func(interface{}) {
+		atomic.AddInt64(&consumed, 1)
+	})
+	assert.Eventually(t, func() bool {
+		return pq.Size() == 0
+	}, time.Second, time.Millisecond)
+	pq.Stop()
+	assert.EqualValues(t, 0, atomic.LoadInt64(&consumed))
+	assertPersistentFiles(t, dir, 1)
+}
+
+func TestPersistentQueue_NilUnmarshaler(t *testing.T) {
+	_, err := newPersistentQueue(t.TempDir(), 1, nil, zap.NewNop())
+	assert.Equal(t, errNilRequestUnmarshaler, err)
+}
+
+func TestPersistentQueue_InvalidDirectory(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, ioutil.WriteFile(file, []byte{}, 0600))
+
+	te, err := NewTracesExporter(&defaultExporterCfg, zap.NewNop(), newTraceDataPusher(nil), WithQueue(newPersistentQueueSettings(file)))
+	require.NoError(t, err)
+	assert.Error(t, te.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func assertPersistentFiles(t *testing.T, dir string, want int) {
+	entries, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	got := 0
+	for _, entry := range entries {
+		if entry.Mode().IsRegular() && filepath.Ext(entry.Name()) == persistentItemExt {
+			got++
+		}
+	}
+	assert.Equal(t, want, got)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -29,6 +30,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
 )
@@ -41,6 +43,24 @@ var (
 		metric.WithDescription("Current size of the retry queue (in batches)"),
 		metric.WithLabelKeys(obsreport.ExporterKey),
 		metric.WithUnit(metricdata.UnitDimensionless))
+
+	queueSizeBytesGauge, _ = r.AddInt64DerivedGauge(
+		obsreport.ExporterKey+"/queue_size_bytes",
+		metric.WithDescription("Current size of the retry queue (in bytes)"),
+		metric.WithLabelKeys(obsreport.ExporterKey),
+		metric.WithUnit(metricdata.UnitBytes))
+
+	circuitBreakerStateGauge, _ = r.AddInt64DerivedGauge(
+		obsreport.ExporterKey+"/circuit_breaker_state",
+		metric.WithDescription("Current state of the circuit breaker (0 closed, 1 open, 2 half-open)"),
+		metric.WithLabelKeys(obsreport.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	concurrencyGauge, _ = r.AddInt64DerivedGauge(
+		obsreport.ExporterKey+"/concurrency",
+		metric.WithDescription("Current limit of concurrent requests sent to the backend"),
+		metric.WithLabelKeys(obsreport.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
 )
 
 func init() {
@@ -55,6 +75,15 @@ type QueueSettings struct {
 	NumConsumers int `mapstructure:"num_consumers"`
 	// QueueSize is the maximum number of batches allowed in queue at a given time.
 	QueueSize int `mapstructure:"queue_size"`
+	// QueueSizeBytes is the maximum size in bytes of all the batches allowed in queue at a given time.
+	// The size of a batch is the size of its OTLP protobuf encoding. Zero means no limit.
+	QueueSizeBytes int64 `mapstructure:"queue_size_bytes"`
+	// PersistentDirectory is the local directory where the queued batches are stored. If not empty, batches that
+	// were not yet sent survive a restart or crash of the collector, and are sent when the exporter starts again.
+	PersistentDirectory string `mapstructure:"persistent_directory"`
+	// AdaptiveConcurrency adapts the number of concurrent requests to the latency and errors of the backend,
+	// NumConsumers is then the maximum number of concurrent requests.
+	AdaptiveConcurrency AdaptiveConcurrencySettings `mapstructure:"adaptive_concurrency"`
 }
 
 // DefaultQueueSettings returns the default settings for QueueSettings.
@@ -96,10 +125,17 @@ func DefaultRetrySettings() RetrySettings {
 }
 
 type queuedRetrySender struct {
+	// queuedBytes is the size of all the requests in the queue, first field to be 64-bit aligned for atomic operations.
+	queuedBytes     int64
 	fullName        string
+	dataType        config.DataType
 	cfg             QueueSettings
 	consumerSender  requestSender
-	queue           *queue.BoundedQueue
+	circuitBreaker  *circuitBreakerSender
+	limiter         *concurrencyLimiter
+	deadLetter      *deadLetterSender
+	queue           consumersQueue
+	reqUnmarshaler  requestUnmarshaler
 	retryStopCh     chan struct{}
 	traceAttributes []trace.Attribute
 	logger          *zap.Logger
@@ -124,21 +160,54 @@ func createSampledLogger(logger *zap.Logger) *zap.Logger {
 	return logger.WithOptions(opts)
 }
 
-func newQueuedRetrySender(fullName string, qCfg QueueSettings, rCfg RetrySettings, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
+func newQueuedRetrySender(
+	fullName string,
+	dataType config.DataType,
+	qCfg QueueSettings,
+	rCfg RetrySettings,
+	cbCfg CircuitBreakerSettings,
+	deadLetter *deadLetterSender,
+	reqUnmarshaler requestUnmarshaler,
+	nextSender requestSender,
+	logger *zap.Logger,
+) *queuedRetrySender {
 	retryStopCh := make(chan struct{})
 	sampledLogger := createSampledLogger(logger)
 	traceAttr := trace.StringAttribute(obsreport.ExporterKey, fullName)
+
+	// The concurrency limiter measures every attempt, the waiting for a slot is not part of the latency.
+	var limiter *concurrencyLimiter
+	if qCfg.Enabled && qCfg.AdaptiveConcurrency.Enabled {
+		limiter = newConcurrencyLimiter(qCfg.AdaptiveConcurrency, qCfg.NumConsumers, nextSender, retryStopCh, logger)
+		nextSender = limiter
+	}
+
+	// The circuit breaker sits between the retries and the attempts, so that every attempt is accounted.
+	var circuitBreaker *circuitBreakerSender
+	if cbCfg.Enabled {
+		circuitBreaker = newCircuitBreakerSender(cbCfg, traceAttr, nextSender, retryStopCh, logger)
+		nextSender = circuitBreaker
+	}
+
 	return &queuedRetrySender{
-		fullName: fullName,
-		cfg:      qCfg,
+		fullName:       fullName,
+		dataType:       dataType,
+		cfg:            qCfg,
+		circuitBreaker: circuitBreaker,
+		limiter:        limiter,
+		deadLetter:     deadLetter,
 		consumerSender: &retrySender{
 			traceAttribute: traceAttr,
 			cfg:            rCfg,
 			nextSender:     nextSender,
+			deadLetter:     deadLetter,
+			// The requests interrupted by the shutdown stay in the persistent queue, they are not dropped.
+			keepOnShutdown: qCfg.Enabled && qCfg.PersistentDirectory != "",
 			stopCh:         retryStopCh,
 			logger:         sampledLogger,
 		},
 		queue:           queue.NewBoundedQueue(qCfg.QueueSize, func(item interface{}) {}),
+		reqUnmarshaler:  reqUnmarshaler,
 		retryStopCh:     retryStopCh,
 		traceAttributes: []trace.Attribute{traceAttr},
 		logger:          sampledLogger,
@@ -147,9 +216,26 @@ func newQueuedRetrySender(fullName string, qCfg QueueSettings, rCfg RetrySetting
 
 // start is invoked during service startup.
 func (qrs *queuedRetrySender) start() error {
+	if qrs.cfg.Enabled && qrs.cfg.PersistentDirectory != "" {
+		dir := persistentQueueDirectory(qrs.cfg.PersistentDirectory, qrs.fullName, string(qrs.dataType))
+		pq, err := newPersistentQueue(dir, qrs.cfg.QueueSize, qrs.reqUnmarshaler, qrs.logger)
+		if err != nil {
+			return fmt.Errorf("failed to open persistent sending_queue: %w", err)
+		}
+		qrs.queue = pq
+		if qrs.cfg.QueueSizeBytes > 0 {
+			atomic.AddInt64(&qrs.queuedBytes, pq.loadedBytes)
+		}
+	}
+
 	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item interface{}) {
 		req := item.(request)
+		// The request is not modified while in the queue, so this is the size accounted by send.
+		size := qrs.requestSize(req)
 		_ = qrs.consumerSender.send(req)
+		if size > 0 {
+			atomic.AddInt64(&qrs.queuedBytes, -size)
+		}
 	})
 
 	// Start reporting queue length metric
@@ -161,6 +247,34 @@ func (qrs *queuedRetrySender) start() error {
 			return fmt.Errorf("failed to create retry queue size metric: %v", err)
 		}
 	}
+	if qrs.cfg.Enabled && qrs.cfg.QueueSizeBytes > 0 {
+		err := queueSizeBytesGauge.UpsertEntry(func() int64 {
+			return atomic.LoadInt64(&qrs.queuedBytes)
+		}, metricdata.NewLabelValue(qrs.fullName))
+		if err != nil {
+			return fmt.Errorf("failed to create retry queue size bytes metric: %v", err)
+		}
+	}
+
+	// Start reporting concurrency metric
+	if qrs.limiter != nil {
+		err := concurrencyGauge.UpsertEntry(func() int64 {
+			return int64(qrs.limiter.currentConcurrency())
+		}, metricdata.NewLabelValue(qrs.fullName))
+		if err != nil {
+			return fmt.Errorf("failed to create concurrency metric: %v", err)
+		}
+	}
+
+	// Start reporting circuit breaker state metric
+	if qrs.circuitBreaker != nil {
+		err := circuitBreakerStateGauge.UpsertEntry(func() int64 {
+			return int64(qrs.circuitBreaker.currentState())
+		}, metricdata.NewLabelValue(qrs.fullName))
+		if err != nil {
+			return fmt.Errorf("failed to create circuit breaker state metric: %v", err)
+		}
+	}
 
 	return nil
 }
@@ -183,19 +297,45 @@ func (qrs *queuedRetrySender) send(req request) error {
 	req.setContext(noCancellationContext{Context: req.context()})
 
 	span := trace.FromContext(req.context())
+	size := qrs.requestSize(req)
+	if size > 0 && atomic.AddInt64(&qrs.queuedBytes, size) > qrs.cfg.QueueSizeBytes {
+		atomic.AddInt64(&qrs.queuedBytes, -size)
+		qrs.logger.Error(
+			"Dropping data because sending_queue is full. Try increasing queue_size_bytes.",
+			zap.Int("dropped_items", req.count()),
+			zap.Int64("dropped_bytes", size),
+		)
+		span.Annotate(qrs.traceAttributes, "Dropped item, sending_queue is full.")
+		err := errors.New("sending_queue is full")
+		qrs.deadLetter.send(req, err)
+		return err
+	}
+
 	if !qrs.queue.Produce(req) {
+		atomic.AddInt64(&qrs.queuedBytes, -size)
 		qrs.logger.Error(
 			"Dropping data because sending_queue is full. Try increasing queue_size.",
 			zap.Int("dropped_items", req.count()),
 		)
 		span.Annotate(qrs.traceAttributes, "Dropped item, sending_queue is full.")
-		return errors.New("sending_queue is full")
+		err := errors.New("sending_queue is full")
+		qrs.deadLetter.send(req, err)
+		return err
 	}
 
 	span.Annotate(qrs.traceAttributes, "Enqueued item.")
 	return nil
 }
 
+// requestSize returns the size accounted in the queue for the request. It is only computed if the queue is limited
+// in bytes, since it requires a full pass over the request.
+func (qrs *queuedRetrySender) requestSize(req request) int64 {
+	if qrs.cfg.QueueSizeBytes <= 0 {
+		return 0
+	}
+	return int64(req.size())
+}
+
 // shutdown is invoked during service shutdown.
 func (qrs *queuedRetrySender) shutdown() {
 	// Cleanup queue metrics reporting
@@ -204,12 +344,32 @@ func (qrs *queuedRetrySender) shutdown() {
 			return int64(0)
 		}, metricdata.NewLabelValue(qrs.fullName))
 	}
+	if qrs.cfg.Enabled && qrs.cfg.QueueSizeBytes > 0 {
+		_ = queueSizeBytesGauge.UpsertEntry(func() int64 {
+			return int64(0)
+		}, metricdata.NewLabelValue(qrs.fullName))
+	}
+	if qrs.limiter != nil {
+		_ = concurrencyGauge.UpsertEntry(func() int64 {
+			return int64(0)
+		}, metricdata.NewLabelValue(qrs.fullName))
+	}
+	if qrs.circuitBreaker != nil {
+		_ = circuitBreakerStateGauge.UpsertEntry(func() int64 {
+			return int64(circuitClosed)
+		}, metricdata.NewLabelValue(qrs.fullName))
+	}
+
+	// Requests interrupted by the shutdown must stay in the persistent queue, so they are sent by the next start.
+	if pq, ok := qrs.queue.(*persistentQueue); ok {
+		pq.prepareStop()
+	}
 
 	// First stop the retry goroutines, so that unblocks the queue workers.
 	close(qrs.retryStopCh)
 
 	// Stop the queued sender, this will drain the queue and will call the retry (which is stopped) that will only
-	// try once every request.
+	// try once every request. The persistent queue is not drained, the remaining requests stay on disk.
 	qrs.queue.Stop()
 }
 
@@ -219,7 +379,8 @@ type throttleRetry struct {
 	delay time.Duration
 }
 
-// NewThrottleRetry creates a new throttle retry error.
+// NewThrottleRetry creates a new throttle retry error. The delay is the time the backend asked to wait before
+// retrying (e.g. gRPC RetryInfo or HTTP Retry-After), a zero delay falls back to the configured backoff.
 func NewThrottleRetry(err error, delay time.Duration) error {
 	return &throttleRetry{
 		error: err,
@@ -227,10 +388,50 @@ func NewThrottleRetry(err error, delay time.Duration) error {
 	}
 }
 
+// Unwrap returns the wrapped error for functions Is and As in standard errors package.
+func (t *throttleRetry) Unwrap() error {
+	return t.error
+}
+
+// throttleDelay returns the delay requested by the backend if the error, or any error it wraps, is a throttle retry error.
+func throttleDelay(err error) (time.Duration, bool) {
+	var throttleErr *throttleRetry
+	if errors.As(err, &throttleErr) && throttleErr.delay > 0 {
+		return throttleErr.delay, true
+	}
+	return 0, false
+}
+
+type requestTooLarge struct {
+	error
+}
+
+// NewRequestTooLarge creates a new error that indicates the backend rejected the request because of its size,
+// e.g. HTTP 413 Request Entity Too Large. The request is split in halves that are sent independently, a request
+// that cannot be split any further is dropped like for a permanent error.
+func NewRequestTooLarge(err error) error {
+	return &requestTooLarge{
+		error: err,
+	}
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard errors package.
+func (t *requestTooLarge) Unwrap() error {
+	return t.error
+}
+
+// isRequestTooLarge returns true if the error, or any error it wraps, is a request too large error.
+func isRequestTooLarge(err error) bool {
+	var tooLargeErr *requestTooLarge
+	return errors.As(err, &tooLargeErr)
+}
+
 type retrySender struct {
 	traceAttribute trace.Attribute
 	cfg            RetrySettings
 	nextSender     requestSender
+	deadLetter     *deadLetterSender
+	keepOnShutdown bool
 	stopCh         chan struct{}
 	logger         *zap.Logger
 }
@@ -239,11 +440,19 @@ type retrySender struct {
 func (rs *retrySender) send(req request) error {
 	if !rs.cfg.Enabled {
 		err := rs.nextSender.send(req)
+		if isRequestTooLarge(err) {
+			if split, halvesErr := rs.sendHalves(req, err); split {
+				return halvesErr
+			}
+			// Sending the request again does not help if it cannot be split any further.
+			err = consumererror.Permanent(err)
+		}
 		if err != nil {
 			rs.logger.Error(
 				"Exporting failed. Try enabling retry_on_failure config option.",
 				zap.Error(err),
 			)
+			rs.deadLetter.send(req, err)
 		}
 		return err
 	}
@@ -274,6 +483,14 @@ func (rs *retrySender) send(req request) error {
 			return nil
 		}
 
+		if isRequestTooLarge(err) {
+			if split, halvesErr := rs.sendHalves(req, err); split {
+				return halvesErr
+			}
+			// Retrying does not help if the request cannot be split any further.
+			err = consumererror.Permanent(err)
+		}
+
 		// Immediately drop data on permanent errors.
 		if consumererror.IsPermanent(err) {
 			rs.logger.Error(
@@ -281,6 +498,7 @@ func (rs *retrySender) send(req request) error {
 				zap.Error(err),
 				zap.Int("dropped_items", req.count()),
 			)
+			rs.deadLetter.send(req.onError(err), err)
 			return err
 		}
 
@@ -297,11 +515,17 @@ func (rs *retrySender) send(req request) error {
 				zap.Error(err),
 				zap.Int("dropped_items", req.count()),
 			)
+			rs.deadLetter.send(req, err)
 			return err
 		}
 
-		if throttleErr, isThrottle := err.(*throttleRetry); isThrottle {
-			backoffDelay = max(backoffDelay, throttleErr.delay)
+		// Wait as long as the backend asked for, instead of the backoff computed on our own. The delay is only
+		// bounded by the time left before MaxElapsedTime, so that the backend cannot block the consumer forever.
+		if delay, isThrottle := throttleDelay(err); isThrottle {
+			if remaining := rs.cfg.MaxElapsedTime - expBackoff.GetElapsedTime(); rs.cfg.MaxElapsedTime > 0 && delay > remaining {
+				delay = remaining
+			}
+			backoffDelay = delay
 		}
 
 		backoffDelayStr := backoffDelay.String()
@@ -323,18 +547,36 @@ func (rs *retrySender) send(req request) error {
 		case <-req.context().Done():
 			return fmt.Errorf("request is cancelled or timed out %w", err)
 		case <-rs.stopCh:
-			return fmt.Errorf("interrupted due to shutdown %w", err)
+			err = fmt.Errorf("interrupted due to shutdown %w", err)
+			if !rs.keepOnShutdown {
+				rs.deadLetter.send(req, err)
+			}
+			return err
 		case <-time.After(backoffDelay):
 		}
 	}
 }
 
-// max returns the larger of x or y.
-func max(x, y time.Duration) time.Duration {
-	if x < y {
-		return y
+// sendHalves splits the request that the backend rejected because of its size in halves, and sends them independently.
+// It returns false if the request cannot be split any further.
+func (rs *retrySender) sendHalves(req request, err error) (bool, error) {
+	first, second, ok := req.split()
+	if !ok {
+		return false, nil
+	}
+
+	rs.logger.Info(
+		"Exporting failed. The request is too large, will send it in two halves.",
+		zap.Error(err),
+		zap.Int("items", req.count()),
+	)
+	var errs []error
+	for _, half := range []request{first, second} {
+		if halfErr := rs.send(half); halfErr != nil {
+			errs = append(errs, halfErr)
+		}
 	}
-	return x
+	return true, consumererror.Combine(errs)
 }
 
 type noCancellationContext struct {
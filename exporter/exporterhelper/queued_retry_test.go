@@ -30,8 +30,10 @@ import (
 	"go.opencensus.io/tag"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 )
@@ -39,7 +41,7 @@ import (
 func TestQueuedRetry_DropOnPermanentError(t *testing.T) {
 	qCfg := DefaultQueueSettings()
 	rCfg := DefaultRetrySettings()
-	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)))
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
 	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
 	be.qrSender.consumerSender = ocs
 	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
@@ -63,7 +65,7 @@ func TestQueuedRetry_DropOnNoRetry(t *testing.T) {
 	qCfg := DefaultQueueSettings()
 	rCfg := DefaultRetrySettings()
 	rCfg.Enabled = false
-	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)))
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
 	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
 	be.qrSender.consumerSender = ocs
 	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
@@ -88,7 +90,7 @@ func TestQueuedRetry_OnError(t *testing.T) {
 	qCfg.NumConsumers = 1
 	rCfg := DefaultRetrySettings()
 	rCfg.InitialInterval = 0
-	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)))
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
 	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
 	be.qrSender.consumerSender = ocs
 	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
@@ -114,7 +116,7 @@ func TestQueuedRetry_StopWhileWaiting(t *testing.T) {
 	qCfg := DefaultQueueSettings()
 	qCfg.NumConsumers = 1
 	rCfg := DefaultRetrySettings()
-	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)))
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
 	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
 	be.qrSender.consumerSender = ocs
 	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
@@ -147,7 +149,7 @@ func TestQueuedRetry_DoNotPreserveCancellation(t *testing.T) {
 	qCfg := DefaultQueueSettings()
 	qCfg.NumConsumers = 1
 	rCfg := DefaultRetrySettings()
-	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)))
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
 	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
 	be.qrSender.consumerSender = ocs
 	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
@@ -176,7 +178,7 @@ func TestQueuedRetry_MaxElapsedTime(t *testing.T) {
 	rCfg := DefaultRetrySettings()
 	rCfg.InitialInterval = time.Millisecond
 	rCfg.MaxElapsedTime = 100 * time.Millisecond
-	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)))
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
 	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
 	be.qrSender.consumerSender = ocs
 	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
@@ -214,7 +216,7 @@ func TestQueuedRetry_ThrottleError(t *testing.T) {
 	qCfg.NumConsumers = 1
 	rCfg := DefaultRetrySettings()
 	rCfg.InitialInterval = 10 * time.Millisecond
-	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)))
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
 	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
 	be.qrSender.consumerSender = ocs
 	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
@@ -239,13 +241,215 @@ func TestQueuedRetry_ThrottleError(t *testing.T) {
 	require.Zero(t, be.qrSender.queue.Size())
 }
 
+func TestQueuedRetry_ThrottleErrorShorterThanBackoff(t *testing.T) {
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = time.Minute
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The throttle error can be wrapped by the exporter.
+	throttleErr := fmt.Errorf("wrapped: %w", NewThrottleRetry(errors.New("throttle error"), 10*time.Millisecond))
+	mockR := newMockRequest(context.Background(), 2, throttleErr)
+	start := time.Now()
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.sender.send(mockR))
+	})
+	ocs.awaitAsyncProcessing()
+
+	// The initial backoff is 1 minute, but the backend asked to retry after 10ms.
+	assert.Less(t, int64(time.Since(start)), int64(10*time.Second))
+
+	mockR.checkNumRequests(t, 2)
+	ocs.checkSendItemsCount(t, 2)
+	ocs.checkDroppedItemsCount(t, 0)
+}
+
+func TestQueuedRetry_ThrottleErrorLongerThanMaxInterval(t *testing.T) {
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.MaxInterval = time.Millisecond
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The delay asked by the backend is not capped by max_interval.
+	retry := 100 * time.Millisecond
+	mockR := newMockRequest(context.Background(), 2, NewThrottleRetry(errors.New("throttle error"), retry))
+	start := time.Now()
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.sender.send(mockR))
+	})
+	ocs.awaitAsyncProcessing()
+
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(retry))
+	mockR.checkNumRequests(t, 2)
+	ocs.checkSendItemsCount(t, 2)
+	ocs.checkDroppedItemsCount(t, 0)
+}
+
+func TestQueuedRetry_ThrottleErrorCappedByMaxElapsedTime(t *testing.T) {
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := DefaultRetrySettings()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.MaxElapsedTime = 10 * time.Millisecond
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
+	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
+	be.qrSender.consumerSender = ocs
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The backend asks to retry after a day, the delay is capped by the time left before max_elapsed_time.
+	mockR := newMockRequest(context.Background(), 2, NewThrottleRetry(errors.New("throttle error"), 24*time.Hour))
+	start := time.Now()
+	ocs.run(func() {
+		// This is asynchronous so it should just enqueue, no errors expected.
+		require.NoError(t, be.sender.send(mockR))
+	})
+	ocs.awaitAsyncProcessing()
+
+	assert.Less(t, int64(time.Since(start)), int64(10*time.Second))
+	mockR.checkNumRequests(t, 2)
+	ocs.checkSendItemsCount(t, 2)
+	ocs.checkDroppedItemsCount(t, 0)
+}
+
+func TestThrottleDelay(t *testing.T) {
+	_, ok := throttleDelay(errors.New("not a throttle"))
+	assert.False(t, ok)
+
+	_, ok = throttleDelay(NewThrottleRetry(errors.New("no delay"), 0))
+	assert.False(t, ok)
+
+	delay, ok := throttleDelay(consumererror.Permanent(NewThrottleRetry(errors.New("throttle"), time.Second)))
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, delay)
+
+	err := errors.New("inner")
+	assert.True(t, errors.Is(NewThrottleRetry(err, time.Second), err))
+}
+
+func TestQueuedRetry_SplitRequestTooLarge(t *testing.T) {
+	for _, retry := range []bool{true, false} {
+		rCfg := DefaultRetrySettings()
+		rCfg.Enabled = retry
+		var sentSpans int64
+		var attempts int64
+		te, err := NewTracesExporter(&defaultExporterCfg, zap.NewNop(), func(_ context.Context, td pdata.Traces) error {
+			atomic.AddInt64(&attempts, 1)
+			if td.SpanCount() > 2 {
+				return NewRequestTooLarge(errors.New("413"))
+			}
+			atomic.AddInt64(&sentSpans, int64(td.SpanCount()))
+			return nil
+		}, WithRetry(rCfg))
+		require.NoError(t, err)
+		require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+		// 7 spans are split in 3 and 4, then in 1, 2, 2 and 2.
+		require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesManySpansSameResource(7)))
+		assert.EqualValues(t, 7, atomic.LoadInt64(&sentSpans))
+		assert.EqualValues(t, 7, atomic.LoadInt64(&attempts))
+		require.NoError(t, te.Shutdown(context.Background()))
+	}
+}
+
+func TestQueuedRetry_RequestTooLargeCannotSplit(t *testing.T) {
+	rCfg := DefaultRetrySettings()
+	rCfg.Enabled = false
+	var attempts int64
+	te, err := NewTracesExporter(&defaultExporterCfg, zap.NewNop(), func(context.Context, pdata.Traces) error {
+		atomic.AddInt64(&attempts, 1)
+		return NewRequestTooLarge(errors.New("413"))
+	}, WithRetry(rCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	// Split once in two single spans, that fail as well.
+	assert.Error(t, te.ConsumeTraces(context.Background(), testdata.GenerateTracesManySpansSameResource(2)))
+	assert.EqualValues(t, 3, atomic.LoadInt64(&attempts))
+	require.NoError(t, te.Shutdown(context.Background()))
+}
+
+func TestQueuedRetry_RequestTooLargeIsPermanent(t *testing.T) {
+	for _, retry := range []bool{true, false} {
+		rCfg := DefaultRetrySettings()
+		rCfg.Enabled = retry
+		var attempts int64
+		te, err := NewTracesExporter(&defaultExporterCfg, zap.NewNop(), func(context.Context, pdata.Traces) error {
+			atomic.AddInt64(&attempts, 1)
+			return NewRequestTooLarge(errors.New("413"))
+		}, WithRetry(rCfg))
+		require.NoError(t, err)
+		require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+		err = te.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan())
+		assert.True(t, consumererror.IsPermanent(err))
+		assert.True(t, isRequestTooLarge(err))
+		assert.EqualValues(t, 1, atomic.LoadInt64(&attempts))
+		require.NoError(t, te.Shutdown(context.Background()))
+	}
+}
+
+func TestQueuedRetry_SplitMetricDataPoints(t *testing.T) {
+	var sentPoints int64
+	var attempts int64
+	me, err := NewMetricsExporter(&defaultExporterCfg, zap.NewNop(), func(_ context.Context, md pdata.Metrics) error {
+		atomic.AddInt64(&attempts, 1)
+		_, numPoints := md.MetricAndDataPointCount()
+		if numPoints > 2 {
+			return NewRequestTooLarge(errors.New("413"))
+		}
+		atomic.AddInt64(&sentPoints, int64(numPoints))
+		return nil
+	}, WithRetry(DefaultRetrySettings()))
+	require.NoError(t, err)
+	require.NoError(t, me.Start(context.Background(), componenttest.NewNopHost()))
+
+	// A single metric with 5 data points is split in 2 and 3, then in 2, 1 and 2.
+	md := testdata.GenerateMetricsOneMetric()
+	dps := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().DataPoints()
+	for i := 0; i < 3; i++ {
+		dps.AppendEmpty().SetValue(int64(i))
+	}
+	require.NoError(t, me.ConsumeMetrics(context.Background(), md))
+	assert.EqualValues(t, 5, atomic.LoadInt64(&sentPoints))
+	assert.EqualValues(t, 5, atomic.LoadInt64(&attempts))
+	require.NoError(t, me.Shutdown(context.Background()))
+}
+
+func TestIsRequestTooLarge(t *testing.T) {
+	assert.False(t, isRequestTooLarge(errors.New("not too large")))
+	assert.True(t, isRequestTooLarge(consumererror.Permanent(NewRequestTooLarge(errors.New("413")))))
+
+	err := errors.New("inner")
+	assert.True(t, errors.Is(NewRequestTooLarge(err), err))
+}
+
 func TestQueuedRetry_RetryOnError(t *testing.T) {
 	qCfg := DefaultQueueSettings()
 	qCfg.NumConsumers = 1
 	qCfg.QueueSize = 1
 	rCfg := DefaultRetrySettings()
 	rCfg.InitialInterval = 0
-	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)))
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
 	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
 	be.qrSender.consumerSender = ocs
 	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
@@ -271,7 +475,7 @@ func TestQueuedRetry_DropOnFull(t *testing.T) {
 	qCfg := DefaultQueueSettings()
 	qCfg.QueueSize = 0
 	rCfg := DefaultRetrySettings()
-	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)))
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
 	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
 	be.qrSender.consumerSender = ocs
 	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
@@ -282,14 +486,34 @@ func TestQueuedRetry_DropOnFull(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestQueuedRetry_DropOnFullBytes(t *testing.T) {
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request go straight to the queue
+	qCfg.QueueSizeBytes = 50
+	rCfg := DefaultRetrySettings()
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// Every mockRequest is 10 bytes per item.
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 3, nil)))
+	require.Error(t, be.sender.send(newMockRequest(context.Background(), 3, nil)))
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, nil)))
+	assert.Equal(t, 2, be.qrSender.queue.Size())
+	assert.EqualValues(t, 50, be.qrSender.queuedBytes)
+}
+
 func TestQueuedRetryHappyPath(t *testing.T) {
 	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
 	require.NoError(t, err)
 	defer doneFn()
 
 	qCfg := DefaultQueueSettings()
+	qCfg.QueueSizeBytes = 1 << 20
 	rCfg := DefaultRetrySettings()
-	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)))
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
 	ocs := newObservabilityConsumerSender(be.qrSender.consumerSender)
 	be.qrSender.consumerSender = ocs
 	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
@@ -317,22 +541,44 @@ func TestQueuedRetryHappyPath(t *testing.T) {
 
 	ocs.checkSendItemsCount(t, 2*wantRequests)
 	ocs.checkDroppedItemsCount(t, 0)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt64(&be.qrSender.queuedBytes) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestQueuedRetry_NoBytesLimit(t *testing.T) {
+	qCfg := DefaultQueueSettings()
+	qCfg.NumConsumers = 0 // to make every request go straight to the queue
+	rCfg := DefaultRetrySettings()
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The size is not computed without queue_size_bytes.
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 3, nil)))
+	assert.Equal(t, 1, be.qrSender.queue.Size())
+	assert.EqualValues(t, 0, be.qrSender.queuedBytes)
 }
 
 func TestQueuedRetry_QueueMetricsReported(t *testing.T) {
 	qCfg := DefaultQueueSettings()
 	qCfg.NumConsumers = 0 // to make every request go straight to the queue
+	qCfg.QueueSizeBytes = 1 << 20
 	rCfg := DefaultRetrySettings()
-	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)))
+	be := newBaseExporter(&defaultExporterCfg, zap.NewNop(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nil)
 	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
 
 	for i := 0; i < 7; i++ {
 		require.NoError(t, be.sender.send(newErrorRequest(context.Background())))
 	}
 	checkValueForProducer(t, defaultExporterTags, int64(7), "exporter/queue_size")
+	checkValueForProducer(t, defaultExporterTags, int64(490), "exporter/queue_size_bytes")
 
 	assert.NoError(t, be.Shutdown(context.Background()))
 	checkValueForProducer(t, defaultExporterTags, int64(0), "exporter/queue_size")
+	checkValueForProducer(t, defaultExporterTags, int64(0), "exporter/queue_size_bytes")
 }
 
 func TestNoCancellationContext(t *testing.T) {
@@ -367,6 +613,22 @@ func (mer *mockErrorRequest) count() int {
 	return 7
 }
 
+func (mer *mockErrorRequest) size() int {
+	return 70
+}
+
+func (mer *mockErrorRequest) marshal() ([]byte, error) {
+	return nil, nil
+}
+
+func (mer *mockErrorRequest) split() (request, request, bool) {
+	return nil, nil, false
+}
+
+func (mer *mockErrorRequest) deadLetter(context.Context, component.Exporter, func(pdata.Resource)) error {
+	return nil
+}
+
 func newErrorRequest(ctx context.Context) request {
 	return &mockErrorRequest{
 		baseRequest: baseRequest{ctx: ctx},
@@ -413,6 +675,22 @@ func (m *mockRequest) count() int {
 	return m.cnt
 }
 
+func (m *mockRequest) size() int {
+	return 10 * m.cnt
+}
+
+func (m *mockRequest) marshal() ([]byte, error) {
+	return nil, nil
+}
+
+func (m *mockRequest) split() (request, request, bool) {
+	return nil, nil, false
+}
+
+func (m *mockRequest) deadLetter(context.Context, component.Exporter, func(pdata.Resource)) error {
+	return nil
+}
+
 func newMockRequest(ctx context.Context, cnt int, consumeError error) *mockRequest {
 	return &mockRequest{
 		baseRequest:  baseRequest{ctx: ctx},
@@ -467,13 +745,15 @@ func checkValueForProducer(t *testing.T, wantTags []tag.Tag, value int64, vName
 	producers := metricproducer.GlobalManager().GetAll()
 	for _, producer := range producers {
 		for _, metric := range producer.Read() {
-			if metric.Descriptor.Name == vName && len(metric.TimeSeries) > 0 {
-				lastValue := metric.TimeSeries[len(metric.TimeSeries)-1]
-				if tagsMatchLabelKeys(wantTags, metric.Descriptor.LabelKeys, lastValue.LabelValues) {
-					require.Equal(t, value, lastValue.Points[len(lastValue.Points)-1].Value.(int64))
+			if metric.Descriptor.Name != vName {
+				continue
+			}
+			// Other exporters may report the same metric, with other label values.
+			for _, ts := range metric.TimeSeries {
+				if tagsMatchLabelKeys(wantTags, metric.Descriptor.LabelKeys, ts.LabelValues) {
+					require.Equal(t, value, ts.Points[len(ts.Points)-1].Value.(int64))
 					return
 				}
-
 			}
 		}
 	}
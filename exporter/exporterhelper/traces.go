@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumerhelper"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/batchsplit"
 	"go.opentelemetry.io/collector/obsreport"
 )
 
@@ -43,6 +44,16 @@ func newTracesRequest(ctx context.Context, td pdata.Traces, pusher consumerhelpe
 	}
 }
 
+func newTracesRequestUnmarshalerFunc(pusher consumerhelper.ConsumeTracesFunc) requestUnmarshaler {
+	return func(bytes []byte) (request, error) {
+		td, err := pdata.TracesFromOtlpProtoBytes(bytes)
+		if err != nil {
+			return nil, err
+		}
+		return newTracesRequest(context.Background(), td, pusher), nil
+	}
+}
+
 func (req *tracesRequest) onError(err error) request {
 	var traceError consumererror.Traces
 	if consumererror.AsTraces(err, &traceError) {
@@ -59,6 +70,38 @@ func (req *tracesRequest) count() int {
 	return req.td.SpanCount()
 }
 
+func (req *tracesRequest) size() int {
+	return req.td.OtlpProtoSize()
+}
+
+func (req *tracesRequest) marshal() ([]byte, error) {
+	return req.td.ToOtlpProtoBytes()
+}
+
+func (req *tracesRequest) split() (request, request, bool) {
+	n := req.td.SpanCount()
+	if n < 2 {
+		return nil, nil, false
+	}
+	// The data may be shared with other consumers, so split a copy.
+	second := req.td.Clone()
+	first := batchsplit.SplitTraces(n/2, second)
+	return newTracesRequest(req.ctx, first, req.pusher), newTracesRequest(req.ctx, second, req.pusher), true
+}
+
+func (req *tracesRequest) deadLetter(ctx context.Context, exporter component.Exporter, annotate func(pdata.Resource)) error {
+	next, ok := exporter.(consumer.Traces)
+	if !ok {
+		return errDeadLetterDataType
+	}
+	td := req.td.Clone()
+	rs := td.ResourceSpans()
+	for i := 0; i < rs.Len(); i++ {
+		annotate(rs.At(i).Resource())
+	}
+	return next.ConsumeTraces(ctx, td)
+}
+
 type traceExporter struct {
 	*baseExporter
 	consumer.Traces
@@ -85,7 +128,7 @@ func NewTracesExporter(
 	}
 
 	bs := fromOptions(options...)
-	be := newBaseExporter(cfg, logger, bs)
+	be := newBaseExporter(cfg, logger, bs, config.TracesDataType, newTracesRequestUnmarshalerFunc(pusher))
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &tracesExporterWithObservability{
 			obsrep: obsreport.NewExporter(
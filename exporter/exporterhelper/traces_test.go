@@ -52,6 +52,19 @@ func TestTracesRequest(t *testing.T) {
 	assert.EqualValues(t, newTracesRequest(context.Background(), pdata.NewTraces(), nil), mr.onError(traceErr))
 }
 
+func TestTracesRequest_Split(t *testing.T) {
+	data := testdata.GenerateTracesManySpansSameResource(5)
+	first, second, ok := newTracesRequest(context.Background(), data, nil).split()
+	require.True(t, ok)
+	assert.Equal(t, 2, first.(*tracesRequest).td.SpanCount())
+	assert.Equal(t, 3, second.(*tracesRequest).td.SpanCount())
+	// The original data is not modified.
+	assert.Equal(t, 5, data.SpanCount())
+
+	_, _, ok = newTracesRequest(context.Background(), testdata.GenerateTracesOneSpan(), nil).split()
+	assert.False(t, ok)
+}
+
 type testOCTracesExporter struct {
 	mu       sync.Mutex
 	spanData []*trace.SpanData
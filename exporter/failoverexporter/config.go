@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverexporter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cast"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// Config defines configuration for failover exporter.
+type Config struct {
+	config.ExporterSettings      `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	exporterhelper.QueueSettings `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings `mapstructure:"retry_on_failure"`
+
+	// Exporters is the list of exporters to send the data to, ordered by priority. Every item is a map with a single
+	// exporter ID as key, and the configuration of that exporter as value. The sending_queue and retry_on_failure
+	// of these exporters are disabled, so that their failures are reported to the failover exporter.
+	Exporters []map[string]interface{} `mapstructure:"exporters"`
+
+	// FailureThreshold is the number of consecutive failures of the active exporter after which the data is sent
+	// to the next exporter in the list.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+
+	// RecoveryInterval is the interval at which the exporters with a higher priority than the active one are tried
+	// again, to return to them once they recover.
+	RecoveryInterval time.Duration `mapstructure:"recovery_interval"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if _, err := cfg.backends(); err != nil {
+		return err
+	}
+	if cfg.FailureThreshold < 1 {
+		return errors.New("failure_threshold must be at least 1")
+	}
+	if cfg.RecoveryInterval <= 0 {
+		return errors.New("recovery_interval must be positive")
+	}
+	return nil
+}
+
+// backendConfig is an item of the Exporters list.
+type backendConfig struct {
+	id       config.ComponentID
+	settings map[string]interface{}
+}
+
+// backends parses the Exporters list.
+func (cfg *Config) backends() ([]backendConfig, error) {
+	if len(cfg.Exporters) == 0 {
+		return nil, errors.New("must specify at least one exporter")
+	}
+
+	backends := make([]backendConfig, 0, len(cfg.Exporters))
+	seen := make(map[config.ComponentID]bool, len(cfg.Exporters))
+	for i, item := range cfg.Exporters {
+		if len(item) != 1 {
+			return nil, fmt.Errorf("exporters[%d] must have exactly one exporter, found %d", i, len(item))
+		}
+		for key, settings := range item {
+			id, err := config.NewIDFromString(key)
+			if err != nil {
+				return nil, fmt.Errorf("exporters[%d] has an invalid exporter ID %q: %w", i, key, err)
+			}
+			if id.Type() == typeStr {
+				return nil, fmt.Errorf("exporters[%d] cannot be a %s exporter", i, typeStr)
+			}
+			if seen[id] {
+				return nil, fmt.Errorf("exporters[%d] duplicates exporter %q", i, key)
+			}
+			seen[id] = true
+			backendSettings := cast.ToStringMap(settings)
+			for _, key := range []string{"sending_queue", "retry_on_failure"} {
+				if cast.ToBool(cast.ToStringMap(backendSettings[key])["enabled"]) {
+					return nil, fmt.Errorf("exporters[%d] cannot enable %s, the failover exporter has its own", i, key)
+				}
+			}
+			backends = append(backends, backendConfig{id: id, settings: backendSettings})
+		}
+	}
+	return backends, nil
+}
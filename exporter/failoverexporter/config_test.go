@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e0 := cfg.Exporters[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, []config.ComponentID{config.NewID("nop")}, backendIDs(t, e0))
+
+	e1 := cfg.Exporters[config.NewIDWithName(typeStr, "2")].(*Config)
+	assert.Equal(t, []config.ComponentID{config.NewIDWithName("otlp", "primary"), config.NewIDWithName("otlp", "standby")}, backendIDs(t, e1))
+	backends, err := e1.backends()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"endpoint": "primary:4317"}, backends[0].settings)
+	e1.Exporters = nil
+	assert.Equal(t,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(config.NewIDWithName(typeStr, "2")),
+			QueueSettings: exporterhelper.QueueSettings{
+				Enabled:      true,
+				NumConsumers: 2,
+				QueueSize:    10,
+			},
+			RetrySettings: exporterhelper.RetrySettings{
+				Enabled:         true,
+				InitialInterval: 10 * time.Second,
+				MaxInterval:     1 * time.Minute,
+				MaxElapsedTime:  10 * time.Minute,
+			},
+			FailureThreshold: 5,
+			RecoveryInterval: time.Minute,
+		}, e1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		exporters []map[string]interface{}
+		modify    func(cfg *Config)
+		err       string
+	}{
+		{
+			name: "no exporters",
+			err:  "must specify at least one exporter",
+		},
+		{
+			name:      "multiple exporters in one item",
+			exporters: []map[string]interface{}{{"otlp/1": nil, "otlp/2": nil}},
+			err:       "exporters[0] must have exactly one exporter, found 2",
+		},
+		{
+			name:      "invalid ID",
+			exporters: []map[string]interface{}{{"otlp/": nil}},
+			err:       `exporters[0] has an invalid exporter ID "otlp/": name part must be specified after / in type/name key`,
+		},
+		{
+			name:      "failover exporter",
+			exporters: []map[string]interface{}{{"failover/other": nil}},
+			err:       "exporters[0] cannot be a failover exporter",
+		},
+		{
+			name:      "duplicated exporter",
+			exporters: []map[string]interface{}{{"otlp": nil}, {"otlp": nil}},
+			err:       `exporters[1] duplicates exporter "otlp"`,
+		},
+		{
+			name:      "backend queue",
+			exporters: []map[string]interface{}{{"otlp": map[string]interface{}{"sending_queue": map[string]interface{}{"enabled": true}}}},
+			err:       "exporters[0] cannot enable sending_queue, the failover exporter has its own",
+		},
+		{
+			name:      "backend retry",
+			exporters: []map[string]interface{}{{"otlp": map[string]interface{}{"retry_on_failure": map[string]interface{}{"enabled": true}}}},
+			err:       "exporters[0] cannot enable retry_on_failure, the failover exporter has its own",
+		},
+		{
+			name:      "failure threshold",
+			exporters: []map[string]interface{}{{"otlp": nil}},
+			modify:    func(cfg *Config) { cfg.FailureThreshold = 0 },
+			err:       "failure_threshold must be at least 1",
+		},
+		{
+			name:      "recovery interval",
+			exporters: []map[string]interface{}{{"otlp": nil}},
+			modify:    func(cfg *Config) { cfg.RecoveryInterval = 0 },
+			err:       "recovery_interval must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Exporters = tt.exporters
+			if tt.modify != nil {
+				tt.modify(cfg)
+			}
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
+
+func backendIDs(t *testing.T, cfg *Config) []config.ComponentID {
+	backends, err := cfg.backends()
+	require.NoError(t, err)
+	var ids []config.ComponentID
+	for _, b := range backends {
+		ids = append(ids, b.id)
+	}
+	return ids
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverexporter
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "failover"
+
+	defaultFailureThreshold = 3
+	defaultRecoveryInterval = 30 * time.Second
+)
+
+// NewFactory creates a factory for failover exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithTraces(createTracesExporter),
+		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithLogs(createLogsExporter))
+}
+
+func createDefaultConfig() config.Exporter {
+	return &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+		QueueSettings:    exporterhelper.DefaultQueueSettings(),
+		RetrySettings:    exporterhelper.DefaultRetrySettings(),
+		FailureThreshold: defaultFailureThreshold,
+		RecoveryInterval: defaultRecoveryInterval,
+	}
+}
+
+func createTracesExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg config.Exporter,
+) (component.TracesExporter, error) {
+	fe := newFailoverExporter(params, cfg.(*Config), config.TracesDataType)
+	return exporterhelper.NewTracesExporter(
+		cfg,
+		params.Logger,
+		fe.pushTraces,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		// Disable the timeout, every exporter applies its own.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(fe.cfg.RetrySettings),
+		exporterhelper.WithQueue(fe.cfg.QueueSettings),
+		exporterhelper.WithStart(fe.start),
+		exporterhelper.WithShutdown(fe.shutdown))
+}
+
+func createMetricsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg config.Exporter,
+) (component.MetricsExporter, error) {
+	fe := newFailoverExporter(params, cfg.(*Config), config.MetricsDataType)
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		params.Logger,
+		fe.pushMetrics,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		// Disable the timeout, every exporter applies its own.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(fe.cfg.RetrySettings),
+		exporterhelper.WithQueue(fe.cfg.QueueSettings),
+		exporterhelper.WithStart(fe.start),
+		exporterhelper.WithShutdown(fe.shutdown))
+}
+
+func createLogsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg config.Exporter,
+) (component.LogsExporter, error) {
+	fe := newFailoverExporter(params, cfg.(*Config), config.LogsDataType)
+	return exporterhelper.NewLogsExporter(
+		cfg,
+		params.Logger,
+		fe.pushLogs,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		// Disable the timeout, every exporter applies its own.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(fe.cfg.RetrySettings),
+		exporterhelper.WithQueue(fe.cfg.QueueSettings),
+		exporterhelper.WithStart(fe.start),
+		exporterhelper.WithShutdown(fe.shutdown))
+}
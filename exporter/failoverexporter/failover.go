@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// backend is an exporter created from an item of the Exporters list.
+type backend struct {
+	id       config.ComponentID
+	exporter component.Exporter
+	// mutatesData is true if the data must be cloned before it is sent to the exporter, since it is also sent
+	// to the next exporter if this one fails.
+	mutatesData bool
+}
+
+// failoverExporter sends the data to the active exporter, which is the exporter with the highest priority that did
+// not fail FailureThreshold consecutive times. The exporters with a higher priority than the active one are tried
+// again every RecoveryInterval.
+type failoverExporter struct {
+	cfg      *Config
+	params   component.ExporterCreateParams
+	logger   *zap.Logger
+	dataType config.DataType
+	backends []backend
+
+	mu        sync.Mutex
+	active    int
+	failures  int
+	nextProbe time.Time
+}
+
+func newFailoverExporter(params component.ExporterCreateParams, cfg *Config, dataType config.DataType) *failoverExporter {
+	return &failoverExporter{
+		cfg:      cfg,
+		params:   params,
+		logger:   params.Logger,
+		dataType: dataType,
+	}
+}
+
+// start creates and starts the exporters of the Exporters list. They are created here since the factories are
+// only available from the host.
+func (fe *failoverExporter) start(ctx context.Context, host component.Host) error {
+	backendCfgs, err := fe.cfg.backends()
+	if err != nil {
+		return err
+	}
+
+	for _, backendCfg := range backendCfgs {
+		exporter, err := fe.createExporter(ctx, host, backendCfg)
+		if err != nil {
+			return fmt.Errorf("failed to create exporter %q: %w", backendCfg.id, err)
+		}
+		fe.backends = append(fe.backends, backend{
+			id:          backendCfg.id,
+			exporter:    exporter,
+			mutatesData: mutatesData(exporter),
+		})
+		if err = exporter.Start(ctx, host); err != nil {
+			return fmt.Errorf("failed to start exporter %q: %w", backendCfg.id, err)
+		}
+	}
+	return nil
+}
+
+func (fe *failoverExporter) createExporter(ctx context.Context, host component.Host, backendCfg backendConfig) (component.Exporter, error) {
+	factory, ok := host.GetFactory(component.KindExporter, backendCfg.id.Type()).(component.ExporterFactory)
+	if !ok {
+		return nil, fmt.Errorf("unknown exporter type %q", backendCfg.id.Type())
+	}
+
+	cfg := factory.CreateDefaultConfig()
+	cfg.SetIDName(backendCfg.id.Name())
+	parser := config.NewParserFromStringMap(backendCfg.settings)
+	if cu, ok := cfg.(config.CustomUnmarshable); ok {
+		err := cu.Unmarshal(parser)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := parser.UnmarshalExact(cfg); err != nil {
+		return nil, err
+	}
+	disableQueueAndRetry(cfg)
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := fe.params
+	params.Logger = fe.logger.With(zap.Stringer("failover_exporter", backendCfg.id))
+	var exporter component.Exporter
+	var err error
+	switch fe.dataType {
+	case config.TracesDataType:
+		exporter, err = factory.CreateTracesExporter(ctx, params, cfg)
+	case config.MetricsDataType:
+		exporter, err = factory.CreateMetricsExporter(ctx, params, cfg)
+	case config.LogsDataType:
+		exporter, err = factory.CreateLogsExporter(ctx, params, cfg)
+	default:
+		err = componenterror.ErrDataTypeIsNotSupported
+	}
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return nil, errors.New("factory produced a nil exporter")
+	}
+	return exporter, nil
+}
+
+var (
+	queueSettingsType = reflect.TypeOf(exporterhelper.QueueSettings{})
+	retrySettingsType = reflect.TypeOf(exporterhelper.RetrySettings{})
+)
+
+// disableQueueAndRetry disables the sending_queue and retry_on_failure of the exporter configuration, otherwise
+// the exporter would accept the data as soon as it is queued, and its failures would never be reported.
+func disableQueueAndRetry(cfg config.Exporter) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		if field.Type() == queueSettingsType || field.Type() == retrySettingsType {
+			field.FieldByName("Enabled").SetBool(false)
+		}
+	}
+}
+
+// mutatesData returns whether the exporter modifies the data it consumes.
+func mutatesData(exporter component.Exporter) bool {
+	if c, ok := exporter.(interface{ Capabilities() consumer.Capabilities }); ok {
+		return c.Capabilities().MutatesData
+	}
+	return false
+}
+
+// shutdown stops all the exporters of the Exporters list.
+func (fe *failoverExporter) shutdown(ctx context.Context) error {
+	var errs []error
+	for _, b := range fe.backends {
+		if err := b.exporter.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+func (fe *failoverExporter) pushTraces(ctx context.Context, td pdata.Traces) error {
+	return fe.send(func(b backend) error {
+		if b.mutatesData {
+			return b.exporter.(consumer.Traces).ConsumeTraces(ctx, td.Clone())
+		}
+		return b.exporter.(consumer.Traces).ConsumeTraces(ctx, td)
+	})
+}
+
+func (fe *failoverExporter) pushMetrics(ctx context.Context, md pdata.Metrics) error {
+	return fe.send(func(b backend) error {
+		if b.mutatesData {
+			return b.exporter.(consumer.Metrics).ConsumeMetrics(ctx, md.Clone())
+		}
+		return b.exporter.(consumer.Metrics).ConsumeMetrics(ctx, md)
+	})
+}
+
+func (fe *failoverExporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
+	return fe.send(func(b backend) error {
+		if b.mutatesData {
+			return b.exporter.(consumer.Logs).ConsumeLogs(ctx, ld.Clone())
+		}
+		return b.exporter.(consumer.Logs).ConsumeLogs(ctx, ld)
+	})
+}
+
+// send sends the data to the active exporter. If it fails for the FailureThreshold time in a row, the data is sent
+// to the next exporter, which becomes the active one. When it is time to probe the exporters with a higher priority,
+// the data is sent to them first.
+func (fe *failoverExporter) send(consume func(b backend) error) error {
+	first := fe.firstBackend()
+
+	var errs []error
+	for i := first; i < len(fe.backends); i++ {
+		b := fe.backends[i]
+		err := consume(b)
+		// Permanent errors are caused by the data, the next exporter would reject it as well.
+		if err == nil || consumererror.IsPermanent(err) {
+			fe.recordSuccess(i)
+			return err
+		}
+
+		errs = append(errs, fmt.Errorf("exporter %q failed: %w", b.id, err))
+		if !fe.recordFailure(i, err) {
+			break
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+// firstBackend returns the index of the first exporter to send the data to.
+func (fe *failoverExporter) firstBackend() int {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+
+	if fe.active > 0 && !time.Now().Before(fe.nextProbe) {
+		// Probe the exporters with a higher priority, only once per interval.
+		fe.nextProbe = time.Now().Add(fe.cfg.RecoveryInterval)
+		return 0
+	}
+	return fe.active
+}
+
+// recordSuccess makes the exporter at the given index the active one.
+func (fe *failoverExporter) recordSuccess(i int) {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+
+	if i < fe.active {
+		fe.logger.Info("Exporter recovered, switched back to it.", zap.Stringer("exporter", fe.backends[i].id))
+		fe.active = i
+	}
+	if i == fe.active {
+		fe.failures = 0
+	}
+}
+
+// recordFailure records a failure of the exporter at the given index, and returns whether the data must be sent
+// to the next exporter.
+func (fe *failoverExporter) recordFailure(i int, err error) bool {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+
+	switch {
+	case i < fe.active:
+		// Failed probe, or concurrent switch to the next exporter.
+		return true
+	case i > fe.active:
+		// Concurrent switch back to a recovered exporter.
+		return false
+	}
+
+	fe.failures++
+	if fe.failures < fe.cfg.FailureThreshold || i == len(fe.backends)-1 {
+		return false
+	}
+
+	fe.active = i + 1
+	fe.failures = 0
+	fe.nextProbe = time.Now().Add(fe.cfg.RecoveryInterval)
+	fe.logger.Warn(
+		"Exporter failed repeatedly, switched to the next exporter.",
+		zap.Stringer("failed_exporter", fe.backends[i].id),
+		zap.Stringer("exporter", fe.backends[fe.active].id),
+		zap.Error(err),
+	)
+	return true
+}
+
+// activeBackend returns the ID of the active exporter.
+func (fe *failoverExporter) activeBackend() config.ComponentID {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	return fe.backends[fe.active].id
+}
@@ -0,0 +1,279 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failoverexporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+const mockTypeStr = "mock"
+
+type mockConfig struct {
+	config.ExporterSettings      `mapstructure:",squash"`
+	exporterhelper.QueueSettings `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings `mapstructure:"retry_on_failure"`
+	Endpoint                     string `mapstructure:"endpoint"`
+}
+
+// mockBackend records the data sent to the exporter with the same name, or fails with the configured error.
+type mockBackend struct {
+	mu       sync.Mutex
+	err      error
+	attempts int
+	endpoint string
+	cfg      *mockConfig
+	traces   consumertest.TracesSink
+	metrics  consumertest.MetricsSink
+	logs     consumertest.LogsSink
+}
+
+func (mb *mockBackend) setError(err error) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.err = err
+}
+
+func (mb *mockBackend) attempt() error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.attempts++
+	return mb.err
+}
+
+func (mb *mockBackend) attemptsCount() int {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.attempts
+}
+
+type mockHost struct {
+	component.Host
+	factory component.ExporterFactory
+}
+
+func (mh *mockHost) GetFactory(kind component.Kind, componentType config.Type) component.Factory {
+	if kind == component.KindExporter && componentType == mockTypeStr {
+		return mh.factory
+	}
+	return nil
+}
+
+func newMockHost(backends map[string]*mockBackend) component.Host {
+	factory := exporterhelper.NewFactory(
+		mockTypeStr,
+		func() config.Exporter {
+			// Like most exporters, the queue and the retries are enabled by default.
+			return &mockConfig{
+				ExporterSettings: config.NewExporterSettings(config.NewID(mockTypeStr)),
+				QueueSettings:    exporterhelper.DefaultQueueSettings(),
+				RetrySettings:    exporterhelper.DefaultRetrySettings(),
+			}
+		},
+		exporterhelper.WithTraces(func(_ context.Context, params component.ExporterCreateParams, cfg config.Exporter) (component.TracesExporter, error) {
+			mb := backends[cfg.ID().Name()]
+			mb.cfg = cfg.(*mockConfig)
+			mb.endpoint = mb.cfg.Endpoint
+			return exporterhelper.NewTracesExporter(cfg, params.Logger, func(ctx context.Context, td pdata.Traces) error {
+				if err := mb.attempt(); err != nil {
+					return err
+				}
+				return mb.traces.ConsumeTraces(ctx, td)
+			}, exporterhelper.WithQueue(mb.cfg.QueueSettings), exporterhelper.WithRetry(mb.cfg.RetrySettings))
+		}),
+		exporterhelper.WithMetrics(func(_ context.Context, params component.ExporterCreateParams, cfg config.Exporter) (component.MetricsExporter, error) {
+			mb := backends[cfg.ID().Name()]
+			return exporterhelper.NewMetricsExporter(cfg, params.Logger, func(ctx context.Context, md pdata.Metrics) error {
+				if err := mb.attempt(); err != nil {
+					return err
+				}
+				return mb.metrics.ConsumeMetrics(ctx, md)
+			})
+		}),
+		exporterhelper.WithLogs(func(_ context.Context, params component.ExporterCreateParams, cfg config.Exporter) (component.LogsExporter, error) {
+			mb := backends[cfg.ID().Name()]
+			return exporterhelper.NewLogsExporter(cfg, params.Logger, func(ctx context.Context, ld pdata.Logs) error {
+				if err := mb.attempt(); err != nil {
+					return err
+				}
+				return mb.logs.ConsumeLogs(ctx, ld)
+			})
+		}))
+	return &mockHost{Host: componenttest.NewNopHost(), factory: factory}
+}
+
+func newTestConfig(failureThreshold int, recoveryInterval time.Duration) *Config {
+	cfg := createDefaultConfig().(*Config)
+	// Get the errors synchronously.
+	cfg.QueueSettings.Enabled = false
+	cfg.RetrySettings.Enabled = false
+	cfg.Exporters = []map[string]interface{}{
+		{"mock/primary": map[string]interface{}{"endpoint": "primary:4317"}},
+		{"mock/standby": nil},
+	}
+	cfg.FailureThreshold = failureThreshold
+	cfg.RecoveryInterval = recoveryInterval
+	return cfg
+}
+
+func startTestExporter(t *testing.T, cfg *Config) (*failoverExporter, *mockBackend, *mockBackend) {
+	primary, standby := &mockBackend{}, &mockBackend{}
+	fe := newFailoverExporter(component.ExporterCreateParams{Logger: zap.NewNop()}, cfg, config.TracesDataType)
+	require.NoError(t, fe.start(context.Background(), newMockHost(map[string]*mockBackend{"primary": primary, "standby": standby})))
+	t.Cleanup(func() {
+		assert.NoError(t, fe.shutdown(context.Background()))
+	})
+	return fe, primary, standby
+}
+
+func TestFailover_SwitchAfterFailureThreshold(t *testing.T) {
+	fe, primary, standby := startTestExporter(t, newTestConfig(2, time.Hour))
+	assert.Equal(t, "primary:4317", primary.endpoint)
+	assert.Equal(t, "", standby.endpoint)
+
+	require.NoError(t, fe.pushTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, 1, primary.traces.SpansCount())
+
+	primary.setError(errors.New("unavailable"))
+	// The first failure is returned, to be retried on the same exporter.
+	assert.Error(t, fe.pushTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, config.NewIDWithName(mockTypeStr, "primary"), fe.activeBackend())
+	assert.Equal(t, 0, standby.traces.SpansCount())
+
+	// The second failure switches to the standby exporter.
+	require.NoError(t, fe.pushTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, config.NewIDWithName(mockTypeStr, "standby"), fe.activeBackend())
+	assert.Equal(t, 1, standby.traces.SpansCount())
+
+	// The data goes to the standby exporter directly.
+	require.NoError(t, fe.pushTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, 2, standby.traces.SpansCount())
+	assert.Equal(t, 3, primary.attemptsCount())
+}
+
+func TestFailover_BackendQueueAndRetryDisabled(t *testing.T) {
+	cfg := newTestConfig(1, time.Hour)
+	fe, primary, standby := startTestExporter(t, cfg)
+	assert.False(t, primary.cfg.QueueSettings.Enabled)
+	assert.False(t, primary.cfg.RetrySettings.Enabled)
+	assert.Equal(t, exporterhelper.DefaultQueueSettings().QueueSize, primary.cfg.QueueSize)
+
+	// The failure of the primary exporter is reported, instead of being queued and retried by the exporter,
+	// so the data is received by the standby exporter.
+	primary.setError(errors.New("unavailable"))
+	require.NoError(t, fe.pushTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, 1, primary.attemptsCount())
+	assert.Equal(t, 1, standby.traces.SpansCount())
+	assert.Equal(t, 0, primary.traces.SpansCount())
+}
+
+func TestFailover_ReturnToRecoveredExporter(t *testing.T) {
+	fe, primary, standby := startTestExporter(t, newTestConfig(1, 10*time.Millisecond))
+
+	primary.setError(errors.New("unavailable"))
+	require.NoError(t, fe.pushTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, config.NewIDWithName(mockTypeStr, "standby"), fe.activeBackend())
+
+	// The failed probe sends the data to the active exporter.
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, fe.pushTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, config.NewIDWithName(mockTypeStr, "standby"), fe.activeBackend())
+	assert.Equal(t, 2, primary.attemptsCount())
+	assert.Equal(t, 2, standby.traces.SpansCount())
+
+	primary.setError(nil)
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, fe.pushTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, config.NewIDWithName(mockTypeStr, "primary"), fe.activeBackend())
+	assert.Equal(t, 1, primary.traces.SpansCount())
+	assert.Equal(t, 2, standby.traces.SpansCount())
+}
+
+func TestFailover_AllExportersFail(t *testing.T) {
+	fe, primary, standby := startTestExporter(t, newTestConfig(1, time.Hour))
+
+	primary.setError(errors.New("primary unavailable"))
+	standby.setError(errors.New("standby unavailable"))
+	err := fe.pushTraces(context.Background(), testdata.GenerateTracesOneSpan())
+	assert.Contains(t, err.Error(), "primary unavailable")
+	assert.Contains(t, err.Error(), "standby unavailable")
+
+	// Stays on the last exporter.
+	assert.Error(t, fe.pushTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, config.NewIDWithName(mockTypeStr, "standby"), fe.activeBackend())
+	assert.Equal(t, 1, primary.attemptsCount())
+	assert.Equal(t, 2, standby.attemptsCount())
+}
+
+func TestFailover_PermanentErrorDoesNotSwitch(t *testing.T) {
+	fe, primary, standby := startTestExporter(t, newTestConfig(1, time.Hour))
+
+	primary.setError(consumererror.Permanent(errors.New("bad data")))
+	err := fe.pushTraces(context.Background(), testdata.GenerateTracesOneSpan())
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, config.NewIDWithName(mockTypeStr, "primary"), fe.activeBackend())
+	assert.Equal(t, 0, standby.attemptsCount())
+}
+
+func TestFailover_StartErrors(t *testing.T) {
+	host := newMockHost(map[string]*mockBackend{"primary": {}, "standby": {}})
+
+	cfg := newTestConfig(1, time.Hour)
+	cfg.Exporters = []map[string]interface{}{{"unknown": nil}}
+	fe := newFailoverExporter(component.ExporterCreateParams{Logger: zap.NewNop()}, cfg, config.TracesDataType)
+	assert.EqualError(t, fe.start(context.Background(), host), `failed to create exporter "unknown": unknown exporter type "unknown"`)
+
+	cfg = newTestConfig(1, time.Hour)
+	cfg.Exporters = []map[string]interface{}{{"mock/primary": map[string]interface{}{"invalid": true}}}
+	fe = newFailoverExporter(component.ExporterCreateParams{Logger: zap.NewNop()}, cfg, config.TracesDataType)
+	assert.Error(t, fe.start(context.Background(), host))
+}
+
+func TestFailover_MetricsAndLogs(t *testing.T) {
+	primary, standby := &mockBackend{}, &mockBackend{}
+	host := newMockHost(map[string]*mockBackend{"primary": primary, "standby": standby})
+	cfg := newTestConfig(1, time.Hour)
+	primary.setError(errors.New("unavailable"))
+
+	me, err := NewFactory().CreateMetricsExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, me.Start(context.Background(), host))
+	require.NoError(t, me.ConsumeMetrics(context.Background(), testdata.GenerateMetricsOneMetric()))
+	assert.Equal(t, 1, standby.metrics.MetricsCount())
+	require.NoError(t, me.Shutdown(context.Background()))
+
+	le, err := NewFactory().CreateLogsExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), host))
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+	assert.Equal(t, 1, standby.logs.LogRecordsCount())
+	require.NoError(t, le.Shutdown(context.Background()))
+}
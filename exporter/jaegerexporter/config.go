@@ -22,10 +22,12 @@ import (
 
 // Config defines configuration for Jaeger gRPC exporter.
 type Config struct {
-	config.ExporterSettings        `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
-	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
-	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
-	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+	config.ExporterSettings               `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	exporterhelper.TimeoutSettings        `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings          `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings          `mapstructure:"retry_on_failure"`
+	exporterhelper.CircuitBreakerSettings `mapstructure:"circuit_breaker"`
+	exporterhelper.DeadLetterSettings     `mapstructure:"dead_letter"`
 
 	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 }
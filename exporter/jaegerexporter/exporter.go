@@ -65,6 +65,8 @@ func newTracesExporter(cfg *Config, logger *zap.Logger) (component.TracesExporte
 		exporterhelper.WithShutdown(s.shutdown),
 		exporterhelper.WithTimeout(cfg.TimeoutSettings),
 		exporterhelper.WithRetry(cfg.RetrySettings),
+		exporterhelper.WithCircuitBreaker(cfg.CircuitBreakerSettings),
+		exporterhelper.WithDeadLetter(cfg.DeadLetterSettings),
 		exporterhelper.WithQueue(cfg.QueueSettings),
 	)
 }
@@ -39,10 +39,11 @@ func NewFactory() component.ExporterFactory {
 
 func createDefaultConfig() config.Exporter {
 	return &Config{
-		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
-		TimeoutSettings:  exporterhelper.DefaultTimeoutSettings(),
-		RetrySettings:    exporterhelper.DefaultRetrySettings(),
-		QueueSettings:    exporterhelper.DefaultQueueSettings(),
+		ExporterSettings:       config.NewExporterSettings(config.NewID(typeStr)),
+		TimeoutSettings:        exporterhelper.DefaultTimeoutSettings(),
+		RetrySettings:          exporterhelper.DefaultRetrySettings(),
+		CircuitBreakerSettings: exporterhelper.DefaultCircuitBreakerSettings(),
+		QueueSettings:          exporterhelper.DefaultQueueSettings(),
 		GRPCClientSettings: configgrpc.GRPCClientSettings{
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
 			WriteBufferSize: 512 * 1024,
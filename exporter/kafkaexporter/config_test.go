@@ -50,6 +50,7 @@ func TestLoadConfig(t *testing.T) {
 			MaxInterval:     1 * time.Minute,
 			MaxElapsedTime:  10 * time.Minute,
 		},
+		CircuitBreakerSettings: exporterhelper.DefaultCircuitBreakerSettings(),
 		QueueSettings: exporterhelper.QueueSettings{
 			Enabled:      true,
 			NumConsumers: 2,
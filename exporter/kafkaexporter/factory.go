@@ -72,11 +72,12 @@ func NewFactory(options ...FactoryOption) component.ExporterFactory {
 
 func createDefaultConfig() config.Exporter {
 	return &Config{
-		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
-		TimeoutSettings:  exporterhelper.DefaultTimeoutSettings(),
-		RetrySettings:    exporterhelper.DefaultRetrySettings(),
-		QueueSettings:    exporterhelper.DefaultQueueSettings(),
-		Brokers:          []string{defaultBroker},
+		ExporterSettings:       config.NewExporterSettings(config.NewID(typeStr)),
+		TimeoutSettings:        exporterhelper.DefaultTimeoutSettings(),
+		RetrySettings:          exporterhelper.DefaultRetrySettings(),
+		CircuitBreakerSettings: exporterhelper.DefaultCircuitBreakerSettings(),
+		QueueSettings:          exporterhelper.DefaultQueueSettings(),
+		Brokers:                []string{defaultBroker},
 		// using an empty topic to track when it has not been set by user, default is based on traces or metrics.
 		Topic:    "",
 		Encoding: defaultEncoding,
@@ -118,6 +119,8 @@ func (f *kafkaExporterFactory) createTracesExporter(
 		// and will rely on the sarama Producer Timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
 		exporterhelper.WithShutdown(exp.Close))
 }
@@ -144,6 +147,8 @@ func (f *kafkaExporterFactory) createMetricsExporter(
 		// and will rely on the sarama Producer Timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
 		exporterhelper.WithShutdown(exp.Close))
 }
@@ -170,6 +175,8 @@ func (f *kafkaExporterFactory) createLogsExporter(
 		// and will rely on the sarama Producer Timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
 		exporterhelper.WithShutdown(exp.Close))
 }
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+)
+
+const (
+	// traceIDRoutingKey sends all the spans of a trace to the same endpoint.
+	traceIDRoutingKey = "traceID"
+	// serviceRoutingKey sends all the data of a service to the same endpoint.
+	serviceRoutingKey = "service"
+)
+
+// Config defines configuration for load-balancing exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Protocol is the configuration of the exporters created for every endpoint, their endpoint is ignored.
+	Protocol Protocol `mapstructure:"protocol"`
+
+	// Endpoints is the list of endpoints to balance the data across.
+	Endpoints []string `mapstructure:"endpoints"`
+
+	// RoutingKey is the property of the data that selects its endpoint, "traceID" or "service".
+	// Metrics can only be routed by "service". If empty, the traces are routed by "traceID" and
+	// the metrics by "service".
+	RoutingKey string `mapstructure:"routing_key"`
+}
+
+// Protocol holds the configuration of the exporters to the endpoints.
+type Protocol struct {
+	OTLP otlpexporter.Config `mapstructure:"otlp"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Endpoints) == 0 {
+		return errors.New("must specify at least one endpoint")
+	}
+	seen := make(map[string]bool, len(cfg.Endpoints))
+	for i, endpoint := range cfg.Endpoints {
+		if endpoint == "" {
+			return fmt.Errorf("endpoints[%d] is empty", i)
+		}
+		if seen[endpoint] {
+			return fmt.Errorf("endpoints[%d] duplicates endpoint %q", i, endpoint)
+		}
+		seen[endpoint] = true
+	}
+	if cfg.RoutingKey != "" && cfg.RoutingKey != traceIDRoutingKey && cfg.RoutingKey != serviceRoutingKey {
+		return fmt.Errorf("unsupported routing_key %q, must be %q or %q", cfg.RoutingKey, traceIDRoutingKey, serviceRoutingKey)
+	}
+	return cfg.Protocol.OTLP.Validate()
+}
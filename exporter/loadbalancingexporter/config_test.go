@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e1 := cfg.Exporters[config.NewIDWithName(typeStr, "2")].(*Config)
+	otlpCfg := otlpexporter.NewFactory().CreateDefaultConfig().(*otlpexporter.Config)
+	otlpCfg.Timeout = time.Second
+	otlpCfg.TLSSetting.Insecure = true
+	otlpCfg.QueueSettings.Enabled = false
+	assert.Equal(t,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(config.NewIDWithName(typeStr, "2")),
+			Protocol:         Protocol{OTLP: *otlpCfg},
+			Endpoints:        []string{"collector-1:4317", "collector-2:4317"},
+			RoutingKey:       serviceRoutingKey,
+		}, e1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints []string
+		modify    func(cfg *Config)
+		err       string
+	}{
+		{
+			name: "no endpoints",
+			err:  "must specify at least one endpoint",
+		},
+		{
+			name:      "empty endpoint",
+			endpoints: []string{"a:4317", ""},
+			err:       "endpoints[1] is empty",
+		},
+		{
+			name:      "duplicated endpoint",
+			endpoints: []string{"a:4317", "a:4317"},
+			err:       `endpoints[1] duplicates endpoint "a:4317"`,
+		},
+		{
+			name:      "routing key",
+			endpoints: []string{"a:4317"},
+			modify:    func(cfg *Config) { cfg.RoutingKey = "spanID" },
+			err:       `unsupported routing_key "spanID", must be "traceID" or "service"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoints = tt.endpoints
+			if tt.modify != nil {
+				tt.modify(cfg)
+			}
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
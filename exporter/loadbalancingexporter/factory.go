@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "loadbalancing"
+)
+
+// NewFactory creates a factory for load-balancing exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithTraces(createTracesExporter),
+		exporterhelper.WithMetrics(createMetricsExporter))
+}
+
+func createDefaultConfig() config.Exporter {
+	otlpCfg := otlpexporter.NewFactory().CreateDefaultConfig().(*otlpexporter.Config)
+	return &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+		Protocol:         Protocol{OTLP: *otlpCfg},
+	}
+}
+
+func createTracesExporter(
+	ctx context.Context,
+	params component.ExporterCreateParams,
+	cfg config.Exporter,
+) (component.TracesExporter, error) {
+	lb, err := newLoadBalancingExporter(ctx, params, cfg.(*Config), config.TracesDataType, otlpexporter.NewFactory())
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewTracesExporter(
+		cfg,
+		params.Logger,
+		lb.pushTraces,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		// Disable the timeout, the exporters to the endpoints apply their own.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithStart(lb.start),
+		exporterhelper.WithShutdown(lb.shutdown))
+}
+
+func createMetricsExporter(
+	ctx context.Context,
+	params component.ExporterCreateParams,
+	cfg config.Exporter,
+) (component.MetricsExporter, error) {
+	lbCfg := cfg.(*Config)
+	if lbCfg.RoutingKey == traceIDRoutingKey {
+		return nil, fmt.Errorf("routing_key %q is not supported for metrics, use %q", lbCfg.RoutingKey, serviceRoutingKey)
+	}
+	lb, err := newLoadBalancingExporter(ctx, params, lbCfg, config.MetricsDataType, otlpexporter.NewFactory())
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		params.Logger,
+		lb.pushMetrics,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		// Disable the timeout, the exporters to the endpoints apply their own.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithStart(lb.start),
+		exporterhelper.WithShutdown(lb.shutdown))
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcheck"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateTracesExporter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoints = []string{"localhost:4317", "localhost:14317"}
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+
+	te, err := NewFactory().CreateTracesExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, te)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, te.Shutdown(context.Background()))
+}
+
+func TestCreateMetricsExporter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoints = []string{"localhost:4317", "localhost:14317"}
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+
+	// The metrics are routed by service with the default config.
+	for _, routingKey := range []string{"", serviceRoutingKey} {
+		cfg.RoutingKey = routingKey
+		me, err := NewFactory().CreateMetricsExporter(context.Background(), params, cfg)
+		require.NoError(t, err)
+		require.NotNil(t, me)
+		require.NoError(t, me.Start(context.Background(), componenttest.NewNopHost()))
+		assert.NoError(t, me.Shutdown(context.Background()))
+	}
+
+	cfg.RoutingKey = traceIDRoutingKey
+	_, err := NewFactory().CreateMetricsExporter(context.Background(), params, cfg)
+	assert.EqualError(t, err, `routing_key "traceID" is not supported for metrics, use "service"`)
+}
+
+func TestCreateLogsExporter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoints = []string{"localhost:4317"}
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+
+	_, err := NewFactory().CreateLogsExporter(context.Background(), params, cfg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// loadBalancingExporter splits the data by routing key, and sends every part to the endpoint of its key on a
+// consistent hash ring, with an OTLP exporter per endpoint.
+type loadBalancingExporter struct {
+	cfg       *Config
+	ring      *hashRing
+	exporters map[string]component.Exporter
+}
+
+// newLoadBalancingExporter creates the exporters to the endpoints with the given factory, the OTLP exporter
+// factory outside of the tests.
+func newLoadBalancingExporter(
+	ctx context.Context,
+	params component.ExporterCreateParams,
+	cfg *Config,
+	dataType config.DataType,
+	factory component.ExporterFactory,
+) (*loadBalancingExporter, error) {
+	lb := &loadBalancingExporter{
+		cfg:       cfg,
+		ring:      newHashRing(cfg.Endpoints),
+		exporters: make(map[string]component.Exporter, len(cfg.Endpoints)),
+	}
+	for _, endpoint := range cfg.Endpoints {
+		exporter, err := createExporter(ctx, params, cfg.Protocol.OTLP, endpoint, dataType, factory)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create exporter for endpoint %q: %w", endpoint, err)
+		}
+		lb.exporters[endpoint] = exporter
+	}
+	return lb, nil
+}
+
+func createExporter(
+	ctx context.Context,
+	params component.ExporterCreateParams,
+	otlpCfg otlpexporter.Config,
+	endpoint string,
+	dataType config.DataType,
+	factory component.ExporterFactory,
+) (component.Exporter, error) {
+	otlpCfg.SetIDName(endpoint)
+	otlpCfg.Endpoint = endpoint
+	params.Logger = params.Logger.With(zap.String("endpoint", endpoint))
+	switch dataType {
+	case config.TracesDataType:
+		return factory.CreateTracesExporter(ctx, params, &otlpCfg)
+	case config.MetricsDataType:
+		return factory.CreateMetricsExporter(ctx, params, &otlpCfg)
+	}
+	return nil, componenterror.ErrDataTypeIsNotSupported
+}
+
+// start starts the exporters to the endpoints.
+func (lb *loadBalancingExporter) start(ctx context.Context, host component.Host) error {
+	for endpoint, exporter := range lb.exporters {
+		if err := exporter.Start(ctx, host); err != nil {
+			return fmt.Errorf("failed to start exporter for endpoint %q: %w", endpoint, err)
+		}
+	}
+	return nil
+}
+
+// shutdown stops the exporters to the endpoints, which send the data of their queue first.
+func (lb *loadBalancingExporter) shutdown(ctx context.Context) error {
+	var errs []error
+	for _, exporter := range lb.exporters {
+		if err := exporter.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+func (lb *loadBalancingExporter) pushTraces(ctx context.Context, td pdata.Traces) error {
+	var errs []error
+	for endpoint, batch := range lb.splitTraces(td) {
+		if err := lb.exporters[endpoint].(consumer.Traces).ConsumeTraces(ctx, batch); err != nil {
+			errs = append(errs, fmt.Errorf("failed to export to endpoint %q: %w", endpoint, err))
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+func (lb *loadBalancingExporter) pushMetrics(ctx context.Context, md pdata.Metrics) error {
+	var errs []error
+	for endpoint, batch := range lb.splitMetrics(md) {
+		if err := lb.exporters[endpoint].(consumer.Metrics).ConsumeMetrics(ctx, batch); err != nil {
+			errs = append(errs, fmt.Errorf("failed to export to endpoint %q: %w", endpoint, err))
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+// splitTraces returns the spans to send to every endpoint.
+func (lb *loadBalancingExporter) splitTraces(td pdata.Traces) map[string]pdata.Traces {
+	batches := make(map[string]pdata.Traces)
+	batch := func(endpoint string) pdata.Traces {
+		b, ok := batches[endpoint]
+		if !ok {
+			b = pdata.NewTraces()
+			batches[endpoint] = b
+		}
+		return b
+	}
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if lb.cfg.RoutingKey == serviceRoutingKey {
+			rs.CopyTo(batch(lb.ring.endpoint(serviceName(rs.Resource()))).ResourceSpans().AppendEmpty())
+			continue
+		}
+
+		// The resource and the instrumentation libraries are copied once per endpoint.
+		destRSs := make(map[string]pdata.ResourceSpans)
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			destILSs := make(map[string]pdata.InstrumentationLibrarySpans)
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				traceID := span.TraceID().Bytes()
+				endpoint := lb.ring.endpoint(traceID[:])
+
+				destILS, ok := destILSs[endpoint]
+				if !ok {
+					destRS, ok := destRSs[endpoint]
+					if !ok {
+						destRS = batch(endpoint).ResourceSpans().AppendEmpty()
+						rs.Resource().CopyTo(destRS.Resource())
+						destRSs[endpoint] = destRS
+					}
+					destILS = destRS.InstrumentationLibrarySpans().AppendEmpty()
+					ils.InstrumentationLibrary().CopyTo(destILS.InstrumentationLibrary())
+					destILSs[endpoint] = destILS
+				}
+				span.CopyTo(destILS.Spans().AppendEmpty())
+			}
+		}
+	}
+	return batches
+}
+
+// splitMetrics returns the metrics to send to every endpoint, always by service.
+func (lb *loadBalancingExporter) splitMetrics(md pdata.Metrics) map[string]pdata.Metrics {
+	batches := make(map[string]pdata.Metrics)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		endpoint := lb.ring.endpoint(serviceName(rm.Resource()))
+		b, ok := batches[endpoint]
+		if !ok {
+			b = pdata.NewMetrics()
+			batches[endpoint] = b
+		}
+		rm.CopyTo(b.ResourceMetrics().AppendEmpty())
+	}
+	return batches
+}
+
+// serviceName returns the service name of the resource, the data without service name is routed together.
+func serviceName(resource pdata.Resource) []byte {
+	if v, ok := resource.Attributes().Get(conventions.AttributeServiceName); ok {
+		return []byte(v.StringVal())
+	}
+	return nil
+}
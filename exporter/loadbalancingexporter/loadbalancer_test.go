@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// mockEndpoint records the data sent to the exporter of an endpoint.
+type mockEndpoint struct {
+	err     error
+	started bool
+	stopped bool
+	traces  consumertest.TracesSink
+	metrics consumertest.MetricsSink
+}
+
+// newMockFactory returns a factory of exporters that send the data to the mockEndpoint of their endpoint.
+func newMockFactory(endpoints map[string]*mockEndpoint) component.ExporterFactory {
+	options := func(me *mockEndpoint) []exporterhelper.Option {
+		return []exporterhelper.Option{
+			exporterhelper.WithStart(func(context.Context, component.Host) error {
+				me.started = true
+				return nil
+			}),
+			exporterhelper.WithShutdown(func(context.Context) error {
+				me.stopped = true
+				return nil
+			}),
+		}
+	}
+	return exporterhelper.NewFactory(
+		"otlp",
+		otlpexporter.NewFactory().CreateDefaultConfig,
+		exporterhelper.WithTraces(func(_ context.Context, params component.ExporterCreateParams, cfg config.Exporter) (component.TracesExporter, error) {
+			me := endpoints[cfg.(*otlpexporter.Config).Endpoint]
+			return exporterhelper.NewTracesExporter(cfg, params.Logger, func(ctx context.Context, td pdata.Traces) error {
+				if me.err != nil {
+					return me.err
+				}
+				return me.traces.ConsumeTraces(ctx, td)
+			}, options(me)...)
+		}),
+		exporterhelper.WithMetrics(func(_ context.Context, params component.ExporterCreateParams, cfg config.Exporter) (component.MetricsExporter, error) {
+			me := endpoints[cfg.(*otlpexporter.Config).Endpoint]
+			return exporterhelper.NewMetricsExporter(cfg, params.Logger, func(ctx context.Context, md pdata.Metrics) error {
+				if me.err != nil {
+					return me.err
+				}
+				return me.metrics.ConsumeMetrics(ctx, md)
+			}, options(me)...)
+		}))
+}
+
+func newTestExporter(t *testing.T, routingKey string, dataType config.DataType) (*loadBalancingExporter, map[string]*mockEndpoint) {
+	endpoints := map[string]*mockEndpoint{"a:4317": {}, "b:4317": {}, "c:4317": {}}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoints = []string{"a:4317", "b:4317", "c:4317"}
+	cfg.RoutingKey = routingKey
+	lb, err := newLoadBalancingExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, cfg, dataType, newMockFactory(endpoints))
+	require.NoError(t, err)
+	require.NoError(t, lb.start(context.Background(), componenttest.NewNopHost()))
+	for _, me := range endpoints {
+		assert.True(t, me.started)
+	}
+	t.Cleanup(func() {
+		require.NoError(t, lb.shutdown(context.Background()))
+		for _, me := range endpoints {
+			assert.True(t, me.stopped)
+		}
+	})
+	return lb, endpoints
+}
+
+// generateTraces returns traces of two resources with two spans of every trace ID from 1 to tracesCount in each.
+func generateTraces(tracesCount int) pdata.Traces {
+	td := pdata.NewTraces()
+	for _, service := range []string{"frontend", "backend"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, service)
+		ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+		ils.InstrumentationLibrary().SetName("library")
+		for i := 1; i <= tracesCount; i++ {
+			for j := 0; j < 2; j++ {
+				span := ils.Spans().AppendEmpty()
+				span.SetTraceID(pdata.NewTraceID([16]byte{byte(i)}))
+				span.SetName(service)
+			}
+		}
+	}
+	return td
+}
+
+func TestLoadBalancing_TracesByTraceID(t *testing.T) {
+	lb, endpoints := newTestExporter(t, traceIDRoutingKey, config.TracesDataType)
+	require.NoError(t, lb.pushTraces(context.Background(), generateTraces(30)))
+
+	seen := make(map[pdata.TraceID]string)
+	spans := 0
+	for endpoint, me := range endpoints {
+		assert.NotZero(t, me.traces.SpansCount(), "endpoint %s", endpoint)
+		for _, td := range me.traces.AllTraces() {
+			rss := td.ResourceSpans()
+			// One resource per service, with the spans of both services in the same library.
+			assert.LessOrEqual(t, rss.Len(), 2)
+			for i := 0; i < rss.Len(); i++ {
+				rs := rss.At(i)
+				service, ok := rs.Resource().Attributes().Get(conventions.AttributeServiceName)
+				require.True(t, ok)
+				require.Equal(t, 1, rs.InstrumentationLibrarySpans().Len())
+				ils := rs.InstrumentationLibrarySpans().At(0)
+				assert.Equal(t, "library", ils.InstrumentationLibrary().Name())
+				for j := 0; j < ils.Spans().Len(); j++ {
+					span := ils.Spans().At(j)
+					assert.Equal(t, service.StringVal(), span.Name())
+					if previous, ok := seen[span.TraceID()]; ok {
+						assert.Equal(t, previous, endpoint, "all the spans of a trace must go to the same endpoint")
+					}
+					seen[span.TraceID()] = endpoint
+					spans++
+				}
+			}
+		}
+	}
+	assert.Len(t, seen, 30)
+	assert.Equal(t, 120, spans)
+}
+
+func TestLoadBalancing_TracesByService(t *testing.T) {
+	lb, endpoints := newTestExporter(t, serviceRoutingKey, config.TracesDataType)
+	td := generateTraces(10)
+	require.NoError(t, lb.pushTraces(context.Background(), td))
+
+	for _, service := range []string{"frontend", "backend"} {
+		me := endpoints[lb.ring.endpoint([]byte(service))]
+		found := false
+		for _, got := range me.traces.AllTraces() {
+			for i := 0; i < got.ResourceSpans().Len(); i++ {
+				name, _ := got.ResourceSpans().At(i).Resource().Attributes().Get(conventions.AttributeServiceName)
+				if name.StringVal() == service {
+					assert.Equal(t, 20, got.ResourceSpans().At(i).InstrumentationLibrarySpans().At(0).Spans().Len())
+					found = true
+				}
+			}
+		}
+		assert.True(t, found, "service %s", service)
+	}
+}
+
+func TestLoadBalancing_MetricsByService(t *testing.T) {
+	lb, endpoints := newTestExporter(t, serviceRoutingKey, config.MetricsDataType)
+	md := pdata.NewMetrics()
+	for _, service := range []string{"frontend", "backend", ""} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		if service != "" {
+			rm.Resource().Attributes().InsertString(conventions.AttributeServiceName, service)
+		}
+		rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName(service)
+	}
+	require.NoError(t, lb.pushMetrics(context.Background(), md))
+
+	metrics := 0
+	for endpoint, me := range endpoints {
+		for _, got := range me.metrics.AllMetrics() {
+			rms := got.ResourceMetrics()
+			for i := 0; i < rms.Len(); i++ {
+				name := rms.At(i).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name()
+				assert.Equal(t, lb.ring.endpoint([]byte(name)), endpoint)
+				metrics++
+			}
+		}
+	}
+	assert.Equal(t, 3, metrics)
+}
+
+func TestLoadBalancing_ExportError(t *testing.T) {
+	lb, endpoints := newTestExporter(t, traceIDRoutingKey, config.TracesDataType)
+	endpoints["b:4317"].err = errors.New("unavailable")
+
+	err := lb.pushTraces(context.Background(), generateTraces(30))
+	assert.EqualError(t, err, `failed to export to endpoint "b:4317": unavailable`)
+	assert.NotZero(t, endpoints["a:4317"].traces.SpansCount())
+	assert.NotZero(t, endpoints["c:4317"].traces.SpansCount())
+}
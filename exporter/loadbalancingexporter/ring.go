@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+)
+
+// virtualNodes is the number of points of every endpoint on the ring, to spread the keys evenly.
+const virtualNodes = 100
+
+// hashRing is a consistent hash ring of endpoints. The position of an endpoint on the ring only depends on the
+// endpoint itself, so adding or removing an endpoint only moves the keys from or to that endpoint.
+type hashRing struct {
+	points []ringPoint
+}
+
+type ringPoint struct {
+	hash     uint64
+	endpoint string
+}
+
+func newHashRing(endpoints []string) *hashRing {
+	points := make([]ringPoint, 0, len(endpoints)*virtualNodes)
+	for _, endpoint := range endpoints {
+		for i := 0; i < virtualNodes; i++ {
+			points = append(points, ringPoint{
+				hash:     hashKey([]byte(endpoint + "-" + strconv.Itoa(i))),
+				endpoint: endpoint,
+			})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		// Break the ties with the endpoint, for the ring not to depend on the order of the endpoints.
+		if points[i].hash == points[j].hash {
+			return points[i].endpoint < points[j].endpoint
+		}
+		return points[i].hash < points[j].hash
+	})
+	return &hashRing{points: points}
+}
+
+// endpoint returns the endpoint of the key, the first point of the ring at or after the hash of the key.
+func (r *hashRing) endpoint(key []byte) string {
+	hash := hashKey(key)
+	i := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= hash
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].endpoint
+}
+
+func hashKey(key []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(key)
+	// FNV spreads close keys poorly on the high bits, mix them before using the whole range of the ring.
+	return mix(h.Sum64())
+}
+
+// mix is the finalizer of MurmurHash3.
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
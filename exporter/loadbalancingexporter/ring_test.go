@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashRing_IndependentOfOrder(t *testing.T) {
+	r1 := newHashRing([]string{"a:4317", "b:4317", "c:4317"})
+	r2 := newHashRing([]string{"c:4317", "a:4317", "b:4317"})
+	for i := 0; i < 1000; i++ {
+		key := testKey(i)
+		assert.Equal(t, r1.endpoint(key), r2.endpoint(key))
+	}
+}
+
+func TestHashRing_Distribution(t *testing.T) {
+	r := newHashRing([]string{"a:4317", "b:4317", "c:4317"})
+	counts := make(map[string]int)
+	for i := 0; i < 30000; i++ {
+		counts[r.endpoint(testKey(i))]++
+	}
+	assert.Len(t, counts, 3)
+	for endpoint, count := range counts {
+		assert.InDelta(t, 10000, count, 2500, "endpoint %s", endpoint)
+	}
+}
+
+func TestHashRing_AddEndpointMovesKeysToItOnly(t *testing.T) {
+	before := newHashRing([]string{"a:4317", "b:4317", "c:4317"})
+	after := newHashRing([]string{"a:4317", "b:4317", "c:4317", "d:4317"})
+	moved := 0
+	for i := 0; i < 10000; i++ {
+		key := testKey(i)
+		if before.endpoint(key) != after.endpoint(key) {
+			assert.Equal(t, "d:4317", after.endpoint(key))
+			moved++
+		}
+	}
+	// About a quarter of the keys move to the new endpoint.
+	assert.InDelta(t, 2500, moved, 1000)
+}
+
+func TestHashRing_SingleEndpoint(t *testing.T) {
+	r := newHashRing([]string{"a:4317"})
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, "a:4317", r.endpoint([]byte(fmt.Sprint(i))))
+	}
+}
+
+func testKey(i int) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key[8:], uint64(i))
+	return key
+}
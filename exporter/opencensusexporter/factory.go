@@ -40,7 +40,8 @@ func NewFactory() component.ExporterFactory {
 
 func createDefaultConfig() config.Exporter {
 	return &Config{
-		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+		ExporterSettings:       config.NewExporterSettings(config.NewID(typeStr)),
+		CircuitBreakerSettings: exporterhelper.DefaultCircuitBreakerSettings(),
 		GRPCClientSettings: configgrpc.GRPCClientSettings{
 			Headers: map[string]string{},
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
@@ -63,6 +64,8 @@ func createTracesExporter(ctx context.Context, params component.ExporterCreatePa
 		oce.pushTraceData,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
 		exporterhelper.WithShutdown(oce.shutdown))
 }
@@ -80,6 +83,8 @@ func createMetricsExporter(ctx context.Context, params component.ExporterCreateP
 		oce.pushMetricsData,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
+		exporterhelper.WithCircuitBreaker(oCfg.CircuitBreakerSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
 		exporterhelper.WithShutdown(oce.shutdown))
 }
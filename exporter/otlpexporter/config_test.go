@@ -57,11 +57,20 @@ func TestLoadConfig(t *testing.T) {
 				MaxInterval:     1 * time.Minute,
 				MaxElapsedTime:  10 * time.Minute,
 			},
+			CircuitBreakerSettings: exporterhelper.CircuitBreakerSettings{
+				Enabled:          true,
+				FailureThreshold: 3,
+				OpenDuration:     1 * time.Minute,
+				FailFast:         true,
+			},
 			QueueSettings: exporterhelper.QueueSettings{
 				Enabled:      true,
 				NumConsumers: 2,
 				QueueSize:    10,
 			},
+			DeadLetterSettings: exporterhelper.DeadLetterSettings{
+				Exporter: "file/dead_letter",
+			},
 			GRPCClientSettings: configgrpc.GRPCClientSettings{
 				Headers: map[string]string{
 					"can you have a . here?": "F0000000-0000-0000-0000-000000000000",
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -162,6 +163,11 @@ func processError(err error) error {
 
 	// Now, this is this a real error.
 
+	if isMessageTooLarge(st) {
+		// Split the request in halves instead of retrying it as is.
+		return exporterhelper.NewRequestTooLarge(err)
+	}
+
 	if !shouldRetry(st.Code()) {
 		// It is not a retryable error, we should not retry.
 		return consumererror.Permanent(err)
@@ -213,6 +219,12 @@ func shouldRetry(code codes.Code) bool {
 	}
 }
 
+// isMessageTooLarge returns true if the message exceeded the maximum message size of the client or the server.
+// Both are reported with the ResourceExhausted code, that is used for exhausted quotas as well.
+func isMessageTooLarge(st *status.Status) bool {
+	return st.Code() == codes.ResourceExhausted && strings.Contains(st.Message(), "larger than max")
+}
+
 func getThrottleDuration(status *status.Status) time.Duration {
 	// See if throttling information is available.
 	for _, detail := range status.Details() {
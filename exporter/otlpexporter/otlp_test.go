@@ -26,13 +26,17 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/internal/pdatagrpc"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/obsreport"
@@ -496,3 +500,19 @@ func TestSendLogData(t *testing.T) {
 	assert.EqualValues(t, 2, atomic.LoadInt32(&rcv.totalItems))
 	assert.EqualValues(t, ld, rcv.GetLastRequest())
 }
+
+func TestProcessError(t *testing.T) {
+	assert.NoError(t, processError(nil))
+
+	err := status.Error(codes.InvalidArgument, "bad data")
+	assert.Equal(t, consumererror.Permanent(err), processError(err))
+
+	err = status.Error(codes.Unavailable, "unavailable")
+	assert.Equal(t, err, processError(err))
+
+	err = status.Error(codes.ResourceExhausted, "grpc: received message larger than max (8 vs. 4)")
+	assert.Equal(t, exporterhelper.NewRequestTooLarge(err), processError(err))
+
+	err = status.Error(codes.ResourceExhausted, "quota exceeded")
+	assert.Equal(t, err, processError(err))
+}
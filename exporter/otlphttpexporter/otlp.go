@@ -164,6 +164,11 @@ func (e *exporter) export(ctx context.Context, url string, request []byte) error
 		return exporterhelper.NewThrottleRetry(formattedErr, time.Duration(retryAfter)*time.Second)
 	}
 
+	if resp.StatusCode == http.StatusRequestEntityTooLarge {
+		// Indicate to our caller to split the request in halves.
+		return exporterhelper.NewRequestTooLarge(formattedErr)
+	}
+
 	if resp.StatusCode == http.StatusBadRequest {
 		// Report the failure as permanent if the server thinks the request is malformed.
 		return consumererror.Permanent(formattedErr)
@@ -385,6 +385,14 @@ func TestErrorResponses(t *testing.T) {
 			responseBody:   status.New(codes.InvalidArgument, "Bad field"),
 			isPermErr:      true,
 		},
+		{
+			name:           "413",
+			responseStatus: http.StatusRequestEntityTooLarge,
+			responseBody:   status.New(codes.InvalidArgument, "Request too large"),
+			// The empty request cannot be split, it is dropped permanently.
+			err: consumererror.Permanent(exporterhelper.NewRequestTooLarge(
+				fmt.Errorf(errMsgPrefix + "413, Message=Request too large, Details=[]"))),
+		},
 		{
 			name:           "404",
 			responseStatus: http.StatusNotFound,
@@ -58,6 +58,7 @@ func Test_loadConfig(t *testing.T) {
 				MaxInterval:     1 * time.Minute,
 				MaxElapsedTime:  10 * time.Minute,
 			},
+			CircuitBreakerSettings: exporterhelper.DefaultCircuitBreakerSettings(),
 			RemoteWriteQueue: RemoteWriteQueue{
 				QueueSize:    2000,
 				NumConsumers: 10,
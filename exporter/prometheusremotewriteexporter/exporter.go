@@ -25,8 +25,10 @@ import (
 	"math"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
@@ -35,9 +37,13 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
 
-const maxBatchByteSize = 3000000
+const (
+	maxBatchByteSize = 3000000
+	headerRetryAfter = "Retry-After"
+)
 
 // PrwExporter converts OTLP metrics to Prometheus remote write TimeSeries and sends them to a remote endpoint.
 type PrwExporter struct {
@@ -337,7 +343,7 @@ func (prwe *PrwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 	defer resp.Body.Close()
 
 	// 2xx status code is considered a success
-	// 5xx errors are recoverable and the exporter should retry
+	// 5xx and 429 errors are recoverable and the exporter should retry
 	// Reference for different behavior according to status code:
 	// https://github.com/prometheus/prometheus/pull/2552/files#diff-ae8db9d16d8057358e49d694522e7186
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
@@ -345,8 +351,34 @@ func (prwe *PrwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 	}
 	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 256))
 	rerr := fmt.Errorf("remote write returned HTTP status %v; err = %v: %s", resp.Status, err, body)
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		// Let the retry handler pause for the number of seconds requested by the server, if any.
+		if retryAfter := getRetryAfter(resp); retryAfter > 0 {
+			return exporterhelper.NewThrottleRetry(rerr, retryAfter)
+		}
+		return rerr
+	}
+	if resp.StatusCode == http.StatusRequestEntityTooLarge {
+		// Let the retry handler split the request in halves.
+		return exporterhelper.NewRequestTooLarge(rerr)
+	}
 	if resp.StatusCode >= 500 && resp.StatusCode < 600 {
 		return rerr
 	}
 	return consumererror.Permanent(rerr)
 }
+
+// getRetryAfter returns the delay from the Retry-After header, or 0 if the header is missing or invalid.
+func getRetryAfter(resp *http.Response) time.Duration {
+	val := resp.Header.Get(headerRetryAfter)
+	if val == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(val); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(val); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
@@ -16,12 +16,14 @@ package prometheusremotewriteexporter
 
 import (
 	"context"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
@@ -32,6 +34,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/internal/testdata"
@@ -251,6 +254,80 @@ func Test_export(t *testing.T) {
 	}
 }
 
+// Test_export_Throttle checks that the throttling responses are retryable and honor the Retry-After header.
+func Test_export_Throttle(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		retryAfter string
+		wantErr    error
+	}{
+		{
+			name:       "429",
+			statusCode: http.StatusTooManyRequests,
+			wantErr:    errors.New("remote write returned HTTP status 429 Too Many Requests; err = <nil>: "),
+		},
+		{
+			name:       "429-Retry-After",
+			statusCode: http.StatusTooManyRequests,
+			retryAfter: "30",
+			wantErr: exporterhelper.NewThrottleRetry(
+				errors.New("remote write returned HTTP status 429 Too Many Requests; err = <nil>: "),
+				30*time.Second),
+		},
+		{
+			name:       "503-Retry-After",
+			statusCode: http.StatusServiceUnavailable,
+			retryAfter: "10",
+			wantErr: exporterhelper.NewThrottleRetry(
+				errors.New("remote write returned HTTP status 503 Service Unavailable; err = <nil>: "),
+				10*time.Second),
+		},
+		{
+			name:       "413",
+			statusCode: http.StatusRequestEntityTooLarge,
+			wantErr: exporterhelper.NewRequestTooLarge(
+				errors.New("remote write returned HTTP status 413 Request Entity Too Large; err = <nil>: ")),
+		},
+		{
+			name:       "503-Invalid-Retry-After",
+			statusCode: http.StatusServiceUnavailable,
+			retryAfter: "invalid",
+			wantErr:    errors.New("remote write returned HTTP status 503 Service Unavailable; err = <nil>: "),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+			serverURL, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			errs := runExportPipeline(getTimeSeries(getPromLabels(label11, value11), getSample(floatVal1, msTime1)), serverURL)
+			require.Len(t, errs, 1)
+			assert.False(t, consumererror.IsPermanent(errs[0]))
+			assert.Equal(t, tt.wantErr, errs[0])
+		})
+	}
+}
+
+func Test_getRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	assert.Equal(t, time.Duration(0), getRetryAfter(resp))
+
+	resp.Header.Set("Retry-After", "5")
+	assert.Equal(t, 5*time.Second, getRetryAfter(resp))
+
+	resp.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.InDelta(t, float64(time.Hour), float64(getRetryAfter(resp)), float64(5*time.Second))
+}
+
 func runExportPipeline(ts *prompb.TimeSeries, endpoint *url.URL) []error {
 	var errs []error
 
@@ -78,6 +78,8 @@ func createMetricsExporter(_ context.Context, params component.ExporterCreatePar
 			QueueSize:    prwCfg.RemoteWriteQueue.QueueSize,
 		}),
 		exporterhelper.WithRetry(prwCfg.RetrySettings),
+		exporterhelper.WithCircuitBreaker(prwCfg.CircuitBreakerSettings),
+		exporterhelper.WithDeadLetter(prwCfg.DeadLetterSettings),
 		exporterhelper.WithResourceToTelemetryConversion(prwCfg.ResourceToTelemetrySettings),
 		exporterhelper.WithShutdown(prwe.Shutdown),
 	)
@@ -85,11 +87,12 @@ func createMetricsExporter(_ context.Context, params component.ExporterCreatePar
 
 func createDefaultConfig() config.Exporter {
 	return &Config{
-		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
-		Namespace:        "",
-		ExternalLabels:   map[string]string{},
-		TimeoutSettings:  exporterhelper.DefaultTimeoutSettings(),
-		RetrySettings:    exporterhelper.DefaultRetrySettings(),
+		ExporterSettings:       config.NewExporterSettings(config.NewID(typeStr)),
+		Namespace:              "",
+		ExternalLabels:         map[string]string{},
+		TimeoutSettings:        exporterhelper.DefaultTimeoutSettings(),
+		RetrySettings:          exporterhelper.DefaultRetrySettings(),
+		CircuitBreakerSettings: exporterhelper.DefaultCircuitBreakerSettings(),
 		HTTPClientSettings: confighttp.HTTPClientSettings{
 			Endpoint: "http://some.url:9411/api/prom/push",
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package batchpertrace splits traces into a batch per trace ID, it is shared by the processors that hold the
+// spans of a trace together.
+package batchpertrace
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// Split returns the spans of every trace of td, the resources and instrumentation libraries are copied
+// in the batch of every trace they have spans of.
+func Split(td pdata.Traces) map[pdata.TraceID]pdata.Traces {
+	traces := make(map[pdata.TraceID]pdata.Traces)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		destRSs := make(map[pdata.TraceID]pdata.ResourceSpans)
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			destILSs := make(map[pdata.TraceID]pdata.InstrumentationLibrarySpans)
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				traceID := span.TraceID()
+
+				destILS, ok := destILSs[traceID]
+				if !ok {
+					destRS, ok := destRSs[traceID]
+					if !ok {
+						trace, ok := traces[traceID]
+						if !ok {
+							trace = pdata.NewTraces()
+							traces[traceID] = trace
+						}
+						destRS = trace.ResourceSpans().AppendEmpty()
+						rs.Resource().CopyTo(destRS.Resource())
+						destRSs[traceID] = destRS
+					}
+					destILS = destRS.InstrumentationLibrarySpans().AppendEmpty()
+					ils.InstrumentationLibrary().CopyTo(destILS.InstrumentationLibrary())
+					destILSs[traceID] = destILS
+				}
+				span.CopyTo(destILS.Spans().AppendEmpty())
+			}
+		}
+	}
+	return traces
+}
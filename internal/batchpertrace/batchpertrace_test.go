@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchpertrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestSplit(t *testing.T) {
+	traceID1 := pdata.NewTraceID([16]byte{1})
+	traceID2 := pdata.NewTraceID([16]byte{2})
+
+	td := pdata.NewTraces()
+	for _, service := range []string{"frontend", "backend"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("service.name", service)
+		for _, library := range []string{"http", "grpc"} {
+			ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+			ils.InstrumentationLibrary().SetName(library)
+			for _, traceID := range []pdata.TraceID{traceID1, traceID2, traceID1} {
+				span := ils.Spans().AppendEmpty()
+				span.SetTraceID(traceID)
+				span.SetName(service + "/" + library)
+			}
+		}
+	}
+	// Only the first trace has spans in the last resource.
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "database")
+	rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetTraceID(traceID1)
+
+	traces := Split(td)
+	require.Len(t, traces, 2)
+	assert.Equal(t, 9, traces[traceID1].SpanCount())
+	assert.Equal(t, 4, traces[traceID2].SpanCount())
+	assert.Equal(t, 3, traces[traceID1].ResourceSpans().Len())
+	assert.Equal(t, 2, traces[traceID2].ResourceSpans().Len())
+
+	for traceID, trace := range traces {
+		rss := trace.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			service, _ := rss.At(i).Resource().Attributes().Get("service.name")
+			ilss := rss.At(i).InstrumentationLibrarySpans()
+			for j := 0; j < ilss.Len(); j++ {
+				spans := ilss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					assert.Equal(t, traceID, spans.At(k).TraceID())
+					if service.StringVal() != "database" {
+						assert.Equal(t, service.StringVal()+"/"+ilss.At(j).InstrumentationLibrary().Name(), spans.At(k).Name())
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSplit_Empty(t *testing.T) {
+	assert.Len(t, Split(pdata.NewTraces()), 0)
+}
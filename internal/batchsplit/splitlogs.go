@@ -12,14 +12,14 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package batchprocessor
+package batchsplit
 
 import (
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
-// splitLogs removes logrecords from the input data and returns a new data of the specified size.
-func splitLogs(size int, src pdata.Logs) pdata.Logs {
+// SplitLogs removes logrecords from the input data and returns a new data of the specified size.
+func SplitLogs(size int, src pdata.Logs) pdata.Logs {
 	if src.LogRecordCount() <= size {
 		return src
 	}
@@ -12,9 +12,10 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package batchprocessor
+package batchsplit
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,7 +27,7 @@ import (
 func TestSplitLogs_noop(t *testing.T) {
 	td := testdata.GenerateLogsManyLogRecordsSameResource(20)
 	splitSize := 40
-	split := splitLogs(splitSize, td)
+	split := SplitLogs(splitSize, td)
 	assert.Equal(t, td, split)
 
 	td.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().Resize(5)
@@ -53,24 +54,24 @@ func TestSplitLogs(t *testing.T) {
 	logs.At(4).CopyTo(cpLogs.At(4))
 
 	splitSize := 5
-	split := splitLogs(splitSize, ld)
+	split := SplitLogs(splitSize, ld)
 	assert.Equal(t, splitSize, split.LogRecordCount())
 	assert.Equal(t, cp, split)
 	assert.Equal(t, 15, ld.LogRecordCount())
 	assert.Equal(t, "test-log-int-0-0", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
 	assert.Equal(t, "test-log-int-0-4", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(4).Name())
 
-	split = splitLogs(splitSize, ld)
+	split = SplitLogs(splitSize, ld)
 	assert.Equal(t, 10, ld.LogRecordCount())
 	assert.Equal(t, "test-log-int-0-5", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
 	assert.Equal(t, "test-log-int-0-9", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(4).Name())
 
-	split = splitLogs(splitSize, ld)
+	split = SplitLogs(splitSize, ld)
 	assert.Equal(t, 5, ld.LogRecordCount())
 	assert.Equal(t, "test-log-int-0-10", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
 	assert.Equal(t, "test-log-int-0-14", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(4).Name())
 
-	split = splitLogs(splitSize, ld)
+	split = SplitLogs(splitSize, ld)
 	assert.Equal(t, 5, ld.LogRecordCount())
 	assert.Equal(t, "test-log-int-0-15", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
 	assert.Equal(t, "test-log-int-0-19", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(4).Name())
@@ -92,7 +93,7 @@ func TestSplitLogsMultipleResourceLogs(t *testing.T) {
 	}
 
 	splitSize := 5
-	split := splitLogs(splitSize, td)
+	split := SplitLogs(splitSize, td)
 	assert.Equal(t, splitSize, split.LogRecordCount())
 	assert.Equal(t, 35, td.LogRecordCount())
 	assert.Equal(t, "test-log-int-0-0", split.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Name())
@@ -115,7 +116,7 @@ func TestSplitLogsMultipleResourceLogs_split_size_greater_than_log_size(t *testi
 	}
 
 	splitSize := 25
-	split := splitLogs(splitSize, td)
+	split := SplitLogs(splitSize, td)
 	assert.Equal(t, splitSize, split.LogRecordCount())
 	assert.Equal(t, 40-splitSize, td.LogRecordCount())
 	assert.Equal(t, 1, td.ResourceLogs().Len())
@@ -140,7 +141,7 @@ func BenchmarkSplitLogs(b *testing.B) {
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
 		cloneReq := md.Clone()
-		split := splitLogs(128, cloneReq)
+		split := SplitLogs(128, cloneReq)
 		if split.LogRecordCount() != 128 || cloneReq.LogRecordCount() != 400-128 {
 			b.Fail()
 		}
@@ -167,3 +168,7 @@ func BenchmarkCloneLogs(b *testing.B) {
 		}
 	}
 }
+
+func getTestLogName(requestNum, index int) string {
+	return fmt.Sprintf("test-log-int-%d-%d", requestNum, index)
+}
@@ -12,14 +12,14 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package batchprocessor
+package batchsplit
 
 import (
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
-// splitMetrics removes metrics from the input data and returns a new data of the specified size.
-func splitMetrics(size int, src pdata.Metrics) pdata.Metrics {
+// SplitMetrics removes metrics from the input data and returns a new data of the specified size.
+func SplitMetrics(size int, src pdata.Metrics) pdata.Metrics {
 	if src.MetricCount() <= size {
 		return src
 	}
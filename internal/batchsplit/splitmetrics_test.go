@@ -12,9 +12,10 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package batchprocessor
+package batchsplit
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,7 +27,7 @@ import (
 func TestSplitMetrics_noop(t *testing.T) {
 	td := testdata.GenerateMetricsManyMetricsSameResource(20)
 	splitSize := 40
-	split := splitMetrics(splitSize, td)
+	split := SplitMetrics(splitSize, td)
 	assert.Equal(t, td, split)
 
 	td.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().Resize(5)
@@ -53,24 +54,24 @@ func TestSplitMetrics(t *testing.T) {
 	metrics.At(4).CopyTo(cpMetrics.At(4))
 
 	splitSize := 5
-	split := splitMetrics(splitSize, md)
+	split := SplitMetrics(splitSize, md)
 	assert.Equal(t, splitSize, split.MetricCount())
 	assert.Equal(t, cp, split)
 	assert.Equal(t, 15, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-0", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 	assert.Equal(t, "test-metric-int-0-4", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(4).Name())
 
-	split = splitMetrics(splitSize, md)
+	split = SplitMetrics(splitSize, md)
 	assert.Equal(t, 10, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-5", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 	assert.Equal(t, "test-metric-int-0-9", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(4).Name())
 
-	split = splitMetrics(splitSize, md)
+	split = SplitMetrics(splitSize, md)
 	assert.Equal(t, 5, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-10", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 	assert.Equal(t, "test-metric-int-0-14", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(4).Name())
 
-	split = splitMetrics(splitSize, md)
+	split = SplitMetrics(splitSize, md)
 	assert.Equal(t, 5, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-15", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
 	assert.Equal(t, "test-metric-int-0-19", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(4).Name())
@@ -91,7 +92,7 @@ func TestSplitMetricsMultipleResourceSpans(t *testing.T) {
 	}
 
 	splitSize := 5
-	split := splitMetrics(splitSize, md)
+	split := SplitMetrics(splitSize, md)
 	assert.Equal(t, splitSize, split.MetricCount())
 	assert.Equal(t, 35, md.MetricCount())
 	assert.Equal(t, "test-metric-int-0-0", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
@@ -114,7 +115,7 @@ func TestSplitMetricsMultipleResourceSpans_SplitSizeGreaterThanMetricSize(t *tes
 	}
 
 	splitSize := 25
-	split := splitMetrics(splitSize, td)
+	split := SplitMetrics(splitSize, td)
 	assert.Equal(t, splitSize, split.MetricCount())
 	assert.Equal(t, 40-splitSize, td.MetricCount())
 	assert.Equal(t, 1, td.ResourceMetrics().Len())
@@ -139,7 +140,7 @@ func BenchmarkSplitMetrics(b *testing.B) {
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
 		cloneReq := md.Clone()
-		split := splitMetrics(128, cloneReq)
+		split := SplitMetrics(128, cloneReq)
 		if split.MetricCount() != 128 || cloneReq.MetricCount() != 400-128 {
 			b.Fail()
 		}
@@ -166,3 +167,7 @@ func BenchmarkCloneMetrics(b *testing.B) {
 		}
 	}
 }
+
+func getTestMetricName(requestNum, index int) string {
+	return fmt.Sprintf("test-metric-int-%d-%d", requestNum, index)
+}
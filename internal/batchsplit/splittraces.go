@@ -12,14 +12,14 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package batchprocessor
+package batchsplit
 
 import (
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
-// splitTraces removes spans from the input trace and returns a new trace of the specified size.
-func splitTraces(size int, src pdata.Traces) pdata.Traces {
+// SplitTraces removes spans from the input trace and returns a new trace of the specified size.
+func SplitTraces(size int, src pdata.Traces) pdata.Traces {
 	if src.SpanCount() <= size {
 		return src
 	}
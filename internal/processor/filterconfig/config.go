@@ -17,6 +17,7 @@ package filterconfig
 import (
 	"errors"
 
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
 )
 
@@ -74,9 +75,12 @@ type MatchProperties struct {
 	// Note: For spans, one of Services, SpanNames, Attributes, Resources or Libraries must be specified with a
 	// non-empty value for a valid configuration.
 
-	// For logs, one of LogNames, Attributes, Resources or Libraries must be specified with a
+	// For metrics, one of MetricNames, Resources or Libraries must be specified with a
 	// non-empty value for a valid configuration.
 
+	// For logs, one of LogNames, LogSeverityTexts, LogSeverityNumber, LogBodies, Attributes, Resources or
+	// Libraries must be specified with a non-empty value for a valid configuration.
+
 	// Services specify the list of of items to match service name against.
 	// A match occurs if the span's service name matches at least one item in this list.
 	// This is an optional field.
@@ -91,6 +95,23 @@ type MatchProperties struct {
 	// against.
 	LogNames []string `mapstructure:"log_names"`
 
+	// LogSeverityTexts is a list of strings that the LogRecord's severity text field must match
+	// against.
+	LogSeverityTexts []string `mapstructure:"log_severity_texts"`
+
+	// LogSeverityNumber defines the minimum severity number that the LogRecord's severity number
+	// must have.
+	LogSeverityNumber *LogSeverityNumberMatchProperties `mapstructure:"log_severity_number"`
+
+	// LogBodies is a list of strings that the LogRecord's body, converted to a string, must match
+	// against.
+	LogBodies []string `mapstructure:"log_bodies"`
+
+	// MetricNames specify the list of items to match the metric name against.
+	// A match occurs if the metric name matches at least one item in this list.
+	// This is an optional field.
+	MetricNames []string `mapstructure:"metric_names"`
+
 	// Attributes specifies the list of attributes to match against.
 	// All of these attributes must match exactly for a match to occur.
 	// Only match_type=strict is allowed if "attributes" are specified.
@@ -114,6 +135,14 @@ func (mp *MatchProperties) ValidateForSpans() error {
 		return errors.New("log_names should not be specified for trace spans")
 	}
 
+	if len(mp.LogSeverityTexts) > 0 || mp.LogSeverityNumber != nil || len(mp.LogBodies) > 0 {
+		return errors.New("neither log_severity_texts, log_severity_number nor log_bodies should be specified for trace spans")
+	}
+
+	if len(mp.MetricNames) > 0 {
+		return errors.New("metric_names should not be specified for trace spans")
+	}
+
 	if len(mp.Services) == 0 && len(mp.SpanNames) == 0 && len(mp.Attributes) == 0 &&
 		len(mp.Libraries) == 0 && len(mp.Resources) == 0 {
 		return errors.New(`at least one of "services", "span_names", "attributes", "libraries" or "resources" field must be specified`)
@@ -128,8 +157,49 @@ func (mp *MatchProperties) ValidateForLogs() error {
 		return errors.New("neither services nor span_names should be specified for log records")
 	}
 
-	if len(mp.LogNames) == 0 && len(mp.Attributes) == 0 && len(mp.Libraries) == 0 && len(mp.Resources) == 0 {
-		return errors.New(`at least one of "log_names", "attributes", "libraries" or "resources" field must be specified`)
+	if len(mp.MetricNames) > 0 {
+		return errors.New("metric_names should not be specified for log records")
+	}
+
+	if len(mp.LogNames) == 0 && len(mp.LogSeverityTexts) == 0 && mp.LogSeverityNumber == nil && len(mp.LogBodies) == 0 &&
+		len(mp.Attributes) == 0 && len(mp.Libraries) == 0 && len(mp.Resources) == 0 {
+		return errors.New(`at least one of "log_names", "log_severity_texts", "log_severity_number", "log_bodies", "attributes", "libraries" or "resources" field must be specified`)
+	}
+
+	if mp.LogSeverityNumber != nil && (mp.LogSeverityNumber.Min < pdata.SeverityNumberTRACE || mp.LogSeverityNumber.Min > pdata.SeverityNumberFATAL4) {
+		return errors.New("log_severity_number min must be between 1 (TRACE) and 24 (FATAL4)")
+	}
+
+	return nil
+}
+
+// LogSeverityNumberMatchProperties specifies the minimum severity number to match against.
+type LogSeverityNumberMatchProperties struct {
+	// Min is the minimum severity number, 9 (INFO) for instance. A log record matches if its
+	// severity number is greater than or equal to Min.
+	Min pdata.SeverityNumber `mapstructure:"min"`
+
+	// MatchUndefined specifies whether the log records without a severity number match.
+	// By default, they do not match.
+	MatchUndefined bool `mapstructure:"match_undefined"`
+}
+
+// ValidateForMetrics validates properties for metrics.
+func (mp *MatchProperties) ValidateForMetrics() error {
+	if len(mp.Services) > 0 || len(mp.SpanNames) > 0 {
+		return errors.New("neither services nor span_names should be specified for metrics")
+	}
+
+	if len(mp.LogNames) > 0 || len(mp.LogSeverityTexts) > 0 || mp.LogSeverityNumber != nil || len(mp.LogBodies) > 0 {
+		return errors.New("neither log_names, log_severity_texts, log_severity_number nor log_bodies should be specified for metrics")
+	}
+
+	if len(mp.Attributes) > 0 {
+		return errors.New("attributes should not be specified for metrics")
+	}
+
+	if len(mp.MetricNames) == 0 && len(mp.Libraries) == 0 && len(mp.Resources) == 0 {
+		return errors.New(`at least one of "metric_names", "libraries" or "resources" field must be specified`)
 	}
 
 	return nil
@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/internal/processor/filterconfig"
 	"go.opentelemetry.io/collector/internal/processor/filtermatcher"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
 )
 
 // Matcher is an interface that allows matching a log record against a
@@ -37,6 +38,15 @@ type propertiesMatcher struct {
 
 	// log names to compare to.
 	nameFilters filterset.FilterSet
+
+	// log severity texts to compare to.
+	severityTextFilters filterset.FilterSet
+
+	// log severity number to compare to.
+	severityNumber *filterconfig.LogSeverityNumberMatchProperties
+
+	// log bodies to compare to.
+	bodyFilters filterset.FilterSet
 }
 
 // NewMatcher creates a LogRecord Matcher that matches based on the given MatchProperties.
@@ -62,23 +72,82 @@ func NewMatcher(mp *filterconfig.MatchProperties) (Matcher, error) {
 		}
 	}
 
+	var severityTextFS filterset.FilterSet
+	if len(mp.LogSeverityTexts) > 0 {
+		severityTextFS, err = filterset.CreateFilterSet(mp.LogSeverityTexts, &mp.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating log record severity text filters: %v", err)
+		}
+	}
+
+	var bodyFS filterset.FilterSet
+	if len(mp.LogBodies) > 0 {
+		bodyFS, err = filterset.CreateFilterSet(mp.LogBodies, &mp.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating log record body filters: %v", err)
+		}
+	}
+
 	return &propertiesMatcher{
-		PropertiesMatcher: rm,
-		nameFilters:       nameFS,
+		PropertiesMatcher:   rm,
+		nameFilters:         nameFS,
+		severityTextFilters: severityTextFS,
+		severityNumber:      mp.LogSeverityNumber,
+		bodyFilters:         bodyFS,
 	}, nil
 }
 
 // MatchLogRecord matches a log record to a set of properties.
-// There are 3 sets of properties to match against.
-// The log record names are matched, if specified.
-// The attributes are then checked, if specified.
-// At least one of log record names or attributes must be specified. It is
-// supported to have more than one of these specified, and all specified must
-// evaluate to true for a match to occur.
+// The log record names, severity texts, severity number and bodies are matched, if specified.
+// The attributes, resources and libraries are then checked, if specified.
+// At least one of these properties must be specified. It is supported to have
+// more than one of these specified, and all specified must evaluate to true
+// for a match to occur.
 func (mp *propertiesMatcher) MatchLogRecord(lr pdata.LogRecord, resource pdata.Resource, library pdata.InstrumentationLibrary) bool {
 	if mp.nameFilters != nil && !mp.nameFilters.Matches(lr.Name()) {
 		return false
 	}
 
+	if mp.severityTextFilters != nil && !mp.severityTextFilters.Matches(lr.SeverityText()) {
+		return false
+	}
+
+	if mp.severityNumber != nil && !matchSeverityNumber(mp.severityNumber, lr.SeverityNumber()) {
+		return false
+	}
+
+	if mp.bodyFilters != nil && !mp.bodyFilters.Matches(tracetranslator.AttributeValueToString(lr.Body())) {
+		return false
+	}
+
 	return mp.PropertiesMatcher.Match(lr.Attributes(), resource, library)
 }
+
+func matchSeverityNumber(snm *filterconfig.LogSeverityNumberMatchProperties, sn pdata.SeverityNumber) bool {
+	if sn == pdata.SeverityNumberUNDEFINED {
+		return snm.MatchUndefined
+	}
+	return sn >= snm.Min
+}
+
+// SkipLogRecord determines if a log record should be processed.
+// True is returned when a log record should be skipped.
+// False is returned when a log record should not be skipped.
+// Include properties are checked before exclude settings are checked.
+func SkipLogRecord(include Matcher, exclude Matcher, lr pdata.LogRecord, resource pdata.Resource, library pdata.InstrumentationLibrary) bool {
+	if include != nil {
+		// A false returned in this case means the log record should not be processed.
+		if i := include.MatchLogRecord(lr, resource, library); !i {
+			return true
+		}
+	}
+
+	if exclude != nil {
+		// A true returned in this case means the log record should not be processed.
+		if e := exclude.MatchLogRecord(lr, resource, library); e {
+			return true
+		}
+	}
+
+	return false
+}
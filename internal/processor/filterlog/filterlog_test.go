@@ -40,14 +40,14 @@ func TestLogRecord_validateMatchesConfiguration_InvalidConfig(t *testing.T) {
 		{
 			name:        "empty_property",
 			property:    filterconfig.MatchProperties{},
-			errorString: "at least one of \"log_names\", \"attributes\", \"libraries\" or \"resources\" field must be specified",
+			errorString: "at least one of \"log_names\", \"log_severity_texts\", \"log_severity_number\", \"log_bodies\", \"attributes\", \"libraries\" or \"resources\" field must be specified",
 		},
 		{
 			name: "empty_log_names_and_attributes",
 			property: filterconfig.MatchProperties{
 				LogNames: []string{},
 			},
-			errorString: "at least one of \"log_names\", \"attributes\", \"libraries\" or \"resources\" field must be specified",
+			errorString: "at least one of \"log_names\", \"log_severity_texts\", \"log_severity_number\", \"log_bodies\", \"attributes\", \"libraries\" or \"resources\" field must be specified",
 		},
 		{
 			name: "span_properties",
@@ -79,6 +79,30 @@ func TestLogRecord_validateMatchesConfiguration_InvalidConfig(t *testing.T) {
 			},
 			errorString: "error creating log record name filters: error parsing regexp: missing closing ]: `[`",
 		},
+		{
+			name: "invalid_severity_number",
+			property: filterconfig.MatchProperties{
+				Config:            *createConfig(filterset.Strict),
+				LogSeverityNumber: &filterconfig.LogSeverityNumberMatchProperties{Min: 25},
+			},
+			errorString: "log_severity_number min must be between 1 (TRACE) and 24 (FATAL4)",
+		},
+		{
+			name: "invalid_severity_text_pattern",
+			property: filterconfig.MatchProperties{
+				Config:           *createConfig(filterset.Regexp),
+				LogSeverityTexts: []string{"["},
+			},
+			errorString: "error creating log record severity text filters: error parsing regexp: missing closing ]: `[`",
+		},
+		{
+			name: "invalid_body_pattern",
+			property: filterconfig.MatchProperties{
+				Config:    *createConfig(filterset.Regexp),
+				LogBodies: []string{"["},
+			},
+			errorString: "error creating log record body filters: error parsing regexp: missing closing ]: `[`",
+		},
 		{
 			name: "invalid_regexp_pattern2",
 			property: filterconfig.MatchProperties{
@@ -181,3 +205,80 @@ func TestLogRecord_Matching_True(t *testing.T) {
 		})
 	}
 }
+
+func TestLogRecord_MatchingSeverityAndBody(t *testing.T) {
+	testcases := []struct {
+		name       string
+		properties *filterconfig.MatchProperties
+		debug      bool
+		info       bool
+		undefined  bool
+	}{
+		{
+			name: "severity_text",
+			properties: &filterconfig.MatchProperties{
+				Config:           *createConfig(filterset.Strict),
+				LogSeverityTexts: []string{"debug"},
+			},
+			debug: true,
+		},
+		{
+			name: "severity_number",
+			properties: &filterconfig.MatchProperties{
+				Config:            *createConfig(filterset.Strict),
+				LogSeverityNumber: &filterconfig.LogSeverityNumberMatchProperties{Min: pdata.SeverityNumberINFO},
+			},
+			info: true,
+		},
+		{
+			name: "severity_number_match_undefined",
+			properties: &filterconfig.MatchProperties{
+				Config:            *createConfig(filterset.Strict),
+				LogSeverityNumber: &filterconfig.LogSeverityNumberMatchProperties{Min: pdata.SeverityNumberINFO, MatchUndefined: true},
+			},
+			info:      true,
+			undefined: true,
+		},
+		{
+			name: "body",
+			properties: &filterconfig.MatchProperties{
+				Config:    *createConfig(filterset.Regexp),
+				LogBodies: []string{"^GET /health"},
+			},
+			debug:     true,
+			undefined: true,
+		},
+		{
+			name: "severity_text_and_body",
+			properties: &filterconfig.MatchProperties{
+				Config:           *createConfig(filterset.Regexp),
+				LogSeverityTexts: []string{"info"},
+				LogBodies:        []string{"^GET /health"},
+			},
+		},
+	}
+
+	debug := pdata.NewLogRecord()
+	debug.SetSeverityText("debug")
+	debug.SetSeverityNumber(pdata.SeverityNumberDEBUG)
+	debug.Body().SetStringVal("GET /health 200")
+
+	info := pdata.NewLogRecord()
+	info.SetSeverityText("info")
+	info.SetSeverityNumber(pdata.SeverityNumberINFO)
+	info.Body().SetStringVal("GET /checkout 200")
+
+	undefined := pdata.NewLogRecord()
+	undefined.Body().SetStringVal("GET /health 503")
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher, err := NewMatcher(tc.properties)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.debug, matcher.MatchLogRecord(debug, pdata.NewResource(), pdata.NewInstrumentationLibrary()))
+			assert.Equal(t, tc.info, matcher.MatchLogRecord(info, pdata.NewResource(), pdata.NewInstrumentationLibrary()))
+			assert.Equal(t, tc.undefined, matcher.MatchLogRecord(undefined, pdata.NewResource(), pdata.NewInstrumentationLibrary()))
+		})
+	}
+}
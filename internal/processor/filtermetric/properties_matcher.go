@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermetric
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filtermatcher"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+// PropertiesMatcher is an interface that allows matching a metric, with its
+// resource and instrumentation library, against a filterconfig.MatchProperties.
+type PropertiesMatcher interface {
+	MatchMetricProperties(metric pdata.Metric, resource pdata.Resource, library pdata.InstrumentationLibrary) bool
+}
+
+// propertiesMatcher allows matching a metric against various metric properties.
+type propertiesMatcher struct {
+	filtermatcher.PropertiesMatcher
+
+	// metric names to compare to.
+	nameFilters filterset.FilterSet
+}
+
+// NewPropertiesMatcher creates a metric PropertiesMatcher that matches based on the given MatchProperties.
+func NewPropertiesMatcher(mp *filterconfig.MatchProperties) (PropertiesMatcher, error) {
+	if mp == nil {
+		return nil, nil
+	}
+
+	if err := mp.ValidateForMetrics(); err != nil {
+		return nil, err
+	}
+
+	rm, err := filtermatcher.NewMatcher(mp)
+	if err != nil {
+		return nil, err
+	}
+
+	var nameFS filterset.FilterSet
+	if len(mp.MetricNames) > 0 {
+		nameFS, err = filterset.CreateFilterSet(mp.MetricNames, &mp.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating metric name filters: %v", err)
+		}
+	}
+
+	return &propertiesMatcher{
+		PropertiesMatcher: rm,
+		nameFilters:       nameFS,
+	}, nil
+}
+
+// MatchMetricProperties matches a metric to a set of properties.
+// The metric names are matched, if specified. The resources and libraries
+// are then checked, if specified. All specified properties must evaluate to
+// true for a match to occur.
+func (mp *propertiesMatcher) MatchMetricProperties(metric pdata.Metric, resource pdata.Resource, library pdata.InstrumentationLibrary) bool {
+	if mp.nameFilters != nil && !mp.nameFilters.Matches(metric.Name()) {
+		return false
+	}
+
+	// Attributes are not supported for metrics, the matcher ignores them.
+	return mp.PropertiesMatcher.Match(pdata.NewAttributeMap(), resource, library)
+}
+
+// SkipMetric determines if a metric should be processed.
+// True is returned when a metric should be skipped.
+// False is returned when a metric should not be skipped.
+// Include properties are checked before exclude settings are checked.
+func SkipMetric(include PropertiesMatcher, exclude PropertiesMatcher, metric pdata.Metric, resource pdata.Resource, library pdata.InstrumentationLibrary) bool {
+	if include != nil {
+		// A false returned in this case means the metric should not be processed.
+		if i := include.MatchMetricProperties(metric, resource, library); !i {
+			return true
+		}
+	}
+
+	if exclude != nil {
+		// A true returned in this case means the metric should not be processed.
+		if e := exclude.MatchMetricProperties(metric, resource, library); e {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+func TestNewPropertiesMatcher_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		mp   *filterconfig.MatchProperties
+		err  string
+	}{
+		{
+			name: "empty",
+			mp:   &filterconfig.MatchProperties{},
+			err:  `at least one of "metric_names", "libraries" or "resources" field must be specified`,
+		},
+		{
+			name: "span_names",
+			mp:   &filterconfig.MatchProperties{SpanNames: []string{"span"}},
+			err:  "neither services nor span_names should be specified for metrics",
+		},
+		{
+			name: "log_names",
+			mp:   &filterconfig.MatchProperties{LogNames: []string{"log"}},
+			err:  "neither log_names, log_severity_texts, log_severity_number nor log_bodies should be specified for metrics",
+		},
+		{
+			name: "attributes",
+			mp:   &filterconfig.MatchProperties{Attributes: []filterconfig.Attribute{{Key: "key"}}},
+			err:  "attributes should not be specified for metrics",
+		},
+		{
+			name: "invalid_regexp_pattern",
+			mp: &filterconfig.MatchProperties{
+				Config:      filterset.Config{MatchType: filterset.Regexp},
+				MetricNames: []string{"["},
+			},
+			err: "error creating metric name filters: error parsing regexp: missing closing ]: `[`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := NewPropertiesMatcher(tt.mp)
+			assert.Nil(t, matcher)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestSkipMetric(t *testing.T) {
+	include, err := NewPropertiesMatcher(&filterconfig.MatchProperties{
+		Config:      filterset.Config{MatchType: filterset.Regexp},
+		MetricNames: []string{"^http\\."},
+		Resources:   []filterconfig.Attribute{{Key: "service.name", Value: "checkout"}},
+	})
+	require.NoError(t, err)
+	exclude, err := NewPropertiesMatcher(&filterconfig.MatchProperties{
+		Config:      filterset.Config{MatchType: filterset.Strict},
+		MetricNames: []string{"http.server.active_requests"},
+	})
+	require.NoError(t, err)
+
+	checkout := pdata.NewResource()
+	checkout.Attributes().InsertString("service.name", "checkout")
+	payment := pdata.NewResource()
+	payment.Attributes().InsertString("service.name", "payment")
+	library := pdata.NewInstrumentationLibrary()
+
+	assert.False(t, SkipMetric(include, exclude, createMetric("http.server.duration"), checkout, library))
+	assert.True(t, SkipMetric(include, exclude, createMetric("http.server.active_requests"), checkout, library))
+	assert.True(t, SkipMetric(include, exclude, createMetric("http.server.duration"), payment, library))
+	assert.True(t, SkipMetric(include, exclude, createMetric("runtime.gc.count"), checkout, library))
+	assert.False(t, SkipMetric(nil, nil, createMetric("runtime.gc.count"), payment, library))
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstream
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// Identity returns the key of the stream of the data points with the labels, made of the resource attributes, the
+// instrumentation library, the name and data type of the metric, and the labels.
+func Identity(resource pdata.Resource, library pdata.InstrumentationLibrary, metric pdata.Metric, labels pdata.StringMap) string {
+	var b strings.Builder
+
+	attrs := make([]string, 0, resource.Attributes().Len())
+	resource.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+		attrs = append(attrs, k+"\x00"+tracetranslator.AttributeValueToString(v))
+		return true
+	})
+	writeSorted(&b, attrs)
+
+	b.WriteString(library.Name())
+	b.WriteByte(0)
+	b.WriteString(library.Version())
+	b.WriteByte(0)
+	b.WriteString(metric.Name())
+	b.WriteByte(0)
+	b.WriteString(metric.DataType().String())
+	b.WriteByte(0)
+
+	pairs := make([]string, 0, labels.Len())
+	labels.Range(func(k, v string) bool {
+		pairs = append(pairs, k+"\x00"+v)
+		return true
+	})
+	writeSorted(&b, pairs)
+	return b.String()
+}
+
+func writeSorted(b *strings.Builder, pairs []string) {
+	sort.Strings(pairs)
+	for _, p := range pairs {
+		b.WriteString(p)
+		b.WriteByte(0)
+	}
+	// Separates the pairs from the next fields.
+	b.WriteByte(1)
+}
+
+// Store holds a state per stream, and removes the streams that were not updated for some time.
+// It is not safe for concurrent use.
+type Store struct {
+	maxStale  time.Duration
+	streams   map[string]*entry
+	lastSweep time.Time
+}
+
+type entry struct {
+	state    interface{}
+	lastSeen time.Time
+}
+
+// NewStore returns a Store that removes the streams not updated for maxStale.
+func NewStore(maxStale time.Duration) *Store {
+	return &Store{
+		maxStale: maxStale,
+		streams:  make(map[string]*entry),
+	}
+}
+
+// Get returns the state of the stream, or false if the stream is unknown.
+func (s *Store) Get(key string) (interface{}, bool) {
+	e, ok := s.streams[key]
+	if !ok {
+		return nil, false
+	}
+	return e.state, true
+}
+
+// Put sets the state of the stream, updated at the given time.
+func (s *Store) Put(key string, state interface{}, now time.Time) {
+	if e, ok := s.streams[key]; ok {
+		e.state = state
+		e.lastSeen = now
+		return
+	}
+	s.streams[key] = &entry{state: state, lastSeen: now}
+}
+
+// Len returns the number of streams.
+func (s *Store) Len() int {
+	return len(s.streams)
+}
+
+// RemoveStale removes the streams that were not updated for maxStale, and returns their number. Since all the
+// streams are checked, it only does so once per maxStale.
+func (s *Store) RemoveStale(now time.Time) int {
+	if now.Sub(s.lastSweep) < s.maxStale {
+		return 0
+	}
+	s.lastSweep = now
+
+	removed := 0
+	for key, e := range s.streams {
+		if now.Sub(e.lastSeen) >= s.maxStale {
+			delete(s.streams, key)
+			removed++
+		}
+	}
+	return removed
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestIdentity(t *testing.T) {
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString("service.name", "checkout")
+	resource.Attributes().InsertInt("pid", 42)
+	library := pdata.NewInstrumentationLibrary()
+	library.SetName("http")
+	metric := pdata.NewMetric()
+	metric.SetName("requests")
+	metric.SetDataType(pdata.MetricDataTypeIntSum)
+	labels := pdata.NewStringMap()
+	labels.InitFromMap(map[string]string{"method": "GET", "code": "200"})
+
+	key := Identity(resource, library, metric, labels)
+
+	// The order of the attributes and labels does not matter.
+	otherResource := pdata.NewResource()
+	otherResource.Attributes().InsertInt("pid", 42)
+	otherResource.Attributes().InsertString("service.name", "checkout")
+	otherLabels := pdata.NewStringMap()
+	otherLabels.Insert("code", "200")
+	otherLabels.Insert("method", "GET")
+	assert.Equal(t, key, Identity(otherResource, library, metric, otherLabels))
+
+	otherResource.Attributes().UpdateInt("pid", 43)
+	assert.NotEqual(t, key, Identity(otherResource, library, metric, labels))
+
+	otherLabels.Update("code", "500")
+	assert.NotEqual(t, key, Identity(resource, library, metric, otherLabels))
+
+	otherMetric := pdata.NewMetric()
+	metric.CopyTo(otherMetric)
+	otherMetric.SetDataType(pdata.MetricDataTypeDoubleSum)
+	assert.NotEqual(t, key, Identity(resource, library, otherMetric, labels))
+
+	otherLibrary := pdata.NewInstrumentationLibrary()
+	otherLibrary.SetName("grpc")
+	assert.NotEqual(t, key, Identity(resource, otherLibrary, metric, labels))
+
+	// A label cannot be mistaken for a resource attribute.
+	emptyResource := pdata.NewResource()
+	serviceLabels := pdata.NewStringMap()
+	serviceLabels.Insert("service.name", "checkout")
+	serviceResource := pdata.NewResource()
+	serviceResource.Attributes().InsertString("service.name", "checkout")
+	assert.NotEqual(t,
+		Identity(emptyResource, library, metric, serviceLabels),
+		Identity(serviceResource, library, metric, pdata.NewStringMap()))
+}
+
+func TestStore(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	s := NewStore(time.Minute)
+
+	_, ok := s.Get("a")
+	assert.False(t, ok)
+
+	s.Put("a", 1, start)
+	s.Put("b", 2, start)
+	s.Put("b", 3, start.Add(50*time.Second))
+	state, ok := s.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 3, state)
+
+	// The first sweep finds nothing stale.
+	assert.Equal(t, 0, s.RemoveStale(start.Add(10*time.Second)))
+	// Sweeps at most once per minute.
+	assert.Equal(t, 0, s.RemoveStale(start.Add(65*time.Second)))
+	assert.Equal(t, 2, s.Len())
+
+	assert.Equal(t, 1, s.RemoveStale(start.Add(70*time.Second)))
+	_, ok = s.Get("a")
+	assert.False(t, ok)
+	_, ok = s.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestHistogramState_SameBuckets(t *testing.T) {
+	dp := pdata.NewHistogramDataPoint()
+	dp.SetBucketCounts([]uint64{1, 2, 3})
+	dp.SetExplicitBounds([]float64{10, 100})
+	state := NewHistogramState(0, dp)
+
+	// The state does not share the buckets of the data point.
+	dp.BucketCounts()[0] = 5
+	assert.Equal(t, []uint64{1, 2, 3}, state.BucketCounts)
+	assert.True(t, state.SameBuckets(dp))
+
+	dp.SetExplicitBounds([]float64{10, 50})
+	assert.False(t, state.SameBuckets(dp))
+	dp.SetExplicitBounds([]float64{10})
+	dp.SetBucketCounts([]uint64{1, 2})
+	assert.False(t, state.SameBuckets(dp))
+}
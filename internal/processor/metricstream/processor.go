@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// DefaultMaxStale is the default MaxStale of the Settings.
+const DefaultMaxStale = 5 * time.Minute
+
+// Settings are the configuration of the processors converting the metrics by stream.
+type Settings struct {
+	// Metrics are the names of the metrics to convert, all the sums and histograms are converted if not set.
+	Metrics []string `mapstructure:"metrics"`
+
+	// MaxStale is the time after which the state of a stream that received no data point is removed. The next
+	// data point of the stream is then handled as its first one.
+	MaxStale time.Duration `mapstructure:"max_stale"`
+}
+
+// Validate checks if the settings are valid.
+func (s *Settings) Validate() error {
+	if s.MaxStale <= 0 {
+		return errors.New("max_stale must be positive")
+	}
+	return nil
+}
+
+// ConvertFunc converts the data points of a metric using the states of their streams in the store, and returns
+// false if none is left.
+type ConvertFunc func(streams *Store, resource pdata.Resource, library pdata.InstrumentationLibrary, metric pdata.Metric, now time.Time) bool
+
+// Processor applies a ConvertFunc to the selected metrics, and removes the metrics left without data points.
+// It implements processorhelper.MProcessor.
+type Processor struct {
+	logger  *zap.Logger
+	metrics map[string]bool
+	convert ConvertFunc
+	// Now is overridable by tests.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	streams *Store
+}
+
+// NewProcessor returns a Processor converting the metrics selected by the settings.
+func NewProcessor(logger *zap.Logger, settings Settings, convert ConvertFunc) *Processor {
+	var metrics map[string]bool
+	if len(settings.Metrics) > 0 {
+		metrics = make(map[string]bool, len(settings.Metrics))
+		for _, name := range settings.Metrics {
+			metrics[name] = true
+		}
+	}
+	return &Processor{
+		logger:  logger,
+		metrics: metrics,
+		convert: convert,
+		Now:     time.Now,
+		streams: NewStore(settings.MaxStale),
+	}
+}
+
+// ProcessMetrics converts the metrics, and removes the metrics left without data points.
+func (p *Processor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.Now()
+	if removed := p.streams.RemoveStale(now); removed > 0 {
+		p.logger.Debug("Removed stale streams.", zap.Int("removed", removed), zap.Int("streams", p.streams.Len()))
+	}
+
+	md.ResourceMetrics().RemoveIf(func(rm pdata.ResourceMetrics) bool {
+		rm.InstrumentationLibraryMetrics().RemoveIf(func(ilm pdata.InstrumentationLibraryMetrics) bool {
+			ilm.Metrics().RemoveIf(func(metric pdata.Metric) bool {
+				if p.metrics != nil && !p.metrics[metric.Name()] {
+					return false
+				}
+				return !p.convert(p.streams, rm.Resource(), ilm.InstrumentationLibrary(), metric, now)
+			})
+			return ilm.Metrics().Len() == 0
+		})
+		return rm.InstrumentationLibraryMetrics().Len() == 0
+	})
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// StreamCount returns the number of streams with a state.
+func (p *Processor) StreamCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.streams.Len()
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstream
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// IntState and DoubleState are the state of a stream of sums: the last data point of the stream, or its
+// cumulative value.
+type IntState struct {
+	Start     pdata.Timestamp
+	Timestamp pdata.Timestamp
+	Value     int64
+}
+
+type DoubleState struct {
+	Start     pdata.Timestamp
+	Timestamp pdata.Timestamp
+	Value     float64
+}
+
+// HistogramState is the state of a stream of histograms: the last data point of the stream, or its cumulative
+// value.
+type HistogramState struct {
+	Start        pdata.Timestamp
+	Timestamp    pdata.Timestamp
+	Count        uint64
+	Sum          float64
+	BucketCounts []uint64
+	Bounds       []float64
+}
+
+// NewHistogramState returns the state of the data point, with copies of its buckets, starting at start.
+func NewHistogramState(start pdata.Timestamp, dp pdata.HistogramDataPoint) *HistogramState {
+	return &HistogramState{
+		Start:        start,
+		Timestamp:    dp.Timestamp(),
+		Count:        dp.Count(),
+		Sum:          dp.Sum(),
+		BucketCounts: append([]uint64(nil), dp.BucketCounts()...),
+		Bounds:       append([]float64(nil), dp.ExplicitBounds()...),
+	}
+}
+
+// SameBuckets returns whether the data point has the same bounds as the state, so that their bucket counts can
+// be combined.
+func (s *HistogramState) SameBuckets(dp pdata.HistogramDataPoint) bool {
+	bounds := dp.ExplicitBounds()
+	if len(s.Bounds) != len(bounds) || len(s.BucketCounts) != len(dp.BucketCounts()) {
+		return false
+	}
+	for i := range bounds {
+		if s.Bounds[i] != bounds[i] {
+			return false
+		}
+	}
+	return true
+}
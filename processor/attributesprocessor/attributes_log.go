@@ -46,6 +46,8 @@ func (a *logAttributesProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (
 		rs := rls.At(i)
 		ilss := rs.InstrumentationLibraryLogs()
 		resource := rs.Resource()
+		// The log records copying attributes to their resource are processed together.
+		var shared []pdata.AttributeMap
 		for j := 0; j < ilss.Len(); j++ {
 			ils := ilss.At(j)
 			logs := ils.Logs()
@@ -56,9 +58,16 @@ func (a *logAttributesProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (
 					continue
 				}
 
+				if a.attrProc.UsesTargets() {
+					shared = append(shared, lr.Attributes())
+					continue
+				}
 				a.attrProc.Process(lr.Attributes())
 			}
 		}
+		if len(shared) > 0 {
+			a.attrProc.ProcessShared(shared, resource.Attributes())
+		}
 	}
 	return ld, nil
 }
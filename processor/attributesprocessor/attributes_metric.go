@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filtermetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type metricAttributesProcessor struct {
+	attrProc *processorhelper.AttrProc
+	include  filtermetric.PropertiesMatcher
+	exclude  filtermetric.PropertiesMatcher
+}
+
+// newMetricAttributesProcessor returns a processor that modifies the labels of
+// the metric data points. To construct the attributes processors, the use of
+// the factory methods are required in order to validate the inputs.
+func newMetricAttributesProcessor(attrProc *processorhelper.AttrProc, include, exclude filtermetric.PropertiesMatcher) *metricAttributesProcessor {
+	return &metricAttributesProcessor{
+		attrProc: attrProc,
+		include:  include,
+		exclude:  exclude,
+	}
+}
+
+// ProcessMetrics implements the MetricsProcessor
+func (a *metricAttributesProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		ilms := rm.InstrumentationLibraryMetrics()
+		resource := rm.Resource()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			metrics := ilm.Metrics()
+			library := ilm.InstrumentationLibrary()
+			for k := 0; k < metrics.Len(); k++ {
+				m := metrics.At(k)
+				if filtermetric.SkipMetric(a.include, a.exclude, m, resource, library) {
+					continue
+				}
+
+				a.processMetricLabels(m)
+			}
+		}
+	}
+	return md, nil
+}
+
+// processMetricLabels applies the actions to the labels of every data point of the metric.
+func (a *metricAttributesProcessor) processMetricLabels(m pdata.Metric) {
+	switch m.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		dps := m.IntGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.attrProc.ProcessLabels(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		dps := m.DoubleGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.attrProc.ProcessLabels(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntSum:
+		dps := m.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.attrProc.ProcessLabels(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := m.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.attrProc.ProcessLabels(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		dps := m.IntHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.attrProc.ProcessLabels(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.attrProc.ProcessLabels(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.attrProc.ProcessLabels(dps.At(i).LabelsMap())
+		}
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// generateMetricData returns one metric of each data type with the given name prefix, each with one data point
+// with the given labels.
+func generateMetricData(prefix string, labels map[string]string) pdata.Metrics {
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	m := metrics.AppendEmpty()
+	m.SetName(prefix + ".int_gauge")
+	m.SetDataType(pdata.MetricDataTypeIntGauge)
+	m.IntGauge().DataPoints().AppendEmpty().LabelsMap().InitFromMap(labels)
+
+	m = metrics.AppendEmpty()
+	m.SetName(prefix + ".double_gauge")
+	m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	m.DoubleGauge().DataPoints().AppendEmpty().LabelsMap().InitFromMap(labels)
+
+	m = metrics.AppendEmpty()
+	m.SetName(prefix + ".int_sum")
+	m.SetDataType(pdata.MetricDataTypeIntSum)
+	m.IntSum().DataPoints().AppendEmpty().LabelsMap().InitFromMap(labels)
+
+	m = metrics.AppendEmpty()
+	m.SetName(prefix + ".double_sum")
+	m.SetDataType(pdata.MetricDataTypeDoubleSum)
+	m.DoubleSum().DataPoints().AppendEmpty().LabelsMap().InitFromMap(labels)
+
+	m = metrics.AppendEmpty()
+	m.SetName(prefix + ".int_histogram")
+	m.SetDataType(pdata.MetricDataTypeIntHistogram)
+	m.IntHistogram().DataPoints().AppendEmpty().LabelsMap().InitFromMap(labels)
+
+	m = metrics.AppendEmpty()
+	m.SetName(prefix + ".histogram")
+	m.SetDataType(pdata.MetricDataTypeHistogram)
+	m.Histogram().DataPoints().AppendEmpty().LabelsMap().InitFromMap(labels)
+
+	m = metrics.AppendEmpty()
+	m.SetName(prefix + ".summary")
+	m.SetDataType(pdata.MetricDataTypeSummary)
+	m.Summary().DataPoints().AppendEmpty().LabelsMap().InitFromMap(labels)
+
+	return md
+}
+
+func TestMetricProcessor_NilEmptyData(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input pdata.Metrics
+	}{
+		{
+			name:  "empty",
+			input: pdata.NewMetrics(),
+		},
+		{
+			name:  "one-empty-resource-metrics",
+			input: testdata.GenerateMetricsOneEmptyResourceMetrics(),
+		},
+		{
+			name:  "one-empty-instrumentation-library",
+			input: testdata.GenerateMetricsOneEmptyInstrumentationLibrary(),
+		},
+		{
+			name:  "one-metric-no-resource",
+			input: testdata.GenerateMetricsOneMetricNoResource(),
+		},
+	}
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Settings.Actions = []processorhelper.ActionKeyValue{
+		{Key: "label1", Action: processorhelper.INSERT, Value: 123},
+		{Key: "label1", Action: processorhelper.DELETE},
+	}
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, oCfg, consumertest.NewNop())
+	require.Nil(t, err)
+	require.NotNil(t, mp)
+	for i := range testCases {
+		tt := testCases[i]
+		t.Run(tt.name, func(t *testing.T) {
+			expected := tt.input.Clone()
+			assert.NoError(t, mp.ConsumeMetrics(context.Background(), tt.input))
+			assert.EqualValues(t, expected, tt.input)
+		})
+	}
+}
+
+func TestMetricProcessor_Labels(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Actions = []processorhelper.ActionKeyValue{
+		{Key: "user.id", Action: processorhelper.DELETE},
+		{Key: "user.email", Action: processorhelper.HASH},
+		{Key: "env", Action: processorhelper.UPSERT, Value: "prod"},
+	}
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, oCfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	md := generateMetricData("http", map[string]string{"user.id": "1234", "user.email": "john.doe@example.com", "method": "GET"})
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+
+	expected := generateMetricData("http", map[string]string{
+		"user.email": "73ec53c4ba1747d485ae2a0d7bfafa6cda80a5a9",
+		"method":     "GET",
+		"env":        "prod",
+	})
+	assert.Equal(t, sortMetricLabels(expected), sortMetricLabels(md))
+}
+
+func TestMetricProcessor_IncludeExclude(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Actions = []processorhelper.ActionKeyValue{
+		{Key: "user.id", Action: processorhelper.DELETE},
+	}
+	oCfg.Include = &filterconfig.MatchProperties{
+		Config:      filterset.Config{MatchType: filterset.Regexp},
+		MetricNames: []string{"^http\\."},
+	}
+	oCfg.Exclude = &filterconfig.MatchProperties{
+		Config:      filterset.Config{MatchType: filterset.Strict},
+		MetricNames: []string{"http.summary"},
+	}
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, oCfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	labels := map[string]string{"user.id": "1234"}
+	md := generateMetricData("http", labels)
+	generateMetricData("rpc", labels).ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+
+	got := map[string]int{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		metrics := rms.At(i).InstrumentationLibraryMetrics().At(0).Metrics()
+		for j := 0; j < metrics.Len(); j++ {
+			got[metrics.At(j).Name()] = metricLabelsLen(metrics.At(j))
+		}
+	}
+	assert.Equal(t, map[string]int{
+		"http.int_gauge":     0,
+		"http.double_gauge":  0,
+		"http.int_sum":       0,
+		"http.double_sum":    0,
+		"http.int_histogram": 0,
+		"http.histogram":     0,
+		// The excluded metric keeps its labels.
+		"http.summary":      1,
+		"rpc.int_gauge":     1,
+		"rpc.double_gauge":  1,
+		"rpc.int_sum":       1,
+		"rpc.double_sum":    1,
+		"rpc.int_histogram": 1,
+		"rpc.histogram":     1,
+		"rpc.summary":       1,
+	}, got)
+}
+
+// metricLabelsLen returns the number of labels of the first data point of the metric.
+func metricLabelsLen(m pdata.Metric) int {
+	switch m.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		return m.IntGauge().DataPoints().At(0).LabelsMap().Len()
+	case pdata.MetricDataTypeDoubleGauge:
+		return m.DoubleGauge().DataPoints().At(0).LabelsMap().Len()
+	case pdata.MetricDataTypeIntSum:
+		return m.IntSum().DataPoints().At(0).LabelsMap().Len()
+	case pdata.MetricDataTypeDoubleSum:
+		return m.DoubleSum().DataPoints().At(0).LabelsMap().Len()
+	case pdata.MetricDataTypeIntHistogram:
+		return m.IntHistogram().DataPoints().At(0).LabelsMap().Len()
+	case pdata.MetricDataTypeHistogram:
+		return m.Histogram().DataPoints().At(0).LabelsMap().Len()
+	case pdata.MetricDataTypeSummary:
+		return m.Summary().DataPoints().At(0).LabelsMap().Len()
+	}
+	return -1
+}
+
+func sortMetricLabels(md pdata.Metrics) pdata.Metrics {
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	metrics.At(0).IntGauge().DataPoints().At(0).LabelsMap().Sort()
+	metrics.At(1).DoubleGauge().DataPoints().At(0).LabelsMap().Sort()
+	metrics.At(2).IntSum().DataPoints().At(0).LabelsMap().Sort()
+	metrics.At(3).DoubleSum().DataPoints().At(0).LabelsMap().Sort()
+	metrics.At(4).IntHistogram().DataPoints().At(0).LabelsMap().Sort()
+	metrics.At(5).Histogram().DataPoints().At(0).LabelsMap().Sort()
+	metrics.At(6).Summary().DataPoints().At(0).LabelsMap().Sort()
+	return md
+}
@@ -45,6 +45,8 @@ func (a *spanAttributesProcessor) ProcessTraces(_ context.Context, td pdata.Trac
 	for i := 0; i < rss.Len(); i++ {
 		rs := rss.At(i)
 		resource := rs.Resource()
+		// The spans copying attributes to their resource are processed together.
+		var shared []pdata.AttributeMap
 		ilss := rs.InstrumentationLibrarySpans()
 		for j := 0; j < ilss.Len(); j++ {
 			ils := ilss.At(j)
@@ -56,9 +58,16 @@ func (a *spanAttributesProcessor) ProcessTraces(_ context.Context, td pdata.Trac
 					continue
 				}
 
+				if a.attrProc.UsesTargets() {
+					shared = append(shared, span.Attributes())
+					continue
+				}
 				a.attrProc.Process(span.Attributes())
 			}
 		}
+		if len(shared) > 0 {
+			a.attrProc.ProcessShared(shared, resource.Attributes())
+		}
 	}
 	return td, nil
 }
@@ -387,6 +387,72 @@ func TestAttributes_Hash(t *testing.T) {
 	}
 }
 
+func TestAttributes_MoveToResource(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Actions = []processorhelper.ActionKeyValue{
+		{Key: "http.status_code", Action: processorhelper.CONVERT, ConvertedType: "int"},
+		{Key: "host", Action: processorhelper.MOVE, ToAttribute: "host.name"},
+	}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, consumertest.NewNop())
+	require.Nil(t, err)
+	require.NotNil(t, tp)
+
+	td := generateTraceData("svc", "span", map[string]pdata.AttributeValue{
+		"http.status_code": pdata.NewAttributeValueString("500"),
+		"host":             pdata.NewAttributeValueString("node-1"),
+	})
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	sortAttributes(td)
+
+	expected := generateTraceData("svc", "span", map[string]pdata.AttributeValue{
+		"http.status_code": pdata.NewAttributeValueInt(500),
+	})
+	expected.ResourceSpans().At(0).Resource().Attributes().UpsertString("host.name", "node-1")
+	sortAttributes(expected)
+	assert.Equal(t, expected, td)
+}
+
+func TestAttributes_MoveToResourceDifferentValues(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Actions = []processorhelper.ActionKeyValue{
+		{Key: "host", Action: processorhelper.MOVE, ToAttribute: "host.name"},
+		{Key: "zone", Action: processorhelper.MOVE},
+	}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, consumertest.NewNop())
+	require.Nil(t, err)
+	require.NotNil(t, tp)
+
+	td := generateTraceData("svc", "span1", map[string]pdata.AttributeValue{
+		"host": pdata.NewAttributeValueString("node-1"),
+		"zone": pdata.NewAttributeValueString("a"),
+	})
+	span2 := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().AppendEmpty()
+	span2.SetName("span2")
+	span2.Attributes().InitFromMap(map[string]pdata.AttributeValue{
+		"host": pdata.NewAttributeValueString("node-2"),
+		"zone": pdata.NewAttributeValueString("a"),
+	})
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	sortAttributes(td)
+
+	// The hosts differ, they are kept in the spans. The zone is the same, it is moved to the resource.
+	expected := generateTraceData("svc", "span1", map[string]pdata.AttributeValue{
+		"host": pdata.NewAttributeValueString("node-1"),
+	})
+	expectedSpan2 := expected.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().AppendEmpty()
+	expectedSpan2.SetName("span2")
+	expectedSpan2.Attributes().InsertString("host", "node-2")
+	expected.ResourceSpans().At(0).Resource().Attributes().UpsertString("zone", "a")
+	sortAttributes(expected)
+	assert.Equal(t, expected, td)
+}
+
 func BenchmarkAttributes_FilterSpansByName(b *testing.B) {
 	testCases := []testCase{
 		{
@@ -33,7 +33,8 @@ type Config struct {
 	filterconfig.MatchConfig `mapstructure:",squash"`
 
 	// Specifies the list of attributes to act on.
-	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT}.
+	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT,
+	// CONVERT, TRUNCATE, COPY, MOVE}.
 	// This is a required field.
 	processorhelper.Settings `mapstructure:",squash"`
 }
@@ -201,4 +201,20 @@ func TestLoadingConfig(t *testing.T) {
 		},
 	})
 
+	p11 := cfg.Processors[config.NewIDWithName(typeStr, "metrics")]
+	assert.Equal(t, p11, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "metrics")),
+		MatchConfig: filterconfig.MatchConfig{
+			Include: &filterconfig.MatchProperties{
+				Config:      *createConfig(filterset.Regexp),
+				MetricNames: []string{"^http\\.server\\."},
+			},
+		},
+		Settings: processorhelper.Settings{
+			Actions: []processorhelper.ActionKeyValue{
+				{Key: "user.id", Action: processorhelper.DELETE},
+				{Key: "user.email", Action: processorhelper.HASH},
+			},
+		},
+	})
 }
@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/internal/processor/filterlog"
+	"go.opentelemetry.io/collector/internal/processor/filtermetric"
 	"go.opentelemetry.io/collector/internal/processor/filterspan"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 )
@@ -39,6 +40,7 @@ func NewFactory() component.ProcessorFactory {
 		typeStr,
 		createDefaultConfig,
 		processorhelper.WithTraces(createTracesProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
 		processorhelper.WithLogs(createLogProcessor))
 }
 
@@ -79,6 +81,39 @@ func createTracesProcessor(
 		processorhelper.WithCapabilities(processorCapabilities))
 }
 
+func createMetricsProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateParams,
+	cfg config.Processor,
+	nextConsumer consumer.Metrics,
+) (component.MetricsProcessor, error) {
+	oCfg := cfg.(*Config)
+	if len(oCfg.Actions) == 0 {
+		return nil, fmt.Errorf("error creating \"attributes\" processor due to missing required field \"actions\" of processor %v", cfg.ID())
+	}
+	attrProc, err := processorhelper.NewAttrProc(&oCfg.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("error creating \"attributes\" processor: %w of processor %v", err, cfg.ID())
+	}
+	if attrProc.UsesTargets() {
+		return nil, fmt.Errorf("error creating \"attributes\" processor: copy and move actions are not supported for metrics of processor %v", cfg.ID())
+	}
+	include, err := filtermetric.NewPropertiesMatcher(oCfg.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := filtermetric.NewPropertiesMatcher(oCfg.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return processorhelper.NewMetricsProcessor(
+		cfg,
+		nextConsumer,
+		newMetricAttributesProcessor(attrProc, include, exclude),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
 func createLogProcessor(
 	_ context.Context,
 	_ component.ProcessorCreateParams,
@@ -19,13 +19,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configcheck"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 )
 
@@ -88,13 +87,64 @@ func TestFactoryCreateTracesProcessor(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestFactory_CreateMetricsProcessor(t *testing.T) {
+func TestFactoryCreateMetricsProcessor_EmptyActions(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
+	mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, mp)
+}
+
+func TestFactoryCreateMetricsProcessor_InvalidActions(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	// Missing key
+	oCfg.Actions = []processorhelper.ActionKeyValue{
+		{Key: "", Value: 123, Action: processorhelper.UPSERT},
+	}
+	mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, mp)
+}
+
+func TestFactoryCreateMetricsProcessor(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Actions = []processorhelper.ActionKeyValue{
+		{Key: "a key", Action: processorhelper.DELETE},
+	}
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, consumertest.NewNop())
+	assert.NotNil(t, mp)
+	assert.NoError(t, err)
+
+	mp, err = factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, nil)
+	assert.Nil(t, mp)
+	assert.Error(t, err)
+
+	oCfg.Include = &filterconfig.MatchProperties{SpanNames: []string{"span"}}
+	mp, err = factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, consumertest.NewNop())
+	assert.Nil(t, mp)
+	assert.Error(t, err)
+}
+
+func TestFactoryCreateMetricsProcessor_CopyActions(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Actions = []processorhelper.ActionKeyValue{
+		{Key: "host", Action: processorhelper.COPY},
+	}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, consumertest.NewNop())
+	assert.NotNil(t, tp)
+	assert.NoError(t, err)
 
-	mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, nil)
-	require.Nil(t, mp)
-	assert.Equal(t, err, componenterror.ErrDataTypeIsNotSupported)
+	mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, cfg, consumertest.NewNop())
+	assert.Nil(t, mp)
+	assert.EqualError(t, err, `error creating "attributes" processor: copy and move actions are not supported for metrics of processor attributes`)
 }
 
 func TestFactoryCreateLogsProcessor_EmptyActions(t *testing.T) {
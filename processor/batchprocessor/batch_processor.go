@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/batchsplit"
 )
 
 // batch_processor is a component that accepts spans and metrics, places them
@@ -244,7 +245,7 @@ func (bt *batchTraces) add(item interface{}) {
 func (bt *batchTraces) export(ctx context.Context, sendBatchMaxSize int) error {
 	var req pdata.Traces
 	if sendBatchMaxSize > 0 && bt.itemCount() > sendBatchMaxSize {
-		req = splitTraces(sendBatchMaxSize, bt.traceData)
+		req = batchsplit.SplitTraces(sendBatchMaxSize, bt.traceData)
 		bt.spanCount -= sendBatchMaxSize
 	} else {
 		req = bt.traceData
@@ -275,7 +276,7 @@ func newBatchMetrics(nextConsumer consumer.Metrics) *batchMetrics {
 func (bm *batchMetrics) export(ctx context.Context, sendBatchMaxSize int) error {
 	var req pdata.Metrics
 	if sendBatchMaxSize > 0 && bm.metricCount > sendBatchMaxSize {
-		req = splitMetrics(sendBatchMaxSize, bm.metricData)
+		req = batchsplit.SplitMetrics(sendBatchMaxSize, bm.metricData)
 		bm.metricCount -= sendBatchMaxSize
 	} else {
 		req = bm.metricData
@@ -317,7 +318,7 @@ func newBatchLogs(nextConsumer consumer.Logs) *batchLogs {
 func (bl *batchLogs) export(ctx context.Context, sendBatchMaxSize int) error {
 	var req pdata.Logs
 	if sendBatchMaxSize > 0 && bl.logCount > sendBatchMaxSize {
-		req = splitLogs(sendBatchMaxSize, bl.logData)
+		req = batchsplit.SplitLogs(sendBatchMaxSize, bl.logData)
 		bl.logCount -= sendBatchMaxSize
 	} else {
 		req = bl.logData
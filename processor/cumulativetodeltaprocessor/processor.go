@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/metricstream"
+)
+
+// newCumulativeToDeltaProcessor returns a processor converting the cumulative sums and histograms into delta ones,
+// by subtracting the previous data point of the stream from each data point. The first data point of a stream is
+// dropped, since the value before it is unknown.
+func newCumulativeToDeltaProcessor(logger *zap.Logger, cfg *Config) *metricstream.Processor {
+	return metricstream.NewProcessor(logger, cfg.Settings, convertMetric)
+}
+
+// convertMetric converts the data points of a cumulative metric, and returns false if none is left.
+func convertMetric(streams *metricstream.Store, resource pdata.Resource, library pdata.InstrumentationLibrary, metric pdata.Metric, now time.Time) bool {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		sum := metric.IntSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityCumulative {
+			return true
+		}
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		sum.DataPoints().RemoveIf(func(dp pdata.IntDataPoint) bool {
+			key := metricstream.Identity(resource, library, metric, dp.LabelsMap())
+			return !convertIntDataPoint(streams, key, dp, sum.IsMonotonic(), now)
+		})
+		return sum.DataPoints().Len() > 0
+	case pdata.MetricDataTypeDoubleSum:
+		sum := metric.DoubleSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityCumulative {
+			return true
+		}
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		sum.DataPoints().RemoveIf(func(dp pdata.DoubleDataPoint) bool {
+			key := metricstream.Identity(resource, library, metric, dp.LabelsMap())
+			return !convertDoubleDataPoint(streams, key, dp, sum.IsMonotonic(), now)
+		})
+		return sum.DataPoints().Len() > 0
+	case pdata.MetricDataTypeHistogram:
+		histogram := metric.Histogram()
+		if histogram.AggregationTemporality() != pdata.AggregationTemporalityCumulative {
+			return true
+		}
+		histogram.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		histogram.DataPoints().RemoveIf(func(dp pdata.HistogramDataPoint) bool {
+			key := metricstream.Identity(resource, library, metric, dp.LabelsMap())
+			return !convertHistogramDataPoint(streams, key, dp, now)
+		})
+		return histogram.DataPoints().Len() > 0
+	}
+	return true
+}
+
+// convertIntDataPoint sets the data point to the delta from the previous one, and returns false if it must be
+// dropped.
+func convertIntDataPoint(streams *metricstream.Store, key string, dp pdata.IntDataPoint, monotonic bool, now time.Time) bool {
+	cur := &metricstream.IntState{Start: dp.StartTimestamp(), Timestamp: dp.Timestamp(), Value: dp.Value()}
+	state, ok := streams.Get(key)
+	if !ok {
+		streams.Put(key, cur, now)
+		return false
+	}
+	prev := state.(*metricstream.IntState)
+	if cur.Timestamp <= prev.Timestamp {
+		// Duplicated or out of order.
+		return false
+	}
+	streams.Put(key, cur, now)
+
+	if isReset(prev.Start, cur.Start) || (monotonic && cur.Value < prev.Value) {
+		// The value is the delta since the reset.
+		dp.SetStartTimestamp(resetStart(prev.Timestamp, cur.Start))
+		return true
+	}
+	dp.SetStartTimestamp(prev.Timestamp)
+	dp.SetValue(cur.Value - prev.Value)
+	return true
+}
+
+// convertDoubleDataPoint sets the data point to the delta from the previous one, and returns false if it must be
+// dropped.
+func convertDoubleDataPoint(streams *metricstream.Store, key string, dp pdata.DoubleDataPoint, monotonic bool, now time.Time) bool {
+	cur := &metricstream.DoubleState{Start: dp.StartTimestamp(), Timestamp: dp.Timestamp(), Value: dp.Value()}
+	state, ok := streams.Get(key)
+	if !ok {
+		streams.Put(key, cur, now)
+		return false
+	}
+	prev := state.(*metricstream.DoubleState)
+	if cur.Timestamp <= prev.Timestamp {
+		// Duplicated or out of order.
+		return false
+	}
+	streams.Put(key, cur, now)
+
+	if isReset(prev.Start, cur.Start) || (monotonic && cur.Value < prev.Value) {
+		// The value is the delta since the reset.
+		dp.SetStartTimestamp(resetStart(prev.Timestamp, cur.Start))
+		return true
+	}
+	dp.SetStartTimestamp(prev.Timestamp)
+	dp.SetValue(cur.Value - prev.Value)
+	return true
+}
+
+// convertHistogramDataPoint sets the data point to the delta from the previous one, and returns false if it must
+// be dropped.
+func convertHistogramDataPoint(streams *metricstream.Store, key string, dp pdata.HistogramDataPoint, now time.Time) bool {
+	cur := metricstream.NewHistogramState(dp.StartTimestamp(), dp)
+	state, ok := streams.Get(key)
+	if !ok {
+		streams.Put(key, cur, now)
+		return false
+	}
+	prev := state.(*metricstream.HistogramState)
+	if cur.Timestamp <= prev.Timestamp {
+		// Duplicated or out of order.
+		return false
+	}
+	streams.Put(key, cur, now)
+
+	if !prev.SameBuckets(dp) {
+		// The buckets cannot be subtracted, handled as a new stream.
+		return false
+	}
+	if isReset(prev.Start, cur.Start) || cur.Count < prev.Count {
+		// The value is the delta since the reset.
+		dp.SetStartTimestamp(resetStart(prev.Timestamp, cur.Start))
+		return true
+	}
+
+	bucketCounts := make([]uint64, len(cur.BucketCounts))
+	for i := range bucketCounts {
+		bucketCounts[i] = cur.BucketCounts[i] - prev.BucketCounts[i]
+	}
+	dp.SetStartTimestamp(prev.Timestamp)
+	dp.SetCount(cur.Count - prev.Count)
+	dp.SetSum(cur.Sum - prev.Sum)
+	dp.SetBucketCounts(bucketCounts)
+	return true
+}
+
+// isReset returns whether the start timestamp of the stream changed, meaning that the counter was reset.
+func isReset(prevStart, curStart pdata.Timestamp) bool {
+	return prevStart != 0 && curStart != 0 && prevStart != curStart
+}
+
+// resetStart returns the start timestamp of the delta since a reset: the new start timestamp of the stream if it
+// is known, or else the timestamp of the previous data point.
+func resetStart(prevTimestamp, curStart pdata.Timestamp) pdata.Timestamp {
+	if curStart > prevTimestamp {
+		return curStart
+	}
+	return prevTimestamp
+}
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/metricstream"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+var startTime = time.Unix(1600000000, 0)
+
+func timestamp(seconds int) pdata.Timestamp {
+	return pdata.TimestampFromTime(startTime.Add(time.Duration(seconds) * time.Second))
+}
+
+func newTestProcessor(cfg *Config) (*metricstream.Processor, *time.Time) {
+	p := newCumulativeToDeltaProcessor(zap.NewNop(), cfg)
+	now := startTime
+	p.Now = func() time.Time { return now }
+	return p, &now
+}
+
+func newMetric(md pdata.Metrics, name string, dataType pdata.MetricDataType) pdata.Metric {
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "checkout")
+	metric := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName(name)
+	metric.SetDataType(dataType)
+	return metric
+}
+
+func intSum(name string, temporality pdata.AggregationTemporality, start, ts int, value int64) pdata.Metrics {
+	md := pdata.NewMetrics()
+	sum := newMetric(md, name, pdata.MetricDataTypeIntSum).IntSum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(temporality)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.LabelsMap().Insert("method", "GET")
+	dp.SetStartTimestamp(timestamp(start))
+	dp.SetTimestamp(timestamp(ts))
+	dp.SetValue(value)
+	return md
+}
+
+func histogram(start, ts int, count uint64, sum float64, bucketCounts []uint64, bounds []float64) pdata.Metrics {
+	md := pdata.NewMetrics()
+	h := newMetric(md, "latency", pdata.MetricDataTypeHistogram).Histogram()
+	h.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	dp := h.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(timestamp(start))
+	dp.SetTimestamp(timestamp(ts))
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetBucketCounts(bucketCounts)
+	dp.SetExplicitBounds(bounds)
+	return md
+}
+
+func firstMetric(md pdata.Metrics) pdata.Metric {
+	return md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+}
+
+func TestCumulativeToDelta_IntSum(t *testing.T) {
+	p, _ := newTestProcessor(createDefaultConfig().(*Config))
+
+	// The first data point is dropped.
+	_, err := p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityCumulative, 0, 10, 10))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	md, err := p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityCumulative, 0, 20, 15))
+	require.NoError(t, err)
+	sum := firstMetric(md).IntSum()
+	assert.Equal(t, pdata.AggregationTemporalityDelta, sum.AggregationTemporality())
+	dp := sum.DataPoints().At(0)
+	assert.Equal(t, int64(5), dp.Value())
+	assert.Equal(t, timestamp(10), dp.StartTimestamp())
+	assert.Equal(t, timestamp(20), dp.Timestamp())
+
+	// Duplicated or out of order data points are dropped.
+	_, err = p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityCumulative, 0, 20, 15))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	_, err = p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityCumulative, 0, 15, 12))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	// A decrease of a monotonic sum is a reset.
+	md, err = p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityCumulative, 0, 30, 3))
+	require.NoError(t, err)
+	dp = firstMetric(md).IntSum().DataPoints().At(0)
+	assert.Equal(t, int64(3), dp.Value())
+	assert.Equal(t, timestamp(20), dp.StartTimestamp())
+
+	// So is a new start timestamp.
+	md, err = p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityCumulative, 35, 40, 7))
+	require.NoError(t, err)
+	dp = firstMetric(md).IntSum().DataPoints().At(0)
+	assert.Equal(t, int64(7), dp.Value())
+	assert.Equal(t, timestamp(35), dp.StartTimestamp())
+}
+
+func TestCumulativeToDelta_DoubleSum(t *testing.T) {
+	p, _ := newTestProcessor(createDefaultConfig().(*Config))
+
+	newDoubleSum := func(ts int, value float64) pdata.Metrics {
+		md := pdata.NewMetrics()
+		sum := newMetric(md, "queue_size", pdata.MetricDataTypeDoubleSum).DoubleSum()
+		sum.SetIsMonotonic(false)
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(timestamp(0))
+		dp.SetTimestamp(timestamp(ts))
+		dp.SetValue(value)
+		return md
+	}
+
+	_, err := p.ProcessMetrics(context.Background(), newDoubleSum(10, 2.5))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	// A decrease of a non monotonic sum is a negative delta.
+	md, err := p.ProcessMetrics(context.Background(), newDoubleSum(20, 1))
+	require.NoError(t, err)
+	dp := firstMetric(md).DoubleSum().DataPoints().At(0)
+	assert.Equal(t, -1.5, dp.Value())
+	assert.Equal(t, timestamp(10), dp.StartTimestamp())
+}
+
+func TestCumulativeToDelta_Histogram(t *testing.T) {
+	p, _ := newTestProcessor(createDefaultConfig().(*Config))
+
+	_, err := p.ProcessMetrics(context.Background(), histogram(0, 10, 3, 30, []uint64{1, 2, 0}, []float64{10, 100}))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	md, err := p.ProcessMetrics(context.Background(), histogram(0, 20, 6, 300, []uint64{2, 3, 1}, []float64{10, 100}))
+	require.NoError(t, err)
+	h := firstMetric(md).Histogram()
+	assert.Equal(t, pdata.AggregationTemporalityDelta, h.AggregationTemporality())
+	dp := h.DataPoints().At(0)
+	assert.Equal(t, timestamp(10), dp.StartTimestamp())
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, float64(270), dp.Sum())
+	assert.Equal(t, []uint64{1, 1, 1}, dp.BucketCounts())
+
+	// Reset.
+	md, err = p.ProcessMetrics(context.Background(), histogram(0, 30, 1, 5, []uint64{1, 0, 0}, []float64{10, 100}))
+	require.NoError(t, err)
+	dp = firstMetric(md).Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(1), dp.Count())
+	assert.Equal(t, []uint64{1, 0, 0}, dp.BucketCounts())
+
+	// New bounds start a new stream.
+	_, err = p.ProcessMetrics(context.Background(), histogram(0, 40, 2, 10, []uint64{2, 0}, []float64{10}))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	md, err = p.ProcessMetrics(context.Background(), histogram(0, 50, 3, 20, []uint64{2, 1}, []float64{10}))
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{0, 1}, firstMetric(md).Histogram().DataPoints().At(0).BucketCounts())
+}
+
+func TestCumulativeToDelta_Streams(t *testing.T) {
+	p, _ := newTestProcessor(createDefaultConfig().(*Config))
+
+	md := intSum("requests", pdata.AggregationTemporalityCumulative, 0, 10, 10)
+	dp := firstMetric(md).IntSum().DataPoints().AppendEmpty()
+	dp.LabelsMap().Insert("method", "POST")
+	dp.SetTimestamp(timestamp(10))
+	dp.SetValue(100)
+	_, err := p.ProcessMetrics(context.Background(), md)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	md = intSum("requests", pdata.AggregationTemporalityCumulative, 0, 20, 11)
+	dp = firstMetric(md).IntSum().DataPoints().AppendEmpty()
+	dp.LabelsMap().Insert("method", "POST")
+	dp.SetTimestamp(timestamp(20))
+	dp.SetValue(120)
+	// Another resource.
+	other := intSum("requests", pdata.AggregationTemporalityCumulative, 0, 20, 1000)
+	other.ResourceMetrics().At(0).Resource().Attributes().UpdateString("service.name", "cart")
+	other.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+
+	md, err = p.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	dps := firstMetric(md).IntSum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, int64(1), dps.At(0).Value())
+	assert.Equal(t, int64(20), dps.At(1).Value())
+}
+
+func TestCumulativeToDelta_Unchanged(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics = []string{"requests"}
+	p, _ := newTestProcessor(cfg)
+
+	// Delta sums, gauges and the metrics not in the list are not converted.
+	md := intSum("requests", pdata.AggregationTemporalityDelta, 0, 10, 10)
+	intSum("errors", pdata.AggregationTemporalityCumulative, 0, 10, 10).ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	gauge := newMetric(md, "requests", pdata.MetricDataTypeDoubleGauge)
+	gauge.DoubleGauge().DataPoints().AppendEmpty().SetValue(1)
+	expected := md.Clone()
+
+	md, err := p.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, expected, md)
+}
+
+func TestCumulativeToDelta_MaxStale(t *testing.T) {
+	p, now := newTestProcessor(createDefaultConfig().(*Config))
+
+	_, err := p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityCumulative, 0, 10, 10))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	*now = now.Add(metricstream.DefaultMaxStale)
+	// The stream expired, the data point is handled as the first one.
+	_, err = p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityCumulative, 0, 20, 15))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	assert.Equal(t, 1, p.StreamCount())
+
+	*now = now.Add(time.Minute)
+	_, err = p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityCumulative, 0, 30, 18))
+	require.NoError(t, err)
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dedupprocessor
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// cacheKey identifies a span or a log record.
+type cacheKey [32]byte
+
+type cacheEntry struct {
+	key    cacheKey
+	expiry time.Time
+}
+
+// cache remembers the keys seen during the window, up to maxEntries keys.
+type cache struct {
+	window     time.Duration
+	maxEntries int
+	// now is overridable by tests.
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	// order holds the entries in the order they were seen, the oldest first. Since they all have the same window,
+	// it is also the order of their expiry.
+	order *list.List
+}
+
+func newCache(window time.Duration, maxEntries int) *cache {
+	return &cache{
+		window:     window,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[cacheKey]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// contains returns whether the key was recorded during the window.
+func (c *cache) contains(key cacheKey) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeExpired(c.now())
+	_, ok := c.entries[key]
+	return ok
+}
+
+// add records the keys, the window of a key already recorded is not extended.
+func (c *cache) add(keys []cacheKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.removeExpired(now)
+	for _, key := range keys {
+		if _, ok := c.entries[key]; ok {
+			continue
+		}
+		if len(c.entries) >= c.maxEntries {
+			c.remove(c.order.Front())
+		}
+		c.entries[key] = c.order.PushBack(cacheEntry{key: key, expiry: now.Add(c.window)})
+	}
+}
+
+// removeExpired removes the entries whose window is over. Must be called with the lock held.
+func (c *cache) removeExpired(now time.Time) {
+	for front := c.order.Front(); front != nil && !now.Before(front.Value.(cacheEntry).expiry); front = c.order.Front() {
+		c.remove(front)
+	}
+}
+
+// remove removes the entry of the element. Must be called with the lock held.
+func (c *cache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(cacheEntry).key)
+}
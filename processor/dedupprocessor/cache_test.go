@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dedupprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// seen records the key, and returns whether it was already recorded.
+func seen(c *cache, key cacheKey) bool {
+	if c.contains(key) {
+		return true
+	}
+	c.add([]cacheKey{key})
+	return false
+}
+
+func newTestCache(window time.Duration, maxEntries int) (*cache, *time.Time) {
+	now := time.Unix(1000, 0)
+	c := newCache(window, maxEntries)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestCache_Window(t *testing.T) {
+	c, now := newTestCache(time.Minute, 10)
+
+	assert.False(t, seen(c, cacheKey{1}))
+	assert.True(t, seen(c, cacheKey{1}))
+	assert.False(t, seen(c, cacheKey{2}))
+
+	*now = now.Add(30 * time.Second)
+	assert.True(t, seen(c, cacheKey{1}))
+	assert.False(t, seen(c, cacheKey{3}))
+
+	// The window starts when the key is first seen.
+	*now = now.Add(30 * time.Second)
+	assert.False(t, seen(c, cacheKey{1}))
+	assert.True(t, seen(c, cacheKey{3}))
+	assert.Len(t, c.entries, 2)
+	assert.Equal(t, 2, c.order.Len())
+}
+
+func TestCache_MaxEntries(t *testing.T) {
+	c, _ := newTestCache(time.Minute, 2)
+
+	assert.False(t, seen(c, cacheKey{1}))
+	assert.False(t, seen(c, cacheKey{2}))
+	// The oldest key is forgotten.
+	assert.False(t, seen(c, cacheKey{3}))
+	assert.False(t, seen(c, cacheKey{1}))
+	assert.True(t, seen(c, cacheKey{3}))
+	assert.Len(t, c.entries, 2)
+}
+
+func TestCache_Add(t *testing.T) {
+	c, now := newTestCache(time.Minute, 10)
+
+	assert.False(t, c.contains(cacheKey{1}))
+	c.add([]cacheKey{{1}, {2}})
+	assert.True(t, c.contains(cacheKey{1}))
+	assert.True(t, c.contains(cacheKey{2}))
+
+	// Adding a key again does not extend its window.
+	*now = now.Add(30 * time.Second)
+	c.add([]cacheKey{{1}})
+	assert.Equal(t, 2, c.order.Len())
+	*now = now.Add(30 * time.Second)
+	assert.False(t, c.contains(cacheKey{1}))
+	assert.Len(t, c.entries, 0)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dedupprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the deduplication processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Window is the time during which the duplicates of a span or log record are dropped, after it is first seen.
+	Window time.Duration `mapstructure:"window"`
+
+	// MaxEntries is the maximum number of spans or log records remembered. When it is reached, the oldest ones are
+	// forgotten before their Window.
+	MaxEntries int `mapstructure:"max_entries"`
+
+	// LogFingerprint selects the fields of the log records identifying the duplicates.
+	LogFingerprint LogFingerprint `mapstructure:"log_fingerprint"`
+}
+
+// LogFingerprint selects the fields of the log records identifying the duplicates, in addition to their resource
+// attributes which are always used.
+type LogFingerprint struct {
+	// Timestamp includes the timestamp of the log records.
+	Timestamp bool `mapstructure:"timestamp"`
+
+	// Body includes the body of the log records.
+	Body bool `mapstructure:"body"`
+
+	// Attributes includes all the attributes of the log records.
+	Attributes bool `mapstructure:"attributes"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Window <= 0 {
+		return errors.New("window must be positive")
+	}
+	if cfg.MaxEntries <= 0 {
+		return errors.New("max_entries must be positive")
+	}
+	if !cfg.LogFingerprint.Timestamp && !cfg.LogFingerprint.Body && !cfg.LogFingerprint.Attributes {
+		return errors.New("log_fingerprint must include at least one of timestamp, body or attributes")
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dedupprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, createDefaultConfig(), cfg.Processors[config.NewID(typeStr)])
+
+	p1 := cfg.Processors[config.NewIDWithName(typeStr, "logs")]
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "logs")),
+			Window:            5 * time.Minute,
+			MaxEntries:        500000,
+			LogFingerprint: LogFingerprint{
+				Timestamp: true,
+				Body:      true,
+			},
+		}, p1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "window",
+			modify: func(cfg *Config) { cfg.Window = 0 },
+			err:    "window must be positive",
+		},
+		{
+			name:   "max entries",
+			modify: func(cfg *Config) { cfg.MaxEntries = 0 },
+			err:    "max_entries must be positive",
+		},
+		{
+			name:   "empty log fingerprint",
+			modify: func(cfg *Config) { cfg.LogFingerprint = LogFingerprint{} },
+			err:    "log_fingerprint must include at least one of timestamp, body or attributes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dedupprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "dedup"
+
+	defaultWindow     = time.Minute
+	defaultMaxEntries = 100000
+)
+
+var processorCapabilities = consumer.Capabilities{MutatesData: true}
+
+// NewFactory returns a new factory for the deduplication processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTracesProcessor),
+		processorhelper.WithLogs(createLogsProcessor))
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+		Window:            defaultWindow,
+		MaxEntries:        defaultMaxEntries,
+		LogFingerprint: LogFingerprint{
+			Timestamp:  true,
+			Body:       true,
+			Attributes: true,
+		},
+	}
+}
+
+func createTracesProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateParams,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	// The next consumer is wrapped, so processorhelper cannot check it.
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	dp, err := newDedupProcessor(cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		&tracesCommitter{Traces: nextConsumer, cache: dp.cache},
+		dp,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateParams,
+	cfg config.Processor,
+	nextConsumer consumer.Logs,
+) (component.LogsProcessor, error) {
+	// The next consumer is wrapped, so processorhelper cannot check it.
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	dp, err := newDedupProcessor(cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewLogsProcessor(
+		cfg,
+		&logsCommitter{Logs: nextConsumer, dp: dp},
+		dp,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dedupprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+var (
+	processorTagKey = tag.MustNewKey(obsreport.ProcessorKey)
+
+	statDuplicateSpans      = stats.Int64("duplicate_spans", "Number of duplicate spans dropped", stats.UnitDimensionless)
+	statDuplicateLogRecords = stats.Int64("duplicate_log_records", "Number of duplicate log records dropped", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views related to deduplication
+func MetricViews() []*view.View {
+	processorTagKeys := []tag.Key{processorTagKey}
+
+	countDuplicateSpansView := &view.View{
+		Name:        statDuplicateSpans.Name(),
+		Measure:     statDuplicateSpans,
+		Description: statDuplicateSpans.Description(),
+		TagKeys:     processorTagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	countDuplicateLogRecordsView := &view.View{
+		Name:        statDuplicateLogRecords.Name(),
+		Measure:     statDuplicateLogRecords,
+		Description: statDuplicateLogRecords.Description(),
+		TagKeys:     processorTagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	legacyViews := []*view.View{
+		countDuplicateSpansView,
+		countDuplicateLogRecordsView,
+	}
+
+	return obsreport.ProcessorMetricViews(typeStr, legacyViews)
+}
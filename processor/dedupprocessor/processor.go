@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dedupprocessor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"sort"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+type dedupProcessor struct {
+	cache          *cache
+	logFingerprint LogFingerprint
+	// metricsCtx holds the processor tag of the custom metrics.
+	metricsCtx context.Context
+}
+
+func newDedupProcessor(cfg *Config) (*dedupProcessor, error) {
+	metricsCtx, err := tag.New(context.Background(), tag.Insert(processorTagKey, cfg.ID().String()))
+	if err != nil {
+		return nil, err
+	}
+	return &dedupProcessor{
+		cache:          newCache(cfg.Window, cfg.MaxEntries),
+		logFingerprint: cfg.LogFingerprint,
+		metricsCtx:     metricsCtx,
+	}, nil
+}
+
+// ProcessTraces drops the spans whose trace ID and span ID were already seen during the window, or earlier in td.
+func (dp *dedupProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	inBatch := make(map[cacheKey]bool)
+	duplicates := 0
+	td.ResourceSpans().RemoveIf(func(rs pdata.ResourceSpans) bool {
+		rs.InstrumentationLibrarySpans().RemoveIf(func(ils pdata.InstrumentationLibrarySpans) bool {
+			ils.Spans().RemoveIf(func(s pdata.Span) bool {
+				key := spanKey(s)
+				if inBatch[key] || dp.cache.contains(key) {
+					duplicates++
+					return true
+				}
+				inBatch[key] = true
+				return false
+			})
+			// Filter out empty InstrumentationLibrarySpans
+			return ils.Spans().Len() == 0
+		})
+		// Filter out empty ResourceSpans
+		return rs.InstrumentationLibrarySpans().Len() == 0
+	})
+	if duplicates > 0 {
+		stats.Record(dp.metricsCtx, statDuplicateSpans.M(int64(duplicates)))
+	}
+	if td.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+// ProcessLogs drops the log records whose fingerprint was already seen during the window, or earlier in ld.
+func (dp *dedupProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	inBatch := make(map[cacheKey]bool)
+	duplicates := 0
+	ld.ResourceLogs().RemoveIf(func(rl pdata.ResourceLogs) bool {
+		resourceDigest := attributesDigest(rl.Resource().Attributes())
+		rl.InstrumentationLibraryLogs().RemoveIf(func(ill pdata.InstrumentationLibraryLogs) bool {
+			ill.Logs().RemoveIf(func(lr pdata.LogRecord) bool {
+				key := dp.logKey(resourceDigest, lr)
+				if inBatch[key] || dp.cache.contains(key) {
+					duplicates++
+					return true
+				}
+				inBatch[key] = true
+				return false
+			})
+			// Filter out empty InstrumentationLibraryLogs
+			return ill.Logs().Len() == 0
+		})
+		// Filter out empty ResourceLogs
+		return rl.InstrumentationLibraryLogs().Len() == 0
+	})
+	if duplicates > 0 {
+		stats.Record(dp.metricsCtx, statDuplicateLogRecords.M(int64(duplicates)))
+	}
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// tracesCommitter remembers the spans once the next consumer accepted them, so that the spans refused and retried
+// by the sender are not dropped as duplicates.
+type tracesCommitter struct {
+	consumer.Traces
+	cache *cache
+}
+
+func (tc *tracesCommitter) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	// The keys are computed before, the next consumer may modify td.
+	var keys []cacheKey
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				keys = append(keys, spanKey(spans.At(k)))
+			}
+		}
+	}
+	if err := tc.Traces.ConsumeTraces(ctx, td); err != nil {
+		return err
+	}
+	tc.cache.add(keys)
+	return nil
+}
+
+// logsCommitter remembers the log records once the next consumer accepted them, so that the log records refused
+// and retried by the sender are not dropped as duplicates.
+type logsCommitter struct {
+	consumer.Logs
+	dp *dedupProcessor
+}
+
+func (lc *logsCommitter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	// The keys are computed before, the next consumer may modify ld.
+	var keys []cacheKey
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		resourceDigest := attributesDigest(rls.At(i).Resource().Attributes())
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				keys = append(keys, lc.dp.logKey(resourceDigest, logs.At(k)))
+			}
+		}
+	}
+	if err := lc.Logs.ConsumeLogs(ctx, ld); err != nil {
+		return err
+	}
+	lc.dp.cache.add(keys)
+	return nil
+}
+
+func spanKey(s pdata.Span) cacheKey {
+	var key cacheKey
+	traceID := s.TraceID().Bytes()
+	spanID := s.SpanID().Bytes()
+	copy(key[:], traceID[:])
+	copy(key[len(traceID):], spanID[:])
+	return key
+}
+
+// logKey returns the fingerprint of the log record, computed from the digest of its resource attributes and the
+// fields selected by LogFingerprint.
+func (dp *dedupProcessor) logKey(resourceDigest []byte, lr pdata.LogRecord) cacheKey {
+	h := sha256.New()
+	h.Write(resourceDigest)
+	if dp.logFingerprint.Timestamp {
+		var ts [8]byte
+		binary.LittleEndian.PutUint64(ts[:], uint64(lr.Timestamp()))
+		h.Write(ts[:])
+	}
+	if dp.logFingerprint.Body {
+		writeString(h, tracetranslator.AttributeValueToString(lr.Body()))
+	}
+	if dp.logFingerprint.Attributes {
+		writeAttributes(h, lr.Attributes())
+	}
+	var key cacheKey
+	h.Sum(key[:0])
+	return key
+}
+
+// attributesDigest returns the digest of the attributes, computed once by resource.
+func attributesDigest(attrs pdata.AttributeMap) []byte {
+	h := sha256.New()
+	writeAttributes(h, attrs)
+	return h.Sum(nil)
+}
+
+// writeAttributes writes the attributes sorted by key, so that the order of the attributes does not matter.
+func writeAttributes(h hash.Hash, attrs pdata.AttributeMap) {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pdata.AttributeValue) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		writeString(h, k)
+		writeString(h, tracetranslator.AttributeValueToString(v))
+	}
+}
+
+// writeString writes the string prefixed by its length, so that consecutive strings are not ambiguous.
+func writeString(h hash.Hash, s string) {
+	var length [8]byte
+	binary.LittleEndian.PutUint64(length[:], uint64(len(s)))
+	h.Write(length[:])
+	h.Write([]byte(s))
+}
@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dedupprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var testParams = component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+func testTraceID(b byte) pdata.TraceID {
+	return pdata.NewTraceID([16]byte{b})
+}
+
+func testSpanID(b byte) pdata.SpanID {
+	return pdata.NewSpanID([8]byte{b})
+}
+
+// genTraces generates one span per ID, all of them having the trace ID 1.
+func genTraces(spanIDs ...byte) pdata.Traces {
+	td := pdata.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for _, id := range spanIDs {
+		s := spans.AppendEmpty()
+		s.SetTraceID(testTraceID(1))
+		s.SetSpanID(testSpanID(id))
+	}
+	return td
+}
+
+type testLog struct {
+	host      string
+	timestamp int64
+	body      string
+	attrs     map[string]string
+}
+
+func genLogs(logs ...testLog) pdata.Logs {
+	ld := pdata.NewLogs()
+	for _, l := range logs {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertString("host.name", l.host)
+		lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+		lr.SetTimestamp(pdata.TimestampFromTime(time.Unix(l.timestamp, 0)))
+		lr.Body().SetStringVal(l.body)
+		for k, v := range l.attrs {
+			lr.Attributes().InsertString(k, v)
+		}
+	}
+	return ld
+}
+
+func TestDedup_Traces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	tp, err := createTracesProcessor(context.Background(), testParams, createDefaultConfig(), sink)
+	require.NoError(t, err)
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), genTraces(1, 2, 2)))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), genTraces(2, 3)))
+	// Nothing is sent to the next consumer if all the spans are duplicates.
+	require.NoError(t, tp.ConsumeTraces(context.Background(), genTraces(1, 3)))
+
+	require.Len(t, sink.AllTraces(), 2)
+	assert.Equal(t, 2, sink.AllTraces()[0].SpanCount())
+	spans := sink.AllTraces()[1].ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	require.Equal(t, 1, spans.Len())
+	assert.Equal(t, testSpanID(3), spans.At(0).SpanID())
+
+	// Same span ID, different trace.
+	td := genTraces(1)
+	td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).SetTraceID(testTraceID(2))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 4, sink.SpansCount())
+}
+
+func TestDedup_Logs(t *testing.T) {
+	tests := []struct {
+		name        string
+		fingerprint LogFingerprint
+		logs        []testLog
+		want        int
+	}{
+		{
+			name:        "exact duplicates",
+			fingerprint: LogFingerprint{Timestamp: true, Body: true, Attributes: true},
+			logs: []testLog{
+				{host: "a", timestamp: 1, body: "started", attrs: map[string]string{"k1": "v1", "k2": "v2"}},
+				{host: "a", timestamp: 1, body: "started", attrs: map[string]string{"k2": "v2", "k1": "v1"}},
+			},
+			want: 1,
+		},
+		{
+			name:        "different resources",
+			fingerprint: LogFingerprint{Timestamp: true, Body: true, Attributes: true},
+			logs: []testLog{
+				{host: "a", timestamp: 1, body: "started"},
+				{host: "b", timestamp: 1, body: "started"},
+			},
+			want: 2,
+		},
+		{
+			name:        "different timestamps",
+			fingerprint: LogFingerprint{Timestamp: true, Body: true, Attributes: true},
+			logs: []testLog{
+				{host: "a", timestamp: 1, body: "started"},
+				{host: "a", timestamp: 2, body: "started"},
+			},
+			want: 2,
+		},
+		{
+			name:        "different attributes",
+			fingerprint: LogFingerprint{Timestamp: true, Body: true, Attributes: true},
+			logs: []testLog{
+				{host: "a", timestamp: 1, body: "started", attrs: map[string]string{"k1": "v1"}},
+				{host: "a", timestamp: 1, body: "started", attrs: map[string]string{"k1": "v2"}},
+			},
+			want: 2,
+		},
+		{
+			name:        "attributes not in fingerprint",
+			fingerprint: LogFingerprint{Timestamp: true, Body: true},
+			logs: []testLog{
+				{host: "a", timestamp: 1, body: "started", attrs: map[string]string{"k1": "v1"}},
+				{host: "a", timestamp: 1, body: "started", attrs: map[string]string{"k1": "v2"}},
+			},
+			want: 1,
+		},
+		{
+			name:        "body only",
+			fingerprint: LogFingerprint{Body: true},
+			logs: []testLog{
+				{host: "a", timestamp: 1, body: "started"},
+				{host: "a", timestamp: 2, body: "started"},
+				{host: "a", timestamp: 2, body: "stopped"},
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.LogFingerprint = tt.fingerprint
+			sink := new(consumertest.LogsSink)
+			lp, err := createLogsProcessor(context.Background(), testParams, cfg, sink)
+			require.NoError(t, err)
+
+			require.NoError(t, lp.ConsumeLogs(context.Background(), genLogs(tt.logs...)))
+			assert.Equal(t, tt.want, sink.LogRecordsCount())
+		})
+	}
+}
+
+// failingTraces is a sink returning err while it is set.
+type failingTraces struct {
+	consumertest.TracesSink
+	err error
+}
+
+func (ft *failingTraces) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if ft.err != nil {
+		return ft.err
+	}
+	return ft.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func TestDedup_NextConsumerError(t *testing.T) {
+	next := &failingTraces{err: errors.New("failed")}
+	tp, err := createTracesProcessor(context.Background(), testParams, createDefaultConfig(), next)
+	require.NoError(t, err)
+
+	// The spans refused by the next consumer are not recorded, they are sent again when retried.
+	assert.EqualError(t, tp.ConsumeTraces(context.Background(), genTraces(1, 2, 2)), "failed")
+	next.err = nil
+	require.NoError(t, tp.ConsumeTraces(context.Background(), genTraces(1, 2, 2)))
+	assert.Equal(t, 2, next.SpansCount())
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), genTraces(1, 2)))
+	assert.Equal(t, 2, next.SpansCount())
+}
+
+func TestDedup_Metrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	tp, err := createTracesProcessor(context.Background(), testParams, createDefaultConfig(), consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, tp.ConsumeTraces(context.Background(), genTraces(1, 1, 2, 1)))
+
+	lp, err := createLogsProcessor(context.Background(), testParams, createDefaultConfig(), consumertest.NewNop())
+	require.NoError(t, err)
+	log := testLog{host: "a", timestamp: 1, body: "started"}
+	require.NoError(t, lp.ConsumeLogs(context.Background(), genLogs(log, log, log, log)))
+
+	for name, want := range map[string]float64{
+		statDuplicateSpans.Name():      2,
+		statDuplicateLogRecords.Name(): 3,
+	} {
+		viewData, err := view.RetrieveData("processor/dedup/" + name)
+		require.NoError(t, err)
+		require.Len(t, viewData, 1, name)
+		assert.Equal(t, want, viewData[0].Data.(*view.SumData).Value, name)
+	}
+}
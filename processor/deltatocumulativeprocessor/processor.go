@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/metricstream"
+)
+
+// newDeltaToCumulativeProcessor returns a processor converting the delta sums and histograms into cumulative ones,
+// by adding each data point to the sum of the previous data points of the stream.
+func newDeltaToCumulativeProcessor(logger *zap.Logger, cfg *Config) *metricstream.Processor {
+	return metricstream.NewProcessor(logger, cfg.Settings, convertMetric)
+}
+
+// convertMetric converts the data points of a delta metric, and returns false if none is left.
+func convertMetric(streams *metricstream.Store, resource pdata.Resource, library pdata.InstrumentationLibrary, metric pdata.Metric, now time.Time) bool {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		sum := metric.IntSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityDelta {
+			return true
+		}
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		sum.DataPoints().RemoveIf(func(dp pdata.IntDataPoint) bool {
+			key := metricstream.Identity(resource, library, metric, dp.LabelsMap())
+			return !convertIntDataPoint(streams, key, dp, sum.IsMonotonic(), now)
+		})
+		return sum.DataPoints().Len() > 0
+	case pdata.MetricDataTypeDoubleSum:
+		sum := metric.DoubleSum()
+		if sum.AggregationTemporality() != pdata.AggregationTemporalityDelta {
+			return true
+		}
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		sum.DataPoints().RemoveIf(func(dp pdata.DoubleDataPoint) bool {
+			key := metricstream.Identity(resource, library, metric, dp.LabelsMap())
+			return !convertDoubleDataPoint(streams, key, dp, sum.IsMonotonic(), now)
+		})
+		return sum.DataPoints().Len() > 0
+	case pdata.MetricDataTypeHistogram:
+		histogram := metric.Histogram()
+		if histogram.AggregationTemporality() != pdata.AggregationTemporalityDelta {
+			return true
+		}
+		histogram.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		histogram.DataPoints().RemoveIf(func(dp pdata.HistogramDataPoint) bool {
+			key := metricstream.Identity(resource, library, metric, dp.LabelsMap())
+			return !convertHistogramDataPoint(streams, key, dp, now)
+		})
+		return histogram.DataPoints().Len() > 0
+	}
+	return true
+}
+
+// convertIntDataPoint adds the data point to the cumulative value, and returns false if it must be dropped.
+func convertIntDataPoint(streams *metricstream.Store, key string, dp pdata.IntDataPoint, monotonic bool, now time.Time) bool {
+	if monotonic && dp.Value() < 0 {
+		// Invalid, a monotonic sum cannot decrease.
+		return false
+	}
+	state, ok := streams.Get(key)
+	if !ok {
+		start := streamStart(dp.StartTimestamp(), dp.Timestamp())
+		streams.Put(key, &metricstream.IntState{Start: start, Timestamp: dp.Timestamp(), Value: dp.Value()}, now)
+		dp.SetStartTimestamp(start)
+		return true
+	}
+	cur := state.(*metricstream.IntState)
+	if isOverlapping(cur.Timestamp, dp.StartTimestamp(), dp.Timestamp()) {
+		return false
+	}
+	cur.Timestamp = dp.Timestamp()
+	cur.Value += dp.Value()
+	streams.Put(key, cur, now)
+
+	dp.SetStartTimestamp(cur.Start)
+	dp.SetValue(cur.Value)
+	return true
+}
+
+// convertDoubleDataPoint adds the data point to the cumulative value, and returns false if it must be dropped.
+func convertDoubleDataPoint(streams *metricstream.Store, key string, dp pdata.DoubleDataPoint, monotonic bool, now time.Time) bool {
+	if monotonic && dp.Value() < 0 {
+		// Invalid, a monotonic sum cannot decrease.
+		return false
+	}
+	state, ok := streams.Get(key)
+	if !ok {
+		start := streamStart(dp.StartTimestamp(), dp.Timestamp())
+		streams.Put(key, &metricstream.DoubleState{Start: start, Timestamp: dp.Timestamp(), Value: dp.Value()}, now)
+		dp.SetStartTimestamp(start)
+		return true
+	}
+	cur := state.(*metricstream.DoubleState)
+	if isOverlapping(cur.Timestamp, dp.StartTimestamp(), dp.Timestamp()) {
+		return false
+	}
+	cur.Timestamp = dp.Timestamp()
+	cur.Value += dp.Value()
+	streams.Put(key, cur, now)
+
+	dp.SetStartTimestamp(cur.Start)
+	dp.SetValue(cur.Value)
+	return true
+}
+
+// convertHistogramDataPoint adds the data point to the cumulative histogram, and returns false if it must be
+// dropped.
+func convertHistogramDataPoint(streams *metricstream.Store, key string, dp pdata.HistogramDataPoint, now time.Time) bool {
+	state, ok := streams.Get(key)
+	if ok && !state.(*metricstream.HistogramState).SameBuckets(dp) {
+		// The buckets cannot be added, the data point starts a new stream.
+		ok = false
+	}
+	if !ok {
+		start := streamStart(dp.StartTimestamp(), dp.Timestamp())
+		streams.Put(key, metricstream.NewHistogramState(start, dp), now)
+		dp.SetStartTimestamp(start)
+		return true
+	}
+	cur := state.(*metricstream.HistogramState)
+	if isOverlapping(cur.Timestamp, dp.StartTimestamp(), dp.Timestamp()) {
+		return false
+	}
+	cur.Timestamp = dp.Timestamp()
+	cur.Count += dp.Count()
+	cur.Sum += dp.Sum()
+	for i, c := range dp.BucketCounts() {
+		cur.BucketCounts[i] += c
+	}
+	streams.Put(key, cur, now)
+
+	dp.SetStartTimestamp(cur.Start)
+	dp.SetCount(cur.Count)
+	dp.SetSum(cur.Sum)
+	dp.SetBucketCounts(append([]uint64(nil), cur.BucketCounts...))
+	return true
+}
+
+// streamStart returns the start timestamp of a stream, the start timestamp of its first data point if it is set.
+func streamStart(start, timestamp pdata.Timestamp) pdata.Timestamp {
+	if start != 0 {
+		return start
+	}
+	return timestamp
+}
+
+// isOverlapping returns whether the data point covers a time already added to the cumulative value, because it is
+// duplicated or out of order.
+func isOverlapping(lastTimestamp, start, timestamp pdata.Timestamp) bool {
+	return timestamp <= lastTimestamp || (start != 0 && start < lastTimestamp)
+}
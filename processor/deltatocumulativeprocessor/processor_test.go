@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/metricstream"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+var startTime = time.Unix(1600000000, 0)
+
+func timestamp(seconds int) pdata.Timestamp {
+	return pdata.TimestampFromTime(startTime.Add(time.Duration(seconds) * time.Second))
+}
+
+func newTestProcessor(cfg *Config) (*metricstream.Processor, *time.Time) {
+	p := newDeltaToCumulativeProcessor(zap.NewNop(), cfg)
+	now := startTime
+	p.Now = func() time.Time { return now }
+	return p, &now
+}
+
+func newMetric(md pdata.Metrics, name string, dataType pdata.MetricDataType) pdata.Metric {
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "checkout")
+	metric := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName(name)
+	metric.SetDataType(dataType)
+	return metric
+}
+
+func intSum(name string, temporality pdata.AggregationTemporality, start, ts int, value int64) pdata.Metrics {
+	md := pdata.NewMetrics()
+	sum := newMetric(md, name, pdata.MetricDataTypeIntSum).IntSum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(temporality)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.LabelsMap().Insert("method", "GET")
+	dp.SetStartTimestamp(timestamp(start))
+	dp.SetTimestamp(timestamp(ts))
+	dp.SetValue(value)
+	return md
+}
+
+func histogram(start, ts int, count uint64, sum float64, bucketCounts []uint64, bounds []float64) pdata.Metrics {
+	md := pdata.NewMetrics()
+	h := newMetric(md, "latency", pdata.MetricDataTypeHistogram).Histogram()
+	h.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+	dp := h.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(timestamp(start))
+	dp.SetTimestamp(timestamp(ts))
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetBucketCounts(bucketCounts)
+	dp.SetExplicitBounds(bounds)
+	return md
+}
+
+func firstMetric(md pdata.Metrics) pdata.Metric {
+	return md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+}
+
+func TestDeltaToCumulative_IntSum(t *testing.T) {
+	p, _ := newTestProcessor(createDefaultConfig().(*Config))
+
+	md, err := p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityDelta, 0, 10, 10))
+	require.NoError(t, err)
+	sum := firstMetric(md).IntSum()
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, sum.AggregationTemporality())
+	dp := sum.DataPoints().At(0)
+	assert.Equal(t, int64(10), dp.Value())
+	assert.Equal(t, timestamp(0), dp.StartTimestamp())
+
+	md, err = p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityDelta, 10, 20, 5))
+	require.NoError(t, err)
+	dp = firstMetric(md).IntSum().DataPoints().At(0)
+	assert.Equal(t, int64(15), dp.Value())
+	assert.Equal(t, timestamp(0), dp.StartTimestamp())
+	assert.Equal(t, timestamp(20), dp.Timestamp())
+
+	// Duplicated or out of order data points are dropped.
+	_, err = p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityDelta, 10, 20, 5))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	_, err = p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityDelta, 15, 25, 5))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	// A negative value of a monotonic sum is invalid.
+	_, err = p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityDelta, 20, 30, -1))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	// A gap between data points is not an overlap.
+	md, err = p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityDelta, 40, 50, 1))
+	require.NoError(t, err)
+	assert.Equal(t, int64(16), firstMetric(md).IntSum().DataPoints().At(0).Value())
+}
+
+func TestDeltaToCumulative_DoubleSum(t *testing.T) {
+	p, _ := newTestProcessor(createDefaultConfig().(*Config))
+
+	newDoubleSum := func(ts int, value float64) pdata.Metrics {
+		md := pdata.NewMetrics()
+		sum := newMetric(md, "queue_size", pdata.MetricDataTypeDoubleSum).DoubleSum()
+		sum.SetIsMonotonic(false)
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetTimestamp(timestamp(ts))
+		dp.SetValue(value)
+		return md
+	}
+
+	md, err := p.ProcessMetrics(context.Background(), newDoubleSum(10, 2.5))
+	require.NoError(t, err)
+	// Without start timestamp, the stream starts at the first data point.
+	assert.Equal(t, timestamp(10), firstMetric(md).DoubleSum().DataPoints().At(0).StartTimestamp())
+
+	// A non monotonic sum can decrease.
+	md, err = p.ProcessMetrics(context.Background(), newDoubleSum(20, -1))
+	require.NoError(t, err)
+	dp := firstMetric(md).DoubleSum().DataPoints().At(0)
+	assert.Equal(t, 1.5, dp.Value())
+	assert.Equal(t, timestamp(10), dp.StartTimestamp())
+}
+
+func TestDeltaToCumulative_Histogram(t *testing.T) {
+	p, _ := newTestProcessor(createDefaultConfig().(*Config))
+
+	_, err := p.ProcessMetrics(context.Background(), histogram(0, 10, 3, 30, []uint64{1, 2, 0}, []float64{10, 100}))
+	require.NoError(t, err)
+
+	md, err := p.ProcessMetrics(context.Background(), histogram(10, 20, 3, 270, []uint64{1, 1, 1}, []float64{10, 100}))
+	require.NoError(t, err)
+	h := firstMetric(md).Histogram()
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, h.AggregationTemporality())
+	dp := h.DataPoints().At(0)
+	assert.Equal(t, timestamp(0), dp.StartTimestamp())
+	assert.Equal(t, uint64(6), dp.Count())
+	assert.Equal(t, float64(300), dp.Sum())
+	assert.Equal(t, []uint64{2, 3, 1}, dp.BucketCounts())
+
+	// New bounds start a new stream.
+	md, err = p.ProcessMetrics(context.Background(), histogram(20, 30, 2, 10, []uint64{2, 0}, []float64{10}))
+	require.NoError(t, err)
+	dp = firstMetric(md).Histogram().DataPoints().At(0)
+	assert.Equal(t, timestamp(20), dp.StartTimestamp())
+	assert.Equal(t, uint64(2), dp.Count())
+	assert.Equal(t, []uint64{2, 0}, dp.BucketCounts())
+}
+
+func TestDeltaToCumulative_Unchanged(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics = []string{"requests"}
+	p, _ := newTestProcessor(cfg)
+
+	// Cumulative sums, gauges and the metrics not in the list are not converted.
+	md := intSum("requests", pdata.AggregationTemporalityCumulative, 0, 10, 10)
+	intSum("errors", pdata.AggregationTemporalityDelta, 0, 10, 10).ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	gauge := newMetric(md, "requests", pdata.MetricDataTypeDoubleGauge)
+	gauge.DoubleGauge().DataPoints().AppendEmpty().SetValue(1)
+	expected := md.Clone()
+
+	md, err := p.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, expected, md)
+}
+
+func TestDeltaToCumulative_MaxStale(t *testing.T) {
+	p, now := newTestProcessor(createDefaultConfig().(*Config))
+
+	_, err := p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityDelta, 0, 10, 10))
+	require.NoError(t, err)
+
+	*now = now.Add(metricstream.DefaultMaxStale)
+	// The stream expired, a new one starts.
+	md, err := p.ProcessMetrics(context.Background(), intSum("requests", pdata.AggregationTemporalityDelta, 300, 310, 5))
+	require.NoError(t, err)
+	dp := firstMetric(md).IntSum().DataPoints().At(0)
+	assert.Equal(t, int64(5), dp.Value())
+	assert.Equal(t, timestamp(300), dp.StartTimestamp())
+	assert.Equal(t, 1, p.StreamCount())
+}
@@ -15,7 +15,10 @@
 package filterprocessor
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
 	"go.opentelemetry.io/collector/internal/processor/filtermetric"
 )
 
@@ -24,6 +27,10 @@ type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
 	Metrics MetricFilters `mapstructure:"metrics"`
+
+	Spans SpanFilters `mapstructure:"spans"`
+
+	Logs LogFilters `mapstructure:"logs"`
 }
 
 // MetricFilters filters by Metric properties.
@@ -39,9 +46,55 @@ type MetricFilters struct {
 	Exclude *filtermetric.MatchProperties `mapstructure:"exclude"`
 }
 
+// SpanFilters filters by Span properties.
+type SpanFilters struct {
+	// Include match properties describe spans that should be included in the Collector Service pipeline,
+	// all other spans should be dropped from further processing.
+	// If both Include and Exclude are specified, Include filtering occurs first.
+	Include *filterconfig.MatchProperties `mapstructure:"include"`
+
+	// Exclude match properties describe spans that should be excluded from the Collector Service pipeline,
+	// all other spans should be included.
+	// If both Include and Exclude are specified, Include filtering occurs first.
+	Exclude *filterconfig.MatchProperties `mapstructure:"exclude"`
+}
+
+// LogFilters filters by LogRecord properties.
+type LogFilters struct {
+	// Include match properties describe log records that should be included in the Collector Service pipeline,
+	// all other log records should be dropped from further processing.
+	// If both Include and Exclude are specified, Include filtering occurs first.
+	Include *filterconfig.MatchProperties `mapstructure:"include"`
+
+	// Exclude match properties describe log records that should be excluded from the Collector Service pipeline,
+	// all other log records should be included.
+	// If both Include and Exclude are specified, Include filtering occurs first.
+	Exclude *filterconfig.MatchProperties `mapstructure:"exclude"`
+}
+
 var _ config.Processor = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.Spans.Include != nil {
+		if err := cfg.Spans.Include.ValidateForSpans(); err != nil {
+			return fmt.Errorf("spans include: %w", err)
+		}
+	}
+	if cfg.Spans.Exclude != nil {
+		if err := cfg.Spans.Exclude.ValidateForSpans(); err != nil {
+			return fmt.Errorf("spans exclude: %w", err)
+		}
+	}
+	if cfg.Logs.Include != nil {
+		if err := cfg.Logs.Include.ValidateForLogs(); err != nil {
+			return fmt.Errorf("logs include: %w", err)
+		}
+	}
+	if cfg.Logs.Exclude != nil {
+		if err := cfg.Logs.Exclude.ValidateForLogs(); err != nil {
+			return fmt.Errorf("logs exclude: %w", err)
+		}
+	}
 	return nil
 }
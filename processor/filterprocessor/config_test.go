@@ -24,7 +24,9 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
 	"go.opentelemetry.io/collector/internal/processor/filtermetric"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
 	fsregexp "go.opentelemetry.io/collector/internal/processor/filterset/regexp"
 )
 
@@ -265,3 +267,109 @@ func TestLoadingConfigExpr(t *testing.T) {
 		})
 	}
 }
+
+// TestLoadingConfigSpans tests loading testdata/config_spans.yaml
+func TestLoadingConfigSpans(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config_spans.yaml"), factories)
+
+	assert.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "spans")),
+		Spans: SpanFilters{
+			Exclude: &filterconfig.MatchProperties{
+				Config:     filterset.Config{MatchType: filterset.Regexp},
+				SpanNames:  []string{"^GET /health.*"},
+				Attributes: []filterconfig.Attribute{{Key: "http.user_agent", Value: "kube-probe/.*"}},
+			},
+		},
+	}, cfg.Processors[config.NewIDWithName(typeStr, "spans")])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "spans_include")),
+		Spans: SpanFilters{
+			Include: &filterconfig.MatchProperties{
+				Config:   filterset.Config{MatchType: filterset.Strict},
+				Services: []string{"checkout", "payment"},
+			},
+		},
+	}, cfg.Processors[config.NewIDWithName(typeStr, "spans_include")])
+}
+
+// TestLoadingConfigLogs tests loading testdata/config_logs.yaml
+func TestLoadingConfigLogs(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config_logs.yaml"), factories)
+
+	assert.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "logs")),
+		Logs: LogFilters{
+			Exclude: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Regexp},
+				LogBodies: []string{"^GET /health"},
+			},
+		},
+	}, cfg.Processors[config.NewIDWithName(typeStr, "logs")])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "logs_severity")),
+		Logs: LogFilters{
+			Include: &filterconfig.MatchProperties{
+				Config:            filterset.Config{MatchType: filterset.Strict},
+				LogSeverityNumber: &filterconfig.LogSeverityNumberMatchProperties{Min: 9, MatchUndefined: true},
+			},
+			Exclude: &filterconfig.MatchProperties{
+				Config:           filterset.Config{MatchType: filterset.Regexp},
+				LogSeverityTexts: []string{"(?i)debug"},
+			},
+		},
+	}, cfg.Processors[config.NewIDWithName(typeStr, "logs_severity")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  string
+	}{
+		{
+			name: "spans include",
+			cfg:  &Config{Spans: SpanFilters{Include: &filterconfig.MatchProperties{}}},
+			err:  `spans include: at least one of "services", "span_names", "attributes", "libraries" or "resources" field must be specified`,
+		},
+		{
+			name: "spans exclude",
+			cfg:  &Config{Spans: SpanFilters{Exclude: &filterconfig.MatchProperties{LogBodies: []string{"body"}}}},
+			err:  "spans exclude: neither log_severity_texts, log_severity_number nor log_bodies should be specified for trace spans",
+		},
+		{
+			name: "logs include",
+			cfg:  &Config{Logs: LogFilters{Include: &filterconfig.MatchProperties{SpanNames: []string{"span"}}}},
+			err:  "logs include: neither services nor span_names should be specified for log records",
+		},
+		{
+			name: "logs exclude",
+			cfg:  &Config{Logs: LogFilters{Exclude: &filterconfig.MatchProperties{LogSeverityNumber: &filterconfig.LogSeverityNumberMatchProperties{}}}},
+			err:  "logs exclude: log_severity_number min must be between 1 (TRACE) and 24 (FATAL4)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.cfg.Validate(), tt.err)
+		})
+	}
+}
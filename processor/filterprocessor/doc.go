@@ -13,5 +13,5 @@
 // limitations under the License.
 
 // Package filterprocessor implements a processor for filtering
-// (dropping) metrics and/or spans by various properties.
+// (dropping) metrics, spans and/or logs by various properties.
 package filterprocessor
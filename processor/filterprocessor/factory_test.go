@@ -58,6 +58,12 @@ func TestCreateProcessors(t *testing.T) {
 		}, {
 			configName: "config_strict.yaml",
 			succeed:    true,
+		}, {
+			configName: "config_spans.yaml",
+			succeed:    true,
+		}, {
+			configName: "config_logs.yaml",
+			succeed:    true,
 		}, {
 			configName: "config_invalid.yaml",
 			succeed:    false,
@@ -78,9 +84,12 @@ func TestCreateProcessors(t *testing.T) {
 				factory := NewFactory()
 
 				tp, tErr := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewNop())
-				// Not implemented error
-				assert.NotNil(t, tErr)
-				assert.Nil(t, tp)
+				assert.NoError(t, tErr)
+				assert.NotNil(t, tp)
+
+				lp, lErr := factory.CreateLogsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewNop())
+				assert.NoError(t, lErr)
+				assert.NotNil(t, lp)
 
 				mp, mErr := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewNop())
 				assert.Equal(t, test.succeed, mp != nil)
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterlog"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type filterLogProcessor struct {
+	include filterlog.Matcher
+	exclude filterlog.Matcher
+	logger  *zap.Logger
+}
+
+func newFilterLogProcessor(logger *zap.Logger, cfg *Config) (*filterLogProcessor, error) {
+	inc, err := filterlog.NewMatcher(cfg.Logs.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	exc, err := filterlog.NewMatcher(cfg.Logs.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(
+		"Log filter configured",
+		zap.Any("include", cfg.Logs.Include),
+		zap.Any("exclude", cfg.Logs.Exclude),
+	)
+
+	return &filterLogProcessor{
+		include: inc,
+		exclude: exc,
+		logger:  logger,
+	}, nil
+}
+
+// ProcessLogs filters the given log records based off the filterLogProcessor's filters.
+func (flp *filterLogProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	ld.ResourceLogs().RemoveIf(func(rl pdata.ResourceLogs) bool {
+		resource := rl.Resource()
+		rl.InstrumentationLibraryLogs().RemoveIf(func(ill pdata.InstrumentationLibraryLogs) bool {
+			library := ill.InstrumentationLibrary()
+			ill.Logs().RemoveIf(func(lr pdata.LogRecord) bool {
+				return filterlog.SkipLogRecord(flp.include, flp.exclude, lr, resource, library)
+			})
+			// Filter out empty InstrumentationLibraryLogs
+			return ill.Logs().Len() == 0
+		})
+		// Filter out empty ResourceLogs
+		return rl.InstrumentationLibraryLogs().Len() == 0
+	})
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
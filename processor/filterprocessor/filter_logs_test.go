@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+func TestFilterLogProcessor(t *testing.T) {
+	tests := []struct {
+		name    string
+		include *filterconfig.MatchProperties
+		exclude *filterconfig.MatchProperties
+		want    []string
+	}{
+		{
+			name: "exclude debug",
+			exclude: &filterconfig.MatchProperties{
+				Config:           filterset.Config{MatchType: filterset.Regexp},
+				LogSeverityTexts: []string{"(?i)debug"},
+			},
+			want: []string{"GET /checkout 200", "payment failed"},
+		},
+		{
+			name: "include min severity",
+			include: &filterconfig.MatchProperties{
+				Config:            filterset.Config{MatchType: filterset.Strict},
+				LogSeverityNumber: &filterconfig.LogSeverityNumberMatchProperties{Min: pdata.SeverityNumberWARN},
+			},
+			want: []string{"payment failed"},
+		},
+		{
+			name: "exclude body",
+			exclude: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Regexp},
+				LogBodies: []string{"^GET "},
+			},
+			want: []string{"payment failed"},
+		},
+		{
+			name: "include resource and exclude body",
+			include: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Strict},
+				Resources: []filterconfig.Attribute{{Key: "service.name", Value: "checkout"}},
+			},
+			exclude: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Regexp},
+				LogBodies: []string{"^GET /health"},
+			},
+			want: []string{"GET /checkout 200"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := new(consumertest.LogsSink)
+			cfg := createDefaultConfig().(*Config)
+			cfg.Logs = LogFilters{Include: tt.include, Exclude: tt.exclude}
+			lp, err := createLogsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, next)
+			require.NoError(t, err)
+
+			require.NoError(t, lp.ConsumeLogs(context.Background(), testLogs()))
+			require.Len(t, next.AllLogs(), 1)
+
+			var got []string
+			rls := next.AllLogs()[0].ResourceLogs()
+			for i := 0; i < rls.Len(); i++ {
+				ills := rls.At(i).InstrumentationLibraryLogs()
+				for j := 0; j < ills.Len(); j++ {
+					logs := ills.At(j).Logs()
+					for k := 0; k < logs.Len(); k++ {
+						got = append(got, logs.At(k).Body().StringVal())
+					}
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilterLogProcessor_AllFiltered(t *testing.T) {
+	next := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Include = &filterconfig.MatchProperties{
+		Config:            filterset.Config{MatchType: filterset.Strict},
+		LogSeverityNumber: &filterconfig.LogSeverityNumberMatchProperties{Min: pdata.SeverityNumberFATAL},
+	}
+	lp, err := createLogsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, next)
+	require.NoError(t, err)
+
+	require.NoError(t, lp.ConsumeLogs(context.Background(), testLogs()))
+	assert.Len(t, next.AllLogs(), 0)
+}
+
+func TestFilterLogProcessor_InvalidConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Exclude = &filterconfig.MatchProperties{
+		Config:    filterset.Config{MatchType: filterset.Regexp},
+		LogBodies: []string{"["},
+	}
+	_, err := createLogsProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewNop())
+	assert.Error(t, err)
+}
+
+// testLogs returns a debug "GET /health 200" and an info "GET /checkout 200" log record from the checkout service,
+// and an error "payment failed" log record from the payment service.
+func testLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	checkout := ld.ResourceLogs().AppendEmpty()
+	checkout.Resource().Attributes().InsertString("service.name", "checkout")
+	logs := checkout.InstrumentationLibraryLogs().AppendEmpty().Logs()
+	appendLogRecord(logs, "DEBUG", pdata.SeverityNumberDEBUG, "GET /health 200")
+	appendLogRecord(logs, "INFO", pdata.SeverityNumberINFO, "GET /checkout 200")
+
+	payment := ld.ResourceLogs().AppendEmpty()
+	payment.Resource().Attributes().InsertString("service.name", "payment")
+	appendLogRecord(payment.InstrumentationLibraryLogs().AppendEmpty().Logs(), "ERROR", pdata.SeverityNumberERROR, "payment failed")
+	return ld
+}
+
+func appendLogRecord(logs pdata.LogSlice, severityText string, severityNumber pdata.SeverityNumber, body string) {
+	lr := logs.AppendEmpty()
+	lr.SetSeverityText(severityText)
+	lr.SetSeverityNumber(severityNumber)
+	lr.Body().SetStringVal(body)
+}
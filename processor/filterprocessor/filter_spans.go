@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+type filterSpanProcessor struct {
+	include filterspan.Matcher
+	exclude filterspan.Matcher
+	logger  *zap.Logger
+}
+
+func newFilterSpanProcessor(logger *zap.Logger, cfg *Config) (*filterSpanProcessor, error) {
+	inc, err := filterspan.NewMatcher(cfg.Spans.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	exc, err := filterspan.NewMatcher(cfg.Spans.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(
+		"Span filter configured",
+		zap.Any("include", cfg.Spans.Include),
+		zap.Any("exclude", cfg.Spans.Exclude),
+	)
+
+	return &filterSpanProcessor{
+		include: inc,
+		exclude: exc,
+		logger:  logger,
+	}, nil
+}
+
+// ProcessTraces filters the given spans based off the filterSpanProcessor's filters.
+func (fsp *filterSpanProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	td.ResourceSpans().RemoveIf(func(rs pdata.ResourceSpans) bool {
+		resource := rs.Resource()
+		rs.InstrumentationLibrarySpans().RemoveIf(func(ils pdata.InstrumentationLibrarySpans) bool {
+			library := ils.InstrumentationLibrary()
+			ils.Spans().RemoveIf(func(span pdata.Span) bool {
+				return filterspan.SkipSpan(fsp.include, fsp.exclude, span, resource, library)
+			})
+			// Filter out empty InstrumentationLibrarySpans
+			return ils.Spans().Len() == 0
+		})
+		// Filter out empty ResourceSpans
+		return rs.InstrumentationLibrarySpans().Len() == 0
+	})
+	if td.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func TestFilterSpanProcessor(t *testing.T) {
+	tests := []struct {
+		name    string
+		include *filterconfig.MatchProperties
+		exclude *filterconfig.MatchProperties
+		want    []string
+	}{
+		{
+			name: "exclude health checks",
+			exclude: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Regexp},
+				SpanNames: []string{"^GET /health"},
+			},
+			want: []string{"GET /checkout", "GET /payment"},
+		},
+		{
+			name: "include service",
+			include: &filterconfig.MatchProperties{
+				Config:   filterset.Config{MatchType: filterset.Strict},
+				Services: []string{"checkout"},
+			},
+			want: []string{"GET /health", "GET /checkout"},
+		},
+		{
+			name: "include and exclude",
+			include: &filterconfig.MatchProperties{
+				Config:   filterset.Config{MatchType: filterset.Strict},
+				Services: []string{"checkout"},
+			},
+			exclude: &filterconfig.MatchProperties{
+				Config:     filterset.Config{MatchType: filterset.Strict},
+				Attributes: []filterconfig.Attribute{{Key: "http.user_agent", Value: "kube-probe/1.20"}},
+			},
+			want: []string{"GET /checkout"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := new(consumertest.TracesSink)
+			cfg := createDefaultConfig().(*Config)
+			cfg.Spans = SpanFilters{Include: tt.include, Exclude: tt.exclude}
+			tp, err := createTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, next)
+			require.NoError(t, err)
+
+			require.NoError(t, tp.ConsumeTraces(context.Background(), testTraces()))
+			require.Len(t, next.AllTraces(), 1)
+
+			var got []string
+			rss := next.AllTraces()[0].ResourceSpans()
+			for i := 0; i < rss.Len(); i++ {
+				ilss := rss.At(i).InstrumentationLibrarySpans()
+				for j := 0; j < ilss.Len(); j++ {
+					spans := ilss.At(j).Spans()
+					for k := 0; k < spans.Len(); k++ {
+						got = append(got, spans.At(k).Name())
+					}
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilterSpanProcessor_AllFiltered(t *testing.T) {
+	next := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Spans.Exclude = &filterconfig.MatchProperties{
+		Config:    filterset.Config{MatchType: filterset.Regexp},
+		SpanNames: []string{"^GET "},
+	}
+	tp, err := createTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, next)
+	require.NoError(t, err)
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), testTraces()))
+	assert.Len(t, next.AllTraces(), 0)
+}
+
+func TestFilterSpanProcessor_InvalidConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Spans.Include = &filterconfig.MatchProperties{
+		Config:    filterset.Config{MatchType: filterset.Regexp},
+		SpanNames: []string{"["},
+	}
+	_, err := createTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, cfg, consumertest.NewNop())
+	assert.Error(t, err)
+}
+
+// testTraces returns a "GET /health" and a "GET /checkout" span from the checkout service, and a "GET /payment"
+// span from the payment service.
+func testTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	for _, service := range []string{"checkout", "payment"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, service)
+		spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+		if service == "checkout" {
+			health := spans.AppendEmpty()
+			health.SetName("GET /health")
+			health.Attributes().InsertString("http.user_agent", "kube-probe/1.20")
+		}
+		spans.AppendEmpty().SetName("GET /" + service)
+	}
+	return td
+}
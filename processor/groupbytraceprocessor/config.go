@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the group-by-trace processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// WaitDuration is the time to hold the spans of a trace after its first span, before sending them in a single batch.
+	WaitDuration time.Duration `mapstructure:"wait_duration"`
+
+	// NumTraces is the maximum number of traces held in memory. When it is reached, the oldest trace is sent
+	// before its WaitDuration.
+	NumTraces int `mapstructure:"num_traces"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.WaitDuration <= 0 {
+		return errors.New("wait_duration must be positive")
+	}
+	if cfg.NumTraces <= 0 {
+		return errors.New("num_traces must be positive")
+	}
+	return nil
+}
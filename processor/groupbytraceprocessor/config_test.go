@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Processors[config.NewID(typeStr)])
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "custom")),
+			WaitDuration:      10 * time.Second,
+			NumTraces:         1000,
+		}, cfg.Processors[config.NewIDWithName(typeStr, "custom")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.WaitDuration = 0
+	assert.EqualError(t, cfg.Validate(), "wait_duration must be positive")
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.NumTraces = 0
+	assert.EqualError(t, cfg.Validate(), "num_traces must be positive")
+}
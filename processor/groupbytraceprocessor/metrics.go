@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+var (
+	processorTagKey = tag.MustNewKey(obsreport.ProcessorKey)
+
+	statTracesInMemory = stats.Int64("traces_in_memory", "Number of traces held in memory", stats.UnitDimensionless)
+	statTracesReleased = stats.Int64("traces_released", "Number of traces sent after their wait duration", stats.UnitDimensionless)
+	statTracesEvicted  = stats.Int64("traces_evicted", "Number of traces sent before their wait duration because num_traces was reached", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views related to grouping by trace
+func MetricViews() []*view.View {
+	processorTagKeys := []tag.Key{processorTagKey}
+
+	lastValueTracesInMemoryView := &view.View{
+		Name:        statTracesInMemory.Name(),
+		Measure:     statTracesInMemory,
+		Description: statTracesInMemory.Description(),
+		TagKeys:     processorTagKeys,
+		Aggregation: view.LastValue(),
+	}
+
+	countTracesReleasedView := &view.View{
+		Name:        statTracesReleased.Name(),
+		Measure:     statTracesReleased,
+		Description: statTracesReleased.Description(),
+		TagKeys:     processorTagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	countTracesEvictedView := &view.View{
+		Name:        statTracesEvicted.Name(),
+		Measure:     statTracesEvicted,
+		Description: statTracesEvicted.Description(),
+		TagKeys:     processorTagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	legacyViews := []*view.View{
+		lastValueTracesInMemoryView,
+		countTracesReleasedView,
+		countTracesEvictedView,
+	}
+
+	return obsreport.ProcessorMetricViews(typeStr, legacyViews)
+}
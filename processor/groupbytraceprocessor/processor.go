@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/batchpertrace"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+// minTickInterval bounds the interval of the checks for the traces to release, for short wait durations.
+const minTickInterval = time.Millisecond
+
+// groupedTrace holds the spans of a trace received during its wait duration.
+type groupedTrace struct {
+	arrival time.Time
+	spans   pdata.Traces
+	// elem is the element of the trace in the arrival list.
+	elem *list.Element
+}
+
+// groupByTraceProcessor holds the spans by trace ID for WaitDuration after the first span of the trace, then
+// sends all the spans of the trace to the next consumer in a single batch.
+type groupByTraceProcessor struct {
+	nextConsumer consumer.Traces
+	logger       *zap.Logger
+	waitDuration time.Duration
+	numTraces    int
+	obsrep       *obsreport.Processor
+	// metricsCtx holds the processor tag of the custom metrics.
+	metricsCtx   context.Context
+	tickInterval time.Duration
+	now          func() time.Time
+
+	mu     sync.Mutex
+	traces map[pdata.TraceID]*groupedTrace
+	// arrivals holds the IDs of the traces in arrival order.
+	arrivals *list.List
+
+	stopCh chan struct{}
+	stopWg sync.WaitGroup
+}
+
+var _ component.TracesProcessor = (*groupByTraceProcessor)(nil)
+
+func newGroupByTraceProcessor(params component.ProcessorCreateParams, nextConsumer consumer.Traces, cfg *Config) (*groupByTraceProcessor, error) {
+	metricsCtx, err := tag.New(context.Background(), tag.Insert(processorTagKey, cfg.ID().String()))
+	if err != nil {
+		return nil, err
+	}
+	tickInterval := cfg.WaitDuration / 10
+	if tickInterval < minTickInterval {
+		tickInterval = minTickInterval
+	}
+	return &groupByTraceProcessor{
+		nextConsumer: nextConsumer,
+		logger:       params.Logger,
+		waitDuration: cfg.WaitDuration,
+		numTraces:    cfg.NumTraces,
+		obsrep: obsreport.NewProcessor(obsreport.ProcessorSettings{
+			Level:       configtelemetry.GetMetricsLevelFlagValue(),
+			ProcessorID: cfg.ID(),
+		}),
+		metricsCtx:   metricsCtx,
+		tickInterval: tickInterval,
+		now:          time.Now,
+		traces:       make(map[pdata.TraceID]*groupedTrace),
+		arrivals:     list.New(),
+		stopCh:       make(chan struct{}),
+	}, nil
+}
+
+// Start starts the release of the traces after their wait duration.
+func (gp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+	gp.stopWg.Add(1)
+	go func() {
+		defer gp.stopWg.Done()
+		ticker := time.NewTicker(gp.tickInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				gp.release(context.Background(), gp.removeExpired(gp.now()))
+			case <-gp.stopCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown sends all the traces held in memory without waiting.
+func (gp *groupByTraceProcessor) Shutdown(ctx context.Context) error {
+	close(gp.stopCh)
+	gp.stopWg.Wait()
+
+	gp.mu.Lock()
+	var toRelease []pdata.Traces
+	for front := gp.arrivals.Front(); front != nil; front = gp.arrivals.Front() {
+		toRelease = append(toRelease, gp.remove(front.Value.(pdata.TraceID)))
+	}
+	gp.recordTracesInMemory()
+	gp.mu.Unlock()
+
+	return gp.release(ctx, toRelease)
+}
+
+// Capabilities returns the consumer capabilities of the processor, the spans are copied.
+func (gp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces adds the spans to their trace, and sends the oldest traces if NumTraces is reached.
+// The evicted traces are sent without the context of the caller and their errors are not returned.
+func (gp *groupByTraceProcessor) ConsumeTraces(_ context.Context, td pdata.Traces) error {
+	now := gp.now()
+	var evicted []pdata.Traces
+
+	gp.mu.Lock()
+	for traceID, batch := range batchpertrace.Split(td) {
+		trace, ok := gp.traces[traceID]
+		if !ok {
+			if len(gp.traces) >= gp.numTraces {
+				evicted = append(evicted, gp.remove(gp.arrivals.Front().Value.(pdata.TraceID)))
+			}
+			trace = &groupedTrace{arrival: now, spans: pdata.NewTraces()}
+			trace.elem = gp.arrivals.PushBack(traceID)
+			gp.traces[traceID] = trace
+		}
+		batch.ResourceSpans().MoveAndAppendTo(trace.spans.ResourceSpans())
+	}
+	gp.recordTracesInMemory()
+	gp.mu.Unlock()
+
+	if len(evicted) > 0 {
+		stats.Record(gp.metricsCtx, statTracesEvicted.M(int64(len(evicted))))
+	}
+	// The failures are logged and counted by release.
+	_ = gp.release(context.Background(), evicted)
+	return nil
+}
+
+// removeExpired removes the traces held for WaitDuration or more, and returns their spans.
+func (gp *groupByTraceProcessor) removeExpired(now time.Time) []pdata.Traces {
+	gp.mu.Lock()
+	defer gp.mu.Unlock()
+
+	var expired []pdata.Traces
+	for front := gp.arrivals.Front(); front != nil; front = gp.arrivals.Front() {
+		traceID := front.Value.(pdata.TraceID)
+		if now.Sub(gp.traces[traceID].arrival) < gp.waitDuration {
+			break
+		}
+		expired = append(expired, gp.remove(traceID))
+	}
+	if len(expired) > 0 {
+		gp.recordTracesInMemory()
+		stats.Record(gp.metricsCtx, statTracesReleased.M(int64(len(expired))))
+	}
+	return expired
+}
+
+// remove removes a trace from memory and returns its spans.
+func (gp *groupByTraceProcessor) remove(traceID pdata.TraceID) pdata.Traces {
+	trace := gp.traces[traceID]
+	gp.arrivals.Remove(trace.elem)
+	delete(gp.traces, traceID)
+	return trace.spans
+}
+
+func (gp *groupByTraceProcessor) recordTracesInMemory() {
+	stats.Record(gp.metricsCtx, statTracesInMemory.M(int64(len(gp.traces))))
+}
+
+// release sends every trace to the next consumer in its own batch.
+func (gp *groupByTraceProcessor) release(ctx context.Context, traces []pdata.Traces) error {
+	var errs []error
+	for _, td := range traces {
+		spanCount := td.SpanCount()
+		if err := gp.nextConsumer.ConsumeTraces(ctx, td); err != nil {
+			gp.obsrep.TracesRefused(ctx, spanCount)
+			gp.logger.Warn("Failed to send the spans of a trace.", zap.Int("spans", spanCount), zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+		gp.obsrep.TracesAccepted(ctx, spanCount)
+	}
+	return consumererror.Combine(errs)
+}
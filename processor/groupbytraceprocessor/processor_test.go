@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// testTraceID returns the trace ID with the given first byte.
+func testTraceID(i byte) pdata.TraceID {
+	return pdata.NewTraceID([16]byte{i})
+}
+
+// generateSpans returns a span of the given service for every trace ID.
+func generateSpans(service string, traceIDs ...pdata.TraceID) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", service)
+	ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+	for _, traceID := range traceIDs {
+		ils.Spans().AppendEmpty().SetTraceID(traceID)
+	}
+	return td
+}
+
+// newTestProcessor returns a processor with a controlled clock.
+func newTestProcessor(t *testing.T, numTraces int, next *consumertest.TracesSink) (*groupByTraceProcessor, *time.Time) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.WaitDuration = 10 * time.Second
+	cfg.NumTraces = numTraces
+	gp, err := newGroupByTraceProcessor(component.ProcessorCreateParams{Logger: zap.NewNop()}, next, cfg)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	gp.now = func() time.Time { return now }
+	return gp, &now
+}
+
+// traceIDs returns the trace ID of every batch, and checks that all the spans of a batch have the same trace ID.
+func traceIDs(t *testing.T, sink *consumertest.TracesSink) []pdata.TraceID {
+	var ids []pdata.TraceID
+	for _, td := range sink.AllTraces() {
+		seen := make(map[pdata.TraceID]bool)
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			spans := rss.At(i).InstrumentationLibrarySpans().At(0).Spans()
+			for j := 0; j < spans.Len(); j++ {
+				seen[spans.At(j).TraceID()] = true
+			}
+		}
+		require.Len(t, seen, 1)
+		for traceID := range seen {
+			ids = append(ids, traceID)
+		}
+	}
+	return ids
+}
+
+func TestGroupByTrace_ReleaseAfterWait(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	gp, now := newTestProcessor(t, 100, sink)
+
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("frontend", testTraceID(1), testTraceID(2))))
+	*now = now.Add(5 * time.Second)
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("backend", testTraceID(1), testTraceID(3), testTraceID(1))))
+
+	require.NoError(t, gp.release(context.Background(), gp.removeExpired(now.Add(4*time.Second))))
+	assert.Equal(t, 0, len(sink.AllTraces()))
+
+	require.NoError(t, gp.release(context.Background(), gp.removeExpired(now.Add(5*time.Second))))
+	require.Len(t, sink.AllTraces(), 2)
+	// Trace 1 and 2 arrived in the same batch, in any order.
+	assert.ElementsMatch(t, []pdata.TraceID{testTraceID(1), testTraceID(2)}, traceIDs(t, sink))
+	for _, td := range sink.AllTraces() {
+		if td.SpanCount() == 3 {
+			assert.Equal(t, 2, td.ResourceSpans().Len())
+		} else {
+			assert.Equal(t, 1, td.SpanCount())
+		}
+	}
+
+	// A late span starts a new group.
+	sink.Reset()
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("backend", testTraceID(1))))
+	require.NoError(t, gp.release(context.Background(), gp.removeExpired(now.Add(20*time.Second))))
+	assert.ElementsMatch(t, []pdata.TraceID{testTraceID(3), testTraceID(1)}, traceIDs(t, sink))
+	assert.Len(t, gp.traces, 0)
+	assert.Equal(t, 0, gp.arrivals.Len())
+}
+
+func TestGroupByTrace_EvictOldestTrace(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	gp, _ := newTestProcessor(t, 2, sink)
+
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("frontend", testTraceID(1))))
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("frontend", testTraceID(2))))
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("frontend", testTraceID(2))))
+	assert.Equal(t, 0, len(sink.AllTraces()))
+
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("frontend", testTraceID(3))))
+	assert.Equal(t, []pdata.TraceID{testTraceID(1)}, traceIDs(t, sink))
+	assert.Len(t, gp.traces, 2)
+}
+
+func TestGroupByTrace_ShutdownReleasesTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	gp, _ := newTestProcessor(t, 100, sink)
+	require.NoError(t, gp.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("frontend", testTraceID(1))))
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("frontend", testTraceID(2))))
+	require.NoError(t, gp.Shutdown(context.Background()))
+	assert.Equal(t, []pdata.TraceID{testTraceID(1), testTraceID(2)}, traceIDs(t, sink))
+}
+
+func TestGroupByTrace_Ticker(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	gp, _ := newTestProcessor(t, 100, sink)
+	gp.now = time.Now
+	gp.waitDuration = 10 * time.Millisecond
+	gp.tickInterval = 5 * time.Millisecond
+	require.NoError(t, gp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, gp.Shutdown(context.Background()))
+	}()
+
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("frontend", testTraceID(1))))
+	assert.Eventually(t, func() bool {
+		return sink.SpansCount() == 1
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestGroupByTrace_NextConsumerError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	gp, err := newGroupByTraceProcessor(component.ProcessorCreateParams{Logger: zap.NewNop()}, consumertest.NewErr(errors.New("failed")), cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("frontend", testTraceID(1))))
+	assert.EqualError(t, gp.Shutdown(context.Background()), "failed")
+}
+
+func TestGroupByTrace_EvictedTraceError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NumTraces = 1
+	gp, err := newGroupByTraceProcessor(component.ProcessorCreateParams{Logger: zap.NewNop()}, consumertest.NewErr(errors.New("failed")), cfg)
+	require.NoError(t, err)
+
+	// The failure of the evicted trace 1 is not returned for the spans of the trace 2.
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("frontend", testTraceID(1))))
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("frontend", testTraceID(2))))
+	assert.Len(t, gp.traces, 1)
+}
+
+func TestGroupByTrace_Metrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	sink := new(consumertest.TracesSink)
+	gp, now := newTestProcessor(t, 2, sink)
+	for i := byte(1); i <= 3; i++ {
+		require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("frontend", testTraceID(i))))
+	}
+	require.NoError(t, gp.ConsumeTraces(context.Background(), generateSpans("frontend", testTraceID(2))))
+	require.NoError(t, gp.release(context.Background(), gp.removeExpired(now.Add(10*time.Second))))
+
+	for name, want := range map[string]float64{
+		statTracesInMemory.Name(): 0,
+		statTracesReleased.Name(): 2,
+		statTracesEvicted.Name():  1,
+	} {
+		viewData, err := view.RetrieveData("processor/groupbytrace/" + name)
+		require.NoError(t, err)
+		require.Len(t, viewData, 1, name)
+		switch data := viewData[0].Data.(type) {
+		case *view.SumData:
+			assert.Equal(t, want, data.Value, name)
+		case *view.LastValueData:
+			assert.Equal(t, want, data.Value, name)
+		}
+	}
+}
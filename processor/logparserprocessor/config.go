@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Format is the format of the log bodies.
+type Format string
+
+const (
+	// JSON parses the bodies as JSON objects.
+	JSON Format = "json"
+	// Regex parses the bodies with the named groups of a regular expression.
+	Regex Format = "regex"
+	// Logfmt parses the bodies as space separated key=value pairs.
+	Logfmt Format = "logfmt"
+)
+
+// OnError is the handling of the log records that fail to be parsed.
+type OnError string
+
+const (
+	// OnErrorKeep keeps the log record unchanged.
+	OnErrorKeep OnError = "keep"
+	// OnErrorAnnotate keeps the log record unchanged, except for an attribute with the error.
+	OnErrorAnnotate OnError = "annotate"
+	// OnErrorDrop drops the log record.
+	OnErrorDrop OnError = "drop"
+)
+
+// Timestamp layouts for the timestamps in seconds, milliseconds, microseconds and nanoseconds since the epoch.
+const (
+	LayoutUnix   = "unix"
+	LayoutUnixMs = "unix_ms"
+	LayoutUnixUs = "unix_us"
+	LayoutUnixNs = "unix_ns"
+)
+
+// Config defines configuration for the log parser processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Format is the format of the log bodies, json, regex or logfmt.
+	Format Format `mapstructure:"format"`
+
+	// Pattern is the regular expression of the regex format. The named groups are the parsed fields.
+	Pattern string `mapstructure:"pattern"`
+
+	// TimestampField is the parsed field promoted to the timestamp of the log record.
+	TimestampField string `mapstructure:"timestamp_field"`
+
+	// TimestampLayout is the Go time layout of the timestamp field, or unix, unix_ms, unix_us or unix_ns for the
+	// time since the epoch. The default is RFC 3339.
+	TimestampLayout string `mapstructure:"timestamp_layout"`
+
+	// SeverityField is the parsed field promoted to the severity of the log record.
+	SeverityField string `mapstructure:"severity_field"`
+
+	// TraceIDField is the parsed field promoted to the trace ID of the log record, as 32 hex characters.
+	TraceIDField string `mapstructure:"trace_id_field"`
+
+	// SpanIDField is the parsed field promoted to the span ID of the log record, as 16 hex characters.
+	SpanIDField string `mapstructure:"span_id_field"`
+
+	// OnError is the handling of the log records that fail to be parsed, keep (default), annotate or drop.
+	OnError OnError `mapstructure:"on_error"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	switch cfg.Format {
+	case JSON, Logfmt:
+		if cfg.Pattern != "" {
+			return errors.New("pattern can only be set for the regex format")
+		}
+	case Regex:
+		if cfg.Pattern == "" {
+			return errors.New("pattern must be set for the regex format")
+		}
+		re, err := regexp.Compile(cfg.Pattern)
+		if err != nil {
+			return fmt.Errorf("pattern: %w", err)
+		}
+		if !hasNamedGroup(re) {
+			return errors.New("pattern must have at least one named group")
+		}
+	default:
+		return fmt.Errorf("unsupported format %q, must be json, regex or logfmt", cfg.Format)
+	}
+
+	switch cfg.OnError {
+	case OnErrorKeep, OnErrorAnnotate, OnErrorDrop:
+	default:
+		return fmt.Errorf("unsupported on_error %q, must be keep, annotate or drop", cfg.OnError)
+	}
+	return nil
+}
+
+func hasNamedGroup(re *regexp.Regexp) bool {
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Processors[config.NewID(typeStr)])
+
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "regex")),
+			Format:            Regex,
+			Pattern:           `^(?P<time>\S+) (?P<level>\w+) (?P<message>.*)$`,
+			TimestampField:    "time",
+			TimestampLayout:   time.RFC3339Nano,
+			SeverityField:     "level",
+			OnError:           OnErrorAnnotate,
+		}, cfg.Processors[config.NewIDWithName(typeStr, "regex")])
+
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "logfmt")),
+			Format:            Logfmt,
+			TimestampField:    "ts",
+			TimestampLayout:   LayoutUnixMs,
+			SeverityField:     "level",
+			TraceIDField:      "trace_id",
+			SpanIDField:       "span_id",
+			OnError:           OnErrorDrop,
+		}, cfg.Processors[config.NewIDWithName(typeStr, "logfmt")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "unsupported format",
+			modify: func(cfg *Config) { cfg.Format = "xml" },
+			err:    `unsupported format "xml", must be json, regex or logfmt`,
+		},
+		{
+			name:   "pattern for json",
+			modify: func(cfg *Config) { cfg.Pattern = "(?P<message>.*)" },
+			err:    "pattern can only be set for the regex format",
+		},
+		{
+			name:   "no pattern",
+			modify: func(cfg *Config) { cfg.Format = Regex },
+			err:    "pattern must be set for the regex format",
+		},
+		{
+			name: "invalid pattern",
+			modify: func(cfg *Config) {
+				cfg.Format = Regex
+				cfg.Pattern = "(?P<message>"
+			},
+			err: "pattern: error parsing regexp: missing closing ): `(?P<message>`",
+		},
+		{
+			name: "no named group",
+			modify: func(cfg *Config) {
+				cfg.Format = Regex
+				cfg.Pattern = "(.*)"
+			},
+			err: "pattern must have at least one named group",
+		},
+		{
+			name:   "unsupported on_error",
+			modify: func(cfg *Config) { cfg.OnError = "ignore" },
+			err:    `unsupported on_error "ignore", must be keep, annotate or drop`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
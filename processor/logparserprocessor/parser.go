@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// parser parses a log body into the attributes.
+type parser func(body string, attrs pdata.AttributeMap) error
+
+func newParser(cfg *Config) (parser, error) {
+	switch cfg.Format {
+	case JSON:
+		return parseJSON, nil
+	case Regex:
+		re, err := regexp.Compile(cfg.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		return newRegexParser(re), nil
+	case Logfmt:
+		return parseLogfmt, nil
+	}
+	return nil, fmt.Errorf("unsupported format %q", cfg.Format)
+}
+
+// parseJSON parses a JSON object. The nested objects and arrays are parsed into map and array values.
+func parseJSON(body string, attrs pdata.AttributeMap) error {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	// Keep the integers as integers, instead of float64.
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return fmt.Errorf("body is not a JSON object: %w", err)
+	}
+	if decoder.More() {
+		return errors.New("body is not a JSON object: unexpected data after the object")
+	}
+
+	insertJSONFields(attrs, fields)
+	return nil
+}
+
+func insertJSONFields(attrs pdata.AttributeMap, fields map[string]interface{}) {
+	// Insert the fields in a deterministic order.
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		attrs.Upsert(k, newJSONAttributeValue(fields[k]))
+	}
+}
+
+func newJSONAttributeValue(value interface{}) pdata.AttributeValue {
+	switch v := value.(type) {
+	case string:
+		return pdata.NewAttributeValueString(v)
+	case bool:
+		return pdata.NewAttributeValueBool(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return pdata.NewAttributeValueInt(i)
+		}
+		f, _ := v.Float64()
+		return pdata.NewAttributeValueDouble(f)
+	case map[string]interface{}:
+		av := pdata.NewAttributeValueMap()
+		insertJSONFields(av.MapVal(), v)
+		return av
+	case []interface{}:
+		av := pdata.NewAttributeValueArray()
+		arr := av.ArrayVal()
+		for _, e := range v {
+			arr.Append(newJSONAttributeValue(e))
+		}
+		return av
+	}
+	return pdata.NewAttributeValueNull()
+}
+
+// newRegexParser returns a parser setting the named groups of the regular expression, that participate in the match.
+func newRegexParser(re *regexp.Regexp) parser {
+	names := re.SubexpNames()
+	return func(body string, attrs pdata.AttributeMap) error {
+		match := re.FindStringSubmatchIndex(body)
+		if match == nil {
+			return errors.New("body does not match the pattern")
+		}
+		for i := 1; i < len(names); i++ {
+			if names[i] == "" || match[2*i] < 0 {
+				continue
+			}
+			attrs.UpsertString(names[i], body[match[2*i]:match[2*i+1]])
+		}
+		return nil
+	}
+}
+
+// parseLogfmt parses space separated key=value pairs. The values can be double quoted, with Go escape sequences.
+// A key without value has an empty value.
+func parseLogfmt(body string, attrs pdata.AttributeMap) error {
+	found := false
+	for i := 0; i < len(body); {
+		if isLogfmtSpace(body[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(body) && body[i] != '=' && !isLogfmtSpace(body[i]) {
+			i++
+		}
+		key := body[start:i]
+		if key == "" {
+			return fmt.Errorf("missing key at offset %d", start)
+		}
+		if i == len(body) || body[i] != '=' {
+			attrs.UpsertString(key, "")
+			found = true
+			continue
+		}
+		// Skip the '='.
+		i++
+
+		var value string
+		if i < len(body) && body[i] == '"' {
+			end := closingQuote(body, i)
+			if end < 0 {
+				return fmt.Errorf("unterminated quoted value of key %q", key)
+			}
+			unquoted, err := strconv.Unquote(body[i : end+1])
+			if err != nil {
+				return fmt.Errorf("invalid quoted value of key %q: %w", key, err)
+			}
+			value = unquoted
+			i = end + 1
+		} else {
+			start = i
+			for i < len(body) && !isLogfmtSpace(body[i]) {
+				i++
+			}
+			value = body[start:i]
+		}
+		attrs.UpsertString(key, value)
+		found = true
+	}
+
+	if !found {
+		return errors.New("body has no key-value pairs")
+	}
+	return nil
+}
+
+// closingQuote returns the index of the quote closing the one at the given index, or -1.
+func closingQuote(s string, open int) int {
+	for i := open + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			// Skip the escaped character.
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func isLogfmtSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestParseJSON(t *testing.T) {
+	attrs := pdata.NewAttributeMap()
+	require.NoError(t, parseJSON(`{"msg":"hello","count":3,"ratio":0.5,"ok":true,"none":null,"user":{"id":"1234"},"tags":["a",1]}`, attrs))
+
+	user := pdata.NewAttributeValueMap()
+	user.MapVal().InsertString("id", "1234")
+	tags := pdata.NewAttributeValueArray()
+	tags.ArrayVal().Append(pdata.NewAttributeValueString("a"))
+	tags.ArrayVal().Append(pdata.NewAttributeValueInt(1))
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"msg":   pdata.NewAttributeValueString("hello"),
+		"count": pdata.NewAttributeValueInt(3),
+		"ratio": pdata.NewAttributeValueDouble(0.5),
+		"ok":    pdata.NewAttributeValueBool(true),
+		"none":  pdata.NewAttributeValueNull(),
+		"user":  user,
+		"tags":  tags,
+	}).Sort(), attrs.Sort())
+}
+
+func TestParseJSON_Errors(t *testing.T) {
+	for _, body := range []string{"hello", `["a"]`, `{"msg":"hello"`, `{"msg":"hello"} {}`} {
+		assert.Error(t, parseJSON(body, pdata.NewAttributeMap()), body)
+	}
+}
+
+func TestRegexParser(t *testing.T) {
+	parse := newRegexParser(regexp.MustCompile(`^(?P<method>[A-Z]+) (?P<path>\S+)(?: (?P<status>\d+))?(?: (\w+))?$`))
+
+	attrs := pdata.NewAttributeMap()
+	require.NoError(t, parse("GET /health", attrs))
+	// The groups that do not participate in the match, and the unnamed groups, are not set.
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"method": pdata.NewAttributeValueString("GET"),
+		"path":   pdata.NewAttributeValueString("/health"),
+	}).Sort(), attrs.Sort())
+
+	attrs = pdata.NewAttributeMap()
+	require.NoError(t, parse("POST /pay 500 slow", attrs))
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"method": pdata.NewAttributeValueString("POST"),
+		"path":   pdata.NewAttributeValueString("/pay"),
+		"status": pdata.NewAttributeValueString("500"),
+	}).Sort(), attrs.Sort())
+
+	assert.EqualError(t, parse("get /health", pdata.NewAttributeMap()), "body does not match the pattern")
+}
+
+func TestParseLogfmt(t *testing.T) {
+	attrs := pdata.NewAttributeMap()
+	require.NoError(t, parseLogfmt(` level=info msg="user \"john\" logged in"	empty= flag path=/login `, attrs))
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"level": pdata.NewAttributeValueString("info"),
+		"msg":   pdata.NewAttributeValueString(`user "john" logged in`),
+		"empty": pdata.NewAttributeValueString(""),
+		"flag":  pdata.NewAttributeValueString(""),
+		"path":  pdata.NewAttributeValueString("/login"),
+	}).Sort(), attrs.Sort())
+}
+
+func TestParseLogfmt_Errors(t *testing.T) {
+	tests := []struct {
+		body string
+		err  string
+	}{
+		{body: "  ", err: "body has no key-value pairs"},
+		{body: "level=info =value", err: "missing key at offset 11"},
+		{body: `msg="hello`, err: `unterminated quoted value of key "msg"`},
+		{body: `msg="\q"`, err: `invalid quoted value of key "msg": invalid syntax`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			assert.EqualError(t, parseLogfmt(tt.body, pdata.NewAttributeMap()), tt.err)
+		})
+	}
+}
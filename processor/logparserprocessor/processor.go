@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// errorAttribute is the attribute recording the parsing error of the annotated log records.
+const errorAttribute = "logparser.error"
+
+// logParserProcessor parses the string bodies of the log records into their attributes.
+type logParserProcessor struct {
+	cfg   *Config
+	parse parser
+}
+
+func newLogParserProcessor(cfg *Config) (*logParserProcessor, error) {
+	parse, err := newParser(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &logParserProcessor{
+		cfg:   cfg,
+		parse: parse,
+	}, nil
+}
+
+// ProcessLogs parses the bodies of the log records, and drops the log records that fail to be parsed if configured.
+func (lpp *logParserProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	ld.ResourceLogs().RemoveIf(func(rl pdata.ResourceLogs) bool {
+		rl.InstrumentationLibraryLogs().RemoveIf(func(ill pdata.InstrumentationLibraryLogs) bool {
+			ill.Logs().RemoveIf(func(lr pdata.LogRecord) bool {
+				return !lpp.processLogRecord(lr)
+			})
+			// Filter out empty InstrumentationLibraryLogs
+			return ill.Logs().Len() == 0
+		})
+		// Filter out empty ResourceLogs
+		return rl.InstrumentationLibraryLogs().Len() == 0
+	})
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// processLogRecord parses the body of the log record, and returns whether the log record must be kept.
+// The log records without a string body are left unchanged.
+func (lpp *logParserProcessor) processLogRecord(lr pdata.LogRecord) bool {
+	if lr.Body().Type() != pdata.AttributeValueTypeString {
+		return true
+	}
+
+	err := lpp.parseLogRecord(lr, lr.Body().StringVal())
+	if err == nil {
+		return true
+	}
+	switch lpp.cfg.OnError {
+	case OnErrorDrop:
+		return false
+	case OnErrorAnnotate:
+		lr.Attributes().UpsertString(errorAttribute, err.Error())
+	}
+	return true
+}
+
+// parseLogRecord parses the body, promotes the configured fields and adds the other fields to the attributes.
+// The log record is only modified if there is no error.
+func (lpp *logParserProcessor) parseLogRecord(lr pdata.LogRecord, body string) error {
+	fields := pdata.NewAttributeMap()
+	if err := lpp.parse(body, fields); err != nil {
+		return err
+	}
+
+	var setters []func()
+	if value, ok := promotedField(fields, lpp.cfg.TimestampField); ok {
+		ts, err := parseTimestamp(value, lpp.cfg.TimestampLayout)
+		if err != nil {
+			return err
+		}
+		setters = append(setters, func() { lr.SetTimestamp(ts) })
+	}
+	if value, ok := promotedField(fields, lpp.cfg.SeverityField); ok {
+		text, number, err := parseSeverity(value)
+		if err != nil {
+			return err
+		}
+		setters = append(setters, func() {
+			if text != "" {
+				lr.SetSeverityText(text)
+			}
+			lr.SetSeverityNumber(number)
+		})
+	}
+	if value, ok := promotedField(fields, lpp.cfg.TraceIDField); ok {
+		traceID, err := parseTraceID(value)
+		if err != nil {
+			return err
+		}
+		setters = append(setters, func() { lr.SetTraceID(traceID) })
+	}
+	if value, ok := promotedField(fields, lpp.cfg.SpanIDField); ok {
+		spanID, err := parseSpanID(value)
+		if err != nil {
+			return err
+		}
+		setters = append(setters, func() { lr.SetSpanID(spanID) })
+	}
+
+	for _, set := range setters {
+		set()
+	}
+	for _, field := range []string{lpp.cfg.TimestampField, lpp.cfg.SeverityField, lpp.cfg.TraceIDField, lpp.cfg.SpanIDField} {
+		if field != "" {
+			fields.Delete(field)
+		}
+	}
+	attrs := lr.Attributes()
+	fields.Range(func(k string, v pdata.AttributeValue) bool {
+		attrs.Upsert(k, v)
+		return true
+	})
+	return nil
+}
+
+// promotedField returns the value of the field promoted to a field of the log record, if configured and parsed.
+func promotedField(fields pdata.AttributeMap, name string) (pdata.AttributeValue, bool) {
+	if name == "" {
+		return pdata.AttributeValue{}, false
+	}
+	return fields.Get(name)
+}
@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+func newTestProcessor(t *testing.T, modify func(cfg *Config)) *logParserProcessor {
+	cfg := createDefaultConfig().(*Config)
+	modify(cfg)
+	require.NoError(t, cfg.Validate())
+	lpp, err := newLogParserProcessor(cfg)
+	require.NoError(t, err)
+	return lpp
+}
+
+func newTestLogs(bodies ...string) pdata.Logs {
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for _, body := range bodies {
+		lr := logs.AppendEmpty()
+		lr.Body().SetStringVal(body)
+		lr.Attributes().InsertString("source", "kafka")
+	}
+	return ld
+}
+
+func TestProcessLogs_PromotedFields(t *testing.T) {
+	lpp := newTestProcessor(t, func(cfg *Config) {
+		cfg.TimestampField = "time"
+		cfg.SeverityField = "level"
+		cfg.TraceIDField = "trace_id"
+		cfg.SpanIDField = "span_id"
+	})
+
+	body := `{"time":"2021-06-01T12:00:00.123Z","level":"WARN","trace_id":"0102030405060708090a0b0c0d0e0f10","span_id":"0102030405060708","msg":"slow request","source":"app"}`
+	ld, err := lpp.ProcessLogs(context.Background(), newTestLogs(body))
+	require.NoError(t, err)
+
+	lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.TimestampFromTime(time.Date(2021, 6, 1, 12, 0, 0, 123000000, time.UTC)), lr.Timestamp())
+	assert.Equal(t, "WARN", lr.SeverityText())
+	assert.Equal(t, pdata.SeverityNumberWARN, lr.SeverityNumber())
+	assert.Equal(t, pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), lr.TraceID())
+	assert.Equal(t, pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}), lr.SpanID())
+	// The body is kept, the parsed fields override the existing attributes.
+	assert.Equal(t, body, lr.Body().StringVal())
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"msg":    pdata.NewAttributeValueString("slow request"),
+		"source": pdata.NewAttributeValueString("app"),
+	}).Sort(), lr.Attributes().Sort())
+}
+
+func TestProcessLogs_UnixTimestamps(t *testing.T) {
+	tests := []struct {
+		layout string
+		body   string
+	}{
+		{layout: LayoutUnix, body: `{"ts":1622548800.5}`},
+		{layout: LayoutUnixMs, body: `{"ts":1622548800500}`},
+		{layout: LayoutUnixUs, body: `{"ts":"1622548800500000"}`},
+		{layout: LayoutUnixNs, body: `{"ts":"1622548800500000000"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			lpp := newTestProcessor(t, func(cfg *Config) {
+				cfg.TimestampField = "ts"
+				cfg.TimestampLayout = tt.layout
+			})
+			ld, err := lpp.ProcessLogs(context.Background(), newTestLogs(tt.body))
+			require.NoError(t, err)
+			lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+			assert.Equal(t, pdata.Timestamp(1622548800500000000), lr.Timestamp())
+		})
+	}
+}
+
+func TestProcessLogs_Severity(t *testing.T) {
+	lpp := newTestProcessor(t, func(cfg *Config) {
+		cfg.Format = Logfmt
+		cfg.SeverityField = "level"
+	})
+	ld, err := lpp.ProcessLogs(context.Background(), newTestLogs("level=error", "level=notice"))
+	require.NoError(t, err)
+
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	assert.Equal(t, "error", logs.At(0).SeverityText())
+	assert.Equal(t, pdata.SeverityNumberERROR, logs.At(0).SeverityNumber())
+	// The unknown severities only set the text.
+	assert.Equal(t, "notice", logs.At(1).SeverityText())
+	assert.Equal(t, pdata.SeverityNumberUNDEFINED, logs.At(1).SeverityNumber())
+
+	lpp = newTestProcessor(t, func(cfg *Config) { cfg.SeverityField = "severity" })
+	ld, err = lpp.ProcessLogs(context.Background(), newTestLogs(`{"severity":17}`))
+	require.NoError(t, err)
+	lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, "", lr.SeverityText())
+	assert.Equal(t, pdata.SeverityNumberERROR, lr.SeverityNumber())
+}
+
+func TestProcessLogs_OnError(t *testing.T) {
+	bodies := []string{
+		"GET /health",
+		"2021-06-01T12:00:00Z INFO started",
+		"yesterday INFO started",
+	}
+
+	tests := []struct {
+		onError OnError
+		want    []map[string]pdata.AttributeValue
+	}{
+		{
+			onError: OnErrorKeep,
+			want: []map[string]pdata.AttributeValue{
+				{"source": pdata.NewAttributeValueString("kafka")},
+				{"source": pdata.NewAttributeValueString("kafka"), "message": pdata.NewAttributeValueString("started")},
+				{"source": pdata.NewAttributeValueString("kafka")},
+			},
+		},
+		{
+			onError: OnErrorAnnotate,
+			want: []map[string]pdata.AttributeValue{
+				{
+					"source":          pdata.NewAttributeValueString("kafka"),
+					"logparser.error": pdata.NewAttributeValueString("body does not match the pattern"),
+				},
+				{"source": pdata.NewAttributeValueString("kafka"), "message": pdata.NewAttributeValueString("started")},
+				{
+					"source":          pdata.NewAttributeValueString("kafka"),
+					"logparser.error": pdata.NewAttributeValueString(`invalid timestamp: parsing time "yesterday" as "2006-01-02T15:04:05.999999999Z07:00": cannot parse "yesterday" as "2006"`),
+				},
+			},
+		},
+		{
+			onError: OnErrorDrop,
+			want: []map[string]pdata.AttributeValue{
+				{"source": pdata.NewAttributeValueString("kafka"), "message": pdata.NewAttributeValueString("started")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.onError), func(t *testing.T) {
+			lpp := newTestProcessor(t, func(cfg *Config) {
+				cfg.Format = Regex
+				cfg.Pattern = `^(?P<time>\S+) (?P<level>[A-Z]+) (?P<message>.*)$`
+				cfg.TimestampField = "time"
+				cfg.SeverityField = "level"
+				cfg.OnError = tt.onError
+			})
+			ld, err := lpp.ProcessLogs(context.Background(), newTestLogs(bodies...))
+			require.NoError(t, err)
+
+			logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+			require.Equal(t, len(tt.want), logs.Len())
+			for i, want := range tt.want {
+				assert.Equal(t, pdata.NewAttributeMap().InitFromMap(want).Sort(), logs.At(i).Attributes().Sort())
+			}
+		})
+	}
+}
+
+func TestProcessLogs_PromotionErrors(t *testing.T) {
+	lpp := newTestProcessor(t, func(cfg *Config) {
+		cfg.TraceIDField = "trace_id"
+		cfg.SpanIDField = "span_id"
+		cfg.SeverityField = "level"
+		cfg.TimestampField = "time"
+		cfg.OnError = OnErrorAnnotate
+	})
+
+	tests := []struct {
+		body string
+		err  string
+	}{
+		{body: `{"trace_id":"0102"}`, err: `invalid trace ID: "0102" must have 32 hex characters`},
+		{body: `{"trace_id":"00000000000000000000000000000000"}`, err: `invalid trace ID: "00000000000000000000000000000000" is all zeros`},
+		{body: `{"span_id":"zz02030405060708"}`, err: "invalid span ID: encoding/hex: invalid byte: U+007A 'z'"},
+		{body: `{"span_id":1}`, err: "invalid span ID: not a string"},
+		{body: `{"level":99}`, err: "invalid severity number 99"},
+		{body: `{"level":true}`, err: "severity is not a string or an integer"},
+		{body: `{"time":1}`, err: "timestamp is not a string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			ld, err := lpp.ProcessLogs(context.Background(), newTestLogs(tt.body))
+			require.NoError(t, err)
+			lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+			// The log record is not modified, except for the error.
+			assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+				"source":          pdata.NewAttributeValueString("kafka"),
+				"logparser.error": pdata.NewAttributeValueString(tt.err),
+			}).Sort(), lr.Attributes().Sort())
+			assert.Equal(t, pdata.InvalidTraceID(), lr.TraceID())
+			assert.Equal(t, pdata.SeverityNumberUNDEFINED, lr.SeverityNumber())
+		})
+	}
+}
+
+func TestProcessLogs_NotStringBody(t *testing.T) {
+	lpp := newTestProcessor(t, func(cfg *Config) { cfg.OnError = OnErrorDrop })
+
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.Body().SetIntVal(1)
+
+	ld, err := lpp.ProcessLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ld.LogRecordCount())
+}
+
+func TestProcessLogs_AllDropped(t *testing.T) {
+	lpp := newTestProcessor(t, func(cfg *Config) { cfg.OnError = OnErrorDrop })
+
+	_, err := lpp.ProcessLogs(context.Background(), newTestLogs("not json"))
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+}
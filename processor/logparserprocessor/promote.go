@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logparserprocessor
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var severityNumbers = map[string]pdata.SeverityNumber{
+	"trace":    pdata.SeverityNumberTRACE,
+	"debug":    pdata.SeverityNumberDEBUG,
+	"info":     pdata.SeverityNumberINFO,
+	"warn":     pdata.SeverityNumberWARN,
+	"warning":  pdata.SeverityNumberWARN,
+	"error":    pdata.SeverityNumberERROR,
+	"fatal":    pdata.SeverityNumberFATAL,
+	"critical": pdata.SeverityNumberFATAL,
+	"panic":    pdata.SeverityNumberFATAL,
+}
+
+// parseTimestamp parses a timestamp field with the given layout.
+func parseTimestamp(value pdata.AttributeValue, layout string) (pdata.Timestamp, error) {
+	var unit float64
+	switch layout {
+	case LayoutUnix:
+		unit = float64(time.Second)
+	case LayoutUnixMs:
+		unit = float64(time.Millisecond)
+	case LayoutUnixUs:
+		unit = float64(time.Microsecond)
+	case LayoutUnixNs:
+		unit = float64(time.Nanosecond)
+	default:
+		if value.Type() != pdata.AttributeValueTypeString {
+			return 0, errors.New("timestamp is not a string")
+		}
+		t, err := time.Parse(layout, value.StringVal())
+		if err != nil {
+			return 0, fmt.Errorf("invalid timestamp: %w", err)
+		}
+		return pdata.TimestampFromTime(t), nil
+	}
+
+	switch value.Type() {
+	case pdata.AttributeValueTypeInt:
+		return pdata.Timestamp(value.IntVal() * int64(unit)), nil
+	case pdata.AttributeValueTypeDouble:
+		return pdata.Timestamp(math.Round(value.DoubleVal() * unit)), nil
+	case pdata.AttributeValueTypeString:
+		// Parse the integers separately to keep the precision of the nanoseconds.
+		if i, err := strconv.ParseInt(value.StringVal(), 10, 64); err == nil {
+			return pdata.Timestamp(i * int64(unit)), nil
+		}
+		f, err := strconv.ParseFloat(value.StringVal(), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timestamp: %w", err)
+		}
+		return pdata.Timestamp(math.Round(f * unit)), nil
+	}
+	return 0, errors.New("timestamp is not a number")
+}
+
+// parseSeverity parses a severity field, either a name such as "info" or a severity number.
+// The number of an unknown name is undefined.
+func parseSeverity(value pdata.AttributeValue) (string, pdata.SeverityNumber, error) {
+	switch value.Type() {
+	case pdata.AttributeValueTypeString:
+		return value.StringVal(), severityNumbers[strings.ToLower(value.StringVal())], nil
+	case pdata.AttributeValueTypeInt:
+		n := value.IntVal()
+		if n < int64(pdata.SeverityNumberTRACE) || n > int64(pdata.SeverityNumberFATAL4) {
+			return "", 0, fmt.Errorf("invalid severity number %d", n)
+		}
+		return "", pdata.SeverityNumber(n), nil
+	}
+	return "", 0, errors.New("severity is not a string or an integer")
+}
+
+// parseTraceID parses a trace ID field of 32 hex characters.
+func parseTraceID(value pdata.AttributeValue) (pdata.TraceID, error) {
+	var id [16]byte
+	if err := decodeID(value, id[:]); err != nil {
+		return pdata.InvalidTraceID(), fmt.Errorf("invalid trace ID: %w", err)
+	}
+	return pdata.NewTraceID(id), nil
+}
+
+// parseSpanID parses a span ID field of 16 hex characters.
+func parseSpanID(value pdata.AttributeValue) (pdata.SpanID, error) {
+	var id [8]byte
+	if err := decodeID(value, id[:]); err != nil {
+		return pdata.InvalidSpanID(), fmt.Errorf("invalid span ID: %w", err)
+	}
+	return pdata.NewSpanID(id), nil
+}
+
+func decodeID(value pdata.AttributeValue, id []byte) error {
+	if value.Type() != pdata.AttributeValueTypeString {
+		return errors.New("not a string")
+	}
+	s := value.StringVal()
+	if len(s) != hex.EncodedLen(len(id)) {
+		return fmt.Errorf("%q must have %d hex characters", s, hex.EncodedLen(len(id)))
+	}
+	if _, err := hex.Decode(id, []byte(s)); err != nil {
+		return err
+	}
+	for _, b := range id {
+		if b != 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is all zeros", s)
+}
@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// forEachLabels calls f with the labels of every data point of the metric.
+func forEachLabels(metric pdata.Metric, f func(labels pdata.StringMap)) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		dps := metric.IntGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		dps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntSum:
+		dps := metric.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := metric.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		dps := metric.IntHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	}
+}
+
+// aggregateDataPoints removes the labels that must not be kept, and aggregates the data points left with the same
+// labels. The values of gauges and sums are aggregated with the aggregation type, histograms and summaries are
+// always summed.
+func aggregateDataPoints(metric pdata.Metric, keep func(label string) bool, aggregationType AggregationType) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		aggregateIntDataPoints(metric.IntGauge().DataPoints(), keep, aggregationType)
+	case pdata.MetricDataTypeDoubleGauge:
+		aggregateDoubleDataPoints(metric.DoubleGauge().DataPoints(), keep, aggregationType)
+	case pdata.MetricDataTypeIntSum:
+		aggregateIntDataPoints(metric.IntSum().DataPoints(), keep, aggregationType)
+	case pdata.MetricDataTypeDoubleSum:
+		aggregateDoubleDataPoints(metric.DoubleSum().DataPoints(), keep, aggregationType)
+	case pdata.MetricDataTypeIntHistogram:
+		aggregateIntHistogramDataPoints(metric.IntHistogram().DataPoints(), keep)
+	case pdata.MetricDataTypeHistogram:
+		aggregateHistogramDataPoints(metric.Histogram().DataPoints(), keep)
+	case pdata.MetricDataTypeSummary:
+		aggregateSummaryDataPoints(metric.Summary().DataPoints(), keep)
+	}
+}
+
+// groupDataPoints removes the labels that must not be kept, and returns the indexes of the data points grouped by
+// labels, in order of first appearance.
+func groupDataPoints(n int, labelsAt func(i int) pdata.StringMap, keep func(label string) bool) [][]int {
+	var groups [][]int
+	groupIndexes := make(map[string]int)
+	for i := 0; i < n; i++ {
+		labels := labelsAt(i)
+		var removed []string
+		pairs := make([]string, 0, labels.Len())
+		labels.Range(func(k, v string) bool {
+			if keep(k) {
+				pairs = append(pairs, k+"\x00"+v)
+			} else {
+				removed = append(removed, k)
+			}
+			return true
+		})
+		for _, k := range removed {
+			labels.Delete(k)
+		}
+		sort.Strings(pairs)
+		key := strings.Join(pairs, "\x00")
+
+		if g, ok := groupIndexes[key]; ok {
+			groups[g] = append(groups[g], i)
+			continue
+		}
+		groupIndexes[key] = len(groups)
+		groups = append(groups, []int{i})
+	}
+	return groups
+}
+
+// mergeTimestamps returns the earliest start timestamp and the latest timestamp of the data points.
+func mergeTimestamps(start, timestamp, otherStart, otherTimestamp pdata.Timestamp) (pdata.Timestamp, pdata.Timestamp) {
+	if otherStart != 0 && (start == 0 || otherStart < start) {
+		start = otherStart
+	}
+	if otherTimestamp > timestamp {
+		timestamp = otherTimestamp
+	}
+	return start, timestamp
+}
+
+func aggregateIntDataPoints(dps pdata.IntDataPointSlice, keep func(string) bool, aggregationType AggregationType) {
+	groups := groupDataPoints(dps.Len(), func(i int) pdata.StringMap { return dps.At(i).LabelsMap() }, keep)
+	if len(groups) == dps.Len() {
+		return
+	}
+
+	aggregated := pdata.NewIntDataPointSlice()
+	for _, group := range groups {
+		dp := aggregated.AppendEmpty()
+		dps.At(group[0]).CopyTo(dp)
+		values := make([]int64, 0, len(group))
+		start, timestamp := dp.StartTimestamp(), dp.Timestamp()
+		for _, i := range group {
+			values = append(values, dps.At(i).Value())
+			start, timestamp = mergeTimestamps(start, timestamp, dps.At(i).StartTimestamp(), dps.At(i).Timestamp())
+		}
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(timestamp)
+		dp.SetValue(aggregateInts(values, aggregationType))
+	}
+	aggregated.CopyTo(dps)
+}
+
+func aggregateDoubleDataPoints(dps pdata.DoubleDataPointSlice, keep func(string) bool, aggregationType AggregationType) {
+	groups := groupDataPoints(dps.Len(), func(i int) pdata.StringMap { return dps.At(i).LabelsMap() }, keep)
+	if len(groups) == dps.Len() {
+		return
+	}
+
+	aggregated := pdata.NewDoubleDataPointSlice()
+	for _, group := range groups {
+		dp := aggregated.AppendEmpty()
+		dps.At(group[0]).CopyTo(dp)
+		values := make([]float64, 0, len(group))
+		start, timestamp := dp.StartTimestamp(), dp.Timestamp()
+		for _, i := range group {
+			values = append(values, dps.At(i).Value())
+			start, timestamp = mergeTimestamps(start, timestamp, dps.At(i).StartTimestamp(), dps.At(i).Timestamp())
+		}
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(timestamp)
+		dp.SetValue(aggregateDoubles(values, aggregationType))
+	}
+	aggregated.CopyTo(dps)
+}
+
+func aggregateIntHistogramDataPoints(dps pdata.IntHistogramDataPointSlice, keep func(string) bool) {
+	groups := groupDataPoints(dps.Len(), func(i int) pdata.StringMap { return dps.At(i).LabelsMap() }, keep)
+	if len(groups) == dps.Len() {
+		return
+	}
+
+	aggregated := pdata.NewIntHistogramDataPointSlice()
+	for _, group := range groups {
+		dp := aggregated.AppendEmpty()
+		dps.At(group[0]).CopyTo(dp)
+		bucketCounts := append([]uint64(nil), dp.BucketCounts()...)
+		for _, i := range group[1:] {
+			other := dps.At(i)
+			start, timestamp := mergeTimestamps(dp.StartTimestamp(), dp.Timestamp(), other.StartTimestamp(), other.Timestamp())
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(timestamp)
+			dp.SetCount(dp.Count() + other.Count())
+			dp.SetSum(dp.Sum() + other.Sum())
+			bucketCounts = addBucketCounts(bucketCounts, dp.ExplicitBounds(), other.BucketCounts(), other.ExplicitBounds())
+		}
+		dp.SetBucketCounts(bucketCounts)
+		if bucketCounts == nil {
+			dp.SetExplicitBounds(nil)
+		}
+	}
+	aggregated.CopyTo(dps)
+}
+
+func aggregateHistogramDataPoints(dps pdata.HistogramDataPointSlice, keep func(string) bool) {
+	groups := groupDataPoints(dps.Len(), func(i int) pdata.StringMap { return dps.At(i).LabelsMap() }, keep)
+	if len(groups) == dps.Len() {
+		return
+	}
+
+	aggregated := pdata.NewHistogramDataPointSlice()
+	for _, group := range groups {
+		dp := aggregated.AppendEmpty()
+		dps.At(group[0]).CopyTo(dp)
+		bucketCounts := append([]uint64(nil), dp.BucketCounts()...)
+		for _, i := range group[1:] {
+			other := dps.At(i)
+			start, timestamp := mergeTimestamps(dp.StartTimestamp(), dp.Timestamp(), other.StartTimestamp(), other.Timestamp())
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(timestamp)
+			dp.SetCount(dp.Count() + other.Count())
+			dp.SetSum(dp.Sum() + other.Sum())
+			bucketCounts = addBucketCounts(bucketCounts, dp.ExplicitBounds(), other.BucketCounts(), other.ExplicitBounds())
+		}
+		dp.SetBucketCounts(bucketCounts)
+		if bucketCounts == nil {
+			dp.SetExplicitBounds(nil)
+		}
+	}
+	aggregated.CopyTo(dps)
+}
+
+func aggregateSummaryDataPoints(dps pdata.SummaryDataPointSlice, keep func(string) bool) {
+	groups := groupDataPoints(dps.Len(), func(i int) pdata.StringMap { return dps.At(i).LabelsMap() }, keep)
+	if len(groups) == dps.Len() {
+		return
+	}
+
+	aggregated := pdata.NewSummaryDataPointSlice()
+	for _, group := range groups {
+		dp := aggregated.AppendEmpty()
+		dps.At(group[0]).CopyTo(dp)
+		if len(group) == 1 {
+			continue
+		}
+		for _, i := range group[1:] {
+			other := dps.At(i)
+			start, timestamp := mergeTimestamps(dp.StartTimestamp(), dp.Timestamp(), other.StartTimestamp(), other.Timestamp())
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(timestamp)
+			dp.SetCount(dp.Count() + other.Count())
+			dp.SetSum(dp.Sum() + other.Sum())
+		}
+		// The quantiles of different summaries cannot be combined.
+		dp.QuantileValues().Resize(0)
+	}
+	aggregated.CopyTo(dps)
+}
+
+// addBucketCounts returns the sum of the bucket counts of two histograms, or nil if they have different bounds.
+func addBucketCounts(bucketCounts []uint64, bounds []float64, otherBucketCounts []uint64, otherBounds []float64) []uint64 {
+	if bucketCounts == nil || len(bucketCounts) != len(otherBucketCounts) || len(bounds) != len(otherBounds) {
+		return nil
+	}
+	for i := range bounds {
+		if bounds[i] != otherBounds[i] {
+			return nil
+		}
+	}
+	for i := range bucketCounts {
+		bucketCounts[i] += otherBucketCounts[i]
+	}
+	return bucketCounts
+}
+
+// aggregateInts returns the aggregation of the values, that are not empty. The mean is truncated.
+func aggregateInts(values []int64, aggregationType AggregationType) int64 {
+	result := values[0]
+	for _, v := range values[1:] {
+		switch aggregationType {
+		case Max:
+			if v > result {
+				result = v
+			}
+		case Min:
+			if v < result {
+				result = v
+			}
+		default:
+			result += v
+		}
+	}
+	if aggregationType == Mean {
+		result /= int64(len(values))
+	}
+	return result
+}
+
+// aggregateDoubles returns the aggregation of the values, that are not empty.
+func aggregateDoubles(values []float64, aggregationType AggregationType) float64 {
+	result := values[0]
+	for _, v := range values[1:] {
+		switch aggregationType {
+		case Max:
+			result = math.Max(result, v)
+		case Min:
+			result = math.Min(result, v)
+		default:
+			result += v
+		}
+	}
+	if aggregationType == Mean {
+		result /= float64(len(values))
+	}
+	return result
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+// Config defines configuration for the metrics transform processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Transforms are applied in order to the metrics of each resource and instrumentation library.
+	Transforms []Transform `mapstructure:"transforms"`
+}
+
+// TransformAction is what a transform does with the matching metrics.
+type TransformAction string
+
+const (
+	// Update modifies the matching metrics.
+	Update TransformAction = "update"
+	// Insert adds a modified copy of each matching metric.
+	Insert TransformAction = "insert"
+	// Combine replaces the matching metrics by a single metric with all their data points.
+	Combine TransformAction = "combine"
+)
+
+// Transform describes the transformation of the metrics matching Include.
+type Transform struct {
+	// Include is the name of the metrics to transform, or a regular expression if MatchType is regexp.
+	Include string `mapstructure:"include"`
+	// MatchType is strict or regexp, strict by default.
+	MatchType filterset.MatchType `mapstructure:"match_type"`
+
+	// Action is update, insert or combine.
+	Action TransformAction `mapstructure:"action"`
+	// NewName is the name of the transformed metric, required for the insert and combine actions.
+	// When metrics are combined, the named subexpressions of Include are added as labels to their data points.
+	NewName string `mapstructure:"new_name"`
+
+	// Operations are applied in order to the data points of the transformed metric.
+	Operations []Operation `mapstructure:"operations"`
+}
+
+// OperationAction is what an operation does with the data points.
+type OperationAction string
+
+const (
+	// AddLabel adds the NewLabel with the NewValue to the data points that do not have it.
+	AddLabel OperationAction = "add_label"
+	// UpdateLabel renames the Label to NewLabel, and its values according to ValueActions.
+	UpdateLabel OperationAction = "update_label"
+	// DeleteLabel removes the Label, the data points left with the same labels are aggregated.
+	DeleteLabel OperationAction = "delete_label"
+	// AggregateLabels removes the labels not in LabelSet, the data points left with the same labels are aggregated.
+	AggregateLabels OperationAction = "aggregate_labels"
+)
+
+// AggregationType is how the values of the data points with the same labels are aggregated.
+type AggregationType string
+
+const (
+	// Sum is the sum of the values.
+	Sum AggregationType = "sum"
+	// Mean is the mean of the values.
+	Mean AggregationType = "mean"
+	// Max is the maximum of the values.
+	Max AggregationType = "max"
+	// Min is the minimum of the values.
+	Min AggregationType = "min"
+)
+
+// Operation describes a change of the labels of the data points.
+type Operation struct {
+	// Action is add_label, update_label, delete_label or aggregate_labels.
+	Action OperationAction `mapstructure:"action"`
+	// Label is the label to update or delete.
+	Label string `mapstructure:"label"`
+	// NewLabel is the label to add, or the new name of the updated label.
+	NewLabel string `mapstructure:"new_label"`
+	// NewValue is the value of the added label.
+	NewValue string `mapstructure:"new_value"`
+	// ValueActions rename the values of the updated label.
+	ValueActions []ValueAction `mapstructure:"value_actions"`
+	// LabelSet are the labels kept by aggregate_labels.
+	LabelSet []string `mapstructure:"label_set"`
+	// AggregationType is sum, mean, max or min. Required for aggregate_labels, sum by default for delete_label.
+	AggregationType AggregationType `mapstructure:"aggregation_type"`
+}
+
+// ValueAction renames a label value.
+type ValueAction struct {
+	Value    string `mapstructure:"value"`
+	NewValue string `mapstructure:"new_value"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Transforms) == 0 {
+		return errors.New("must specify at least one transform")
+	}
+	for i, t := range cfg.Transforms {
+		if err := t.validate(); err != nil {
+			return fmt.Errorf("transforms[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (t *Transform) validate() error {
+	if t.Include == "" {
+		return errors.New("include must be specified")
+	}
+	switch t.MatchType {
+	case "", filterset.Strict:
+		if t.Action == Combine {
+			return errors.New("combine requires the regexp match_type")
+		}
+	case filterset.Regexp:
+		if _, err := regexp.Compile(t.Include); err != nil {
+			return fmt.Errorf("invalid include regexp: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported match_type %q, must be strict or regexp", t.MatchType)
+	}
+
+	switch t.Action {
+	case Update:
+	case Insert, Combine:
+		if t.NewName == "" {
+			return fmt.Errorf("new_name must be specified for the %s action", t.Action)
+		}
+	default:
+		return fmt.Errorf("unsupported action %q, must be update, insert or combine", t.Action)
+	}
+
+	for i, op := range t.Operations {
+		if err := op.validate(); err != nil {
+			return fmt.Errorf("operations[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (op *Operation) validate() error {
+	switch op.Action {
+	case AddLabel:
+		if op.NewLabel == "" || op.NewValue == "" {
+			return errors.New("add_label requires new_label and new_value")
+		}
+	case UpdateLabel:
+		if op.Label == "" {
+			return errors.New("update_label requires label")
+		}
+		if op.NewLabel == "" && len(op.ValueActions) == 0 {
+			return errors.New("update_label requires new_label or value_actions")
+		}
+	case DeleteLabel:
+		if op.Label == "" {
+			return errors.New("delete_label requires label")
+		}
+		if op.AggregationType != "" {
+			return validateAggregationType(op.AggregationType)
+		}
+	case AggregateLabels:
+		return validateAggregationType(op.AggregationType)
+	default:
+		return fmt.Errorf("unsupported action %q, must be add_label, update_label, delete_label or aggregate_labels", op.Action)
+	}
+	return nil
+}
+
+func validateAggregationType(aggregationType AggregationType) error {
+	switch aggregationType {
+	case Sum, Mean, Max, Min:
+		return nil
+	}
+	return fmt.Errorf("unsupported aggregation_type %q, must be sum, mean, max or min", aggregationType)
+}
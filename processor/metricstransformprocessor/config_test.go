@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+			Transforms: []Transform{
+				{
+					Include: "system.cpu.usage",
+					Action:  Update,
+					NewName: "system.cpu.usage_time",
+				},
+				{
+					Include: "system.memory.usage",
+					Action:  Insert,
+					NewName: "system.memory.usage_copy",
+					Operations: []Operation{
+						{Action: AddLabel, NewLabel: "copied", NewValue: "true"},
+					},
+				},
+				{
+					Include:   `^system\.disk\.(?P<state>read|write)$`,
+					MatchType: filterset.Regexp,
+					Action:    Combine,
+					NewName:   "system.disk.operations",
+					Operations: []Operation{
+						{
+							Action:   UpdateLabel,
+							Label:    "state",
+							NewLabel: "direction",
+							ValueActions: []ValueAction{
+								{Value: "read", NewValue: "in"},
+								{Value: "write", NewValue: "out"},
+							},
+						},
+						{Action: DeleteLabel, Label: "device"},
+						{Action: AggregateLabels, LabelSet: []string{"direction"}, AggregationType: Max},
+					},
+				},
+			},
+		}, cfg.Processors[config.NewID(typeStr)])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(t *Transform)
+		err    string
+	}{
+		{
+			name:   "no include",
+			modify: func(t *Transform) { t.Include = "" },
+			err:    "transforms[0]: include must be specified",
+		},
+		{
+			name:   "match type",
+			modify: func(t *Transform) { t.MatchType = "expr" },
+			err:    `transforms[0]: unsupported match_type "expr", must be strict or regexp`,
+		},
+		{
+			name: "invalid regexp",
+			modify: func(t *Transform) {
+				t.MatchType = filterset.Regexp
+				t.Include = "("
+			},
+			err: "transforms[0]: invalid include regexp: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:   "action",
+			modify: func(t *Transform) { t.Action = "delete" },
+			err:    `transforms[0]: unsupported action "delete", must be update, insert or combine`,
+		},
+		{
+			name:   "insert without new name",
+			modify: func(t *Transform) { t.Action = Insert },
+			err:    "transforms[0]: new_name must be specified for the insert action",
+		},
+		{
+			name: "strict combine",
+			modify: func(t *Transform) {
+				t.Action = Combine
+				t.NewName = "combined"
+			},
+			err: "transforms[0]: combine requires the regexp match_type",
+		},
+		{
+			name:   "operation action",
+			modify: func(t *Transform) { t.Operations = []Operation{{Action: "toggle"}} },
+			err:    `transforms[0]: operations[0]: unsupported action "toggle", must be add_label, update_label, delete_label or aggregate_labels`,
+		},
+		{
+			name:   "add label",
+			modify: func(t *Transform) { t.Operations = []Operation{{Action: AddLabel, NewLabel: "host"}} },
+			err:    "transforms[0]: operations[0]: add_label requires new_label and new_value",
+		},
+		{
+			name:   "update label",
+			modify: func(t *Transform) { t.Operations = []Operation{{Action: UpdateLabel, Label: "host"}} },
+			err:    "transforms[0]: operations[0]: update_label requires new_label or value_actions",
+		},
+		{
+			name:   "delete label",
+			modify: func(t *Transform) { t.Operations = []Operation{{Action: DeleteLabel}} },
+			err:    "transforms[0]: operations[0]: delete_label requires label",
+		},
+		{
+			name:   "aggregation type",
+			modify: func(t *Transform) { t.Operations = []Operation{{Action: AggregateLabels, AggregationType: "median"}} },
+			err:    `transforms[0]: operations[0]: unsupported aggregation_type "median", must be sum, mean, max or min`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Transforms = []Transform{{Include: "system.cpu.usage", Action: Update}}
+			tt.modify(&cfg.Transforms[0])
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "must specify at least one transform")
+}
@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+// transform is a Transform ready to be applied.
+type transform struct {
+	include    string
+	regexp     *regexp.Regexp
+	action     TransformAction
+	newName    string
+	operations []operation
+}
+
+// operation is an Operation ready to be applied.
+type operation struct {
+	Operation
+	valueActions map[string]string
+	labelSet     map[string]bool
+}
+
+type metricsTransformProcessor struct {
+	logger     *zap.Logger
+	transforms []transform
+}
+
+func newMetricsTransformProcessor(logger *zap.Logger, cfg *Config) *metricsTransformProcessor {
+	transforms := make([]transform, 0, len(cfg.Transforms))
+	for _, t := range cfg.Transforms {
+		compiled := transform{
+			include: t.Include,
+			action:  t.Action,
+			newName: t.NewName,
+		}
+		if t.MatchType == filterset.Regexp {
+			// Already validated.
+			compiled.regexp = regexp.MustCompile(t.Include)
+		}
+		for _, op := range t.Operations {
+			compiled.operations = append(compiled.operations, newOperation(op))
+		}
+		transforms = append(transforms, compiled)
+	}
+	return &metricsTransformProcessor{
+		logger:     logger,
+		transforms: transforms,
+	}
+}
+
+func newOperation(op Operation) operation {
+	compiled := operation{Operation: op}
+	if op.Action == DeleteLabel && op.AggregationType == "" {
+		compiled.AggregationType = Sum
+	}
+	if len(op.ValueActions) > 0 {
+		compiled.valueActions = make(map[string]string, len(op.ValueActions))
+		for _, va := range op.ValueActions {
+			compiled.valueActions[va.Value] = va.NewValue
+		}
+	}
+	compiled.labelSet = make(map[string]bool, len(op.LabelSet))
+	for _, label := range op.LabelSet {
+		compiled.labelSet[label] = true
+	}
+	return compiled
+}
+
+// match returns whether the metric name matches the transform, and the submatches of the regexp.
+func (t *transform) match(name string) ([]string, bool) {
+	if t.regexp == nil {
+		return nil, name == t.include
+	}
+	submatches := t.regexp.FindStringSubmatch(name)
+	return submatches, submatches != nil
+}
+
+// ProcessMetrics applies the transforms to the metrics of each resource and instrumentation library.
+func (p *metricsTransformProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := range p.transforms {
+				p.applyTransform(&p.transforms[k], metrics)
+			}
+		}
+	}
+	return md, nil
+}
+
+func (p *metricsTransformProcessor) applyTransform(t *transform, metrics pdata.MetricSlice) {
+	switch t.action {
+	case Update:
+		for i := 0; i < metrics.Len(); i++ {
+			metric := metrics.At(i)
+			if _, ok := t.match(metric.Name()); !ok {
+				continue
+			}
+			if t.newName != "" {
+				metric.SetName(t.newName)
+			}
+			applyOperations(t.operations, metric)
+		}
+	case Insert:
+		// The inserted metrics are not transformed again.
+		n := metrics.Len()
+		for i := 0; i < n; i++ {
+			metric := metrics.At(i)
+			if _, ok := t.match(metric.Name()); !ok {
+				continue
+			}
+			inserted := metrics.AppendEmpty()
+			metric.CopyTo(inserted)
+			inserted.SetName(t.newName)
+			applyOperations(t.operations, inserted)
+		}
+	case Combine:
+		if err := combineMetrics(t, metrics); err != nil {
+			p.logger.Warn("Failed to combine the metrics.", zap.String("include", t.include), zap.Error(err))
+		}
+	}
+}
+
+// combineMetrics replaces the matching metrics by a single metric with all their data points. The named
+// subexpressions of the regexp are added as labels to the data points.
+func combineMetrics(t *transform, metrics pdata.MetricSlice) error {
+	var matching []pdata.Metric
+	var submatches [][]string
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		if sm, ok := t.match(metric.Name()); ok {
+			matching = append(matching, metric)
+			submatches = append(submatches, sm)
+		}
+	}
+	if len(matching) == 0 {
+		return nil
+	}
+	for _, metric := range matching[1:] {
+		if err := checkCombinable(matching[0], metric); err != nil {
+			return err
+		}
+	}
+
+	combined := newEmptyMetric(t.newName, matching[0])
+	names := t.regexp.SubexpNames()
+	for i, metric := range matching {
+		forEachLabels(metric, func(labels pdata.StringMap) {
+			for j, name := range names {
+				if name != "" {
+					labels.Upsert(name, submatches[i][j])
+				}
+			}
+		})
+		moveDataPoints(metric, combined)
+	}
+	metrics.RemoveIf(func(metric pdata.Metric) bool {
+		_, ok := t.match(metric.Name())
+		return ok
+	})
+	applyOperations(t.operations, combined)
+	metrics.Append(combined)
+	return nil
+}
+
+// checkCombinable returns an error if the data points of the metrics cannot be combined in a single metric.
+func checkCombinable(metric, other pdata.Metric) error {
+	if metric.DataType() != other.DataType() {
+		return fmt.Errorf("metrics %q and %q have different types %s and %s", metric.Name(), other.Name(), metric.DataType(), other.DataType())
+	}
+	same := true
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		same = metric.IntSum().AggregationTemporality() == other.IntSum().AggregationTemporality() &&
+			metric.IntSum().IsMonotonic() == other.IntSum().IsMonotonic()
+	case pdata.MetricDataTypeDoubleSum:
+		same = metric.DoubleSum().AggregationTemporality() == other.DoubleSum().AggregationTemporality() &&
+			metric.DoubleSum().IsMonotonic() == other.DoubleSum().IsMonotonic()
+	case pdata.MetricDataTypeIntHistogram:
+		same = metric.IntHistogram().AggregationTemporality() == other.IntHistogram().AggregationTemporality()
+	case pdata.MetricDataTypeHistogram:
+		same = metric.Histogram().AggregationTemporality() == other.Histogram().AggregationTemporality()
+	}
+	if !same {
+		return fmt.Errorf("metrics %q and %q have a different aggregation temporality or monotonicity", metric.Name(), other.Name())
+	}
+	return nil
+}
+
+// newEmptyMetric returns a metric without data points, with the given name and the other properties of the model.
+func newEmptyMetric(name string, model pdata.Metric) pdata.Metric {
+	metric := pdata.NewMetric()
+	metric.SetName(name)
+	metric.SetDescription(model.Description())
+	metric.SetUnit(model.Unit())
+	metric.SetDataType(model.DataType())
+	switch model.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		metric.IntSum().SetAggregationTemporality(model.IntSum().AggregationTemporality())
+		metric.IntSum().SetIsMonotonic(model.IntSum().IsMonotonic())
+	case pdata.MetricDataTypeDoubleSum:
+		metric.DoubleSum().SetAggregationTemporality(model.DoubleSum().AggregationTemporality())
+		metric.DoubleSum().SetIsMonotonic(model.DoubleSum().IsMonotonic())
+	case pdata.MetricDataTypeIntHistogram:
+		metric.IntHistogram().SetAggregationTemporality(model.IntHistogram().AggregationTemporality())
+	case pdata.MetricDataTypeHistogram:
+		metric.Histogram().SetAggregationTemporality(model.Histogram().AggregationTemporality())
+	}
+	return metric
+}
+
+// moveDataPoints moves the data points of a metric to another one of the same type.
+func moveDataPoints(from, to pdata.Metric) {
+	switch from.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		from.IntGauge().DataPoints().MoveAndAppendTo(to.IntGauge().DataPoints())
+	case pdata.MetricDataTypeDoubleGauge:
+		from.DoubleGauge().DataPoints().MoveAndAppendTo(to.DoubleGauge().DataPoints())
+	case pdata.MetricDataTypeIntSum:
+		from.IntSum().DataPoints().MoveAndAppendTo(to.IntSum().DataPoints())
+	case pdata.MetricDataTypeDoubleSum:
+		from.DoubleSum().DataPoints().MoveAndAppendTo(to.DoubleSum().DataPoints())
+	case pdata.MetricDataTypeIntHistogram:
+		from.IntHistogram().DataPoints().MoveAndAppendTo(to.IntHistogram().DataPoints())
+	case pdata.MetricDataTypeHistogram:
+		from.Histogram().DataPoints().MoveAndAppendTo(to.Histogram().DataPoints())
+	case pdata.MetricDataTypeSummary:
+		from.Summary().DataPoints().MoveAndAppendTo(to.Summary().DataPoints())
+	}
+}
+
+// applyOperations applies the operations in order to the data points of the metric.
+func applyOperations(operations []operation, metric pdata.Metric) {
+	for i := range operations {
+		op := &operations[i]
+		switch op.Action {
+		case AddLabel:
+			forEachLabels(metric, func(labels pdata.StringMap) {
+				labels.Insert(op.NewLabel, op.NewValue)
+			})
+		case UpdateLabel:
+			forEachLabels(metric, func(labels pdata.StringMap) {
+				value, ok := labels.Get(op.Label)
+				if !ok {
+					return
+				}
+				if newValue, ok := op.valueActions[value]; ok {
+					value = newValue
+				}
+				if op.NewLabel != "" {
+					labels.Delete(op.Label)
+					labels.Upsert(op.NewLabel, value)
+				} else {
+					labels.Update(op.Label, value)
+				}
+			})
+		case DeleteLabel:
+			aggregateDataPoints(metric, func(label string) bool { return label != op.Label }, op.AggregationType)
+		case AggregateLabels:
+			aggregateDataPoints(metric, func(label string) bool { return op.labelSet[label] }, op.AggregationType)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+var startTime = time.Unix(1600000000, 0)
+
+func timestamp(seconds int) pdata.Timestamp {
+	return pdata.TimestampFromTime(startTime.Add(time.Duration(seconds) * time.Second))
+}
+
+// newMetrics returns metrics with a single resource and instrumentation library.
+func newMetrics() (pdata.Metrics, pdata.MetricSlice) {
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	return md, metrics
+}
+
+func appendIntSum(metrics pdata.MetricSlice, name string, points ...map[string]string) pdata.Metric {
+	metric := metrics.AppendEmpty()
+	metric.SetName(name)
+	metric.SetUnit("1")
+	metric.SetDataType(pdata.MetricDataTypeIntSum)
+	metric.IntSum().SetIsMonotonic(true)
+	metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	for i, labels := range points {
+		dp := metric.IntSum().DataPoints().AppendEmpty()
+		dp.LabelsMap().InitFromMap(labels)
+		dp.SetStartTimestamp(timestamp(0))
+		dp.SetTimestamp(timestamp(10 + i))
+		dp.SetValue(int64(i + 1))
+	}
+	return metric
+}
+
+func labelsOf(sm pdata.StringMap) map[string]string {
+	labels := make(map[string]string, sm.Len())
+	sm.Range(func(k, v string) bool {
+		labels[k] = v
+		return true
+	})
+	return labels
+}
+
+func process(t *testing.T, md pdata.Metrics, transforms ...Transform) pdata.MetricSlice {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Transforms = transforms
+	require.NoError(t, cfg.Validate())
+	md, err := newMetricsTransformProcessor(zap.NewNop(), cfg).ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+	return md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+}
+
+func TestMetricsTransform_Update(t *testing.T) {
+	md, metrics := newMetrics()
+	appendIntSum(metrics, "system.cpu.usage", map[string]string{"cpu": "0", "state": "idle"})
+	appendIntSum(metrics, "system.memory.usage", map[string]string{"state": "free"})
+
+	metrics = process(t, md, Transform{
+		Include: "system.cpu.usage",
+		Action:  Update,
+		NewName: "system.cpu.time",
+		Operations: []Operation{
+			{Action: AddLabel, NewLabel: "host", NewValue: "web-1"},
+			// Not replaced.
+			{Action: AddLabel, NewLabel: "cpu", NewValue: "1"},
+			{Action: UpdateLabel, Label: "state", NewLabel: "mode", ValueActions: []ValueAction{{Value: "idle", NewValue: "wait"}}},
+		},
+	})
+
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "system.cpu.time", metrics.At(0).Name())
+	assert.Equal(t, map[string]string{"cpu": "0", "mode": "wait", "host": "web-1"}, labelsOf(metrics.At(0).IntSum().DataPoints().At(0).LabelsMap()))
+	assert.Equal(t, "system.memory.usage", metrics.At(1).Name())
+	assert.Equal(t, map[string]string{"state": "free"}, labelsOf(metrics.At(1).IntSum().DataPoints().At(0).LabelsMap()))
+}
+
+func TestMetricsTransform_Insert(t *testing.T) {
+	md, metrics := newMetrics()
+	appendIntSum(metrics, "http.requests", map[string]string{"method": "GET"})
+
+	metrics = process(t, md, Transform{
+		Include:   "^http\\.",
+		MatchType: filterset.Regexp,
+		Action:    Insert,
+		NewName:   "http.requests_by_host",
+		Operations: []Operation{
+			{Action: UpdateLabel, Label: "method", ValueActions: []ValueAction{{Value: "GET", NewValue: "get"}}},
+		},
+	})
+
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "http.requests", metrics.At(0).Name())
+	assert.Equal(t, "GET", labelsOf(metrics.At(0).IntSum().DataPoints().At(0).LabelsMap())["method"])
+	assert.Equal(t, "http.requests_by_host", metrics.At(1).Name())
+	assert.Equal(t, "get", labelsOf(metrics.At(1).IntSum().DataPoints().At(0).LabelsMap())["method"])
+}
+
+func TestMetricsTransform_Combine(t *testing.T) {
+	md, metrics := newMetrics()
+	appendIntSum(metrics, "system.disk.read", map[string]string{"device": "sda"})
+	appendIntSum(metrics, "system.memory.usage", map[string]string{"state": "free"})
+	appendIntSum(metrics, "system.disk.write", map[string]string{"device": "sda"}, map[string]string{"device": "sdb"})
+
+	metrics = process(t, md, Transform{
+		Include:   `^system\.disk\.(?P<direction>read|write)$`,
+		MatchType: filterset.Regexp,
+		Action:    Combine,
+		NewName:   "system.disk.operations",
+	})
+
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "system.memory.usage", metrics.At(0).Name())
+	combined := metrics.At(1)
+	assert.Equal(t, "system.disk.operations", combined.Name())
+	assert.Equal(t, "1", combined.Unit())
+	assert.True(t, combined.IntSum().IsMonotonic())
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, combined.IntSum().AggregationTemporality())
+	dps := combined.IntSum().DataPoints()
+	require.Equal(t, 3, dps.Len())
+	assert.Equal(t, map[string]string{"device": "sda", "direction": "read"}, labelsOf(dps.At(0).LabelsMap()))
+	assert.Equal(t, map[string]string{"device": "sda", "direction": "write"}, labelsOf(dps.At(1).LabelsMap()))
+	assert.Equal(t, map[string]string{"device": "sdb", "direction": "write"}, labelsOf(dps.At(2).LabelsMap()))
+}
+
+func TestMetricsTransform_CombineDifferentTypes(t *testing.T) {
+	md, metrics := newMetrics()
+	appendIntSum(metrics, "system.disk.read", map[string]string{"device": "sda"})
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("system.disk.write")
+	gauge.SetDataType(pdata.MetricDataTypeDoubleGauge)
+
+	metrics = process(t, md, Transform{
+		Include:   `^system\.disk\.`,
+		MatchType: filterset.Regexp,
+		Action:    Combine,
+		NewName:   "system.disk.operations",
+	})
+
+	// Left unchanged.
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "system.disk.read", metrics.At(0).Name())
+	assert.Equal(t, "system.disk.write", metrics.At(1).Name())
+}
+
+func TestMetricsTransform_DeleteLabel(t *testing.T) {
+	md, metrics := newMetrics()
+	appendIntSum(metrics, "system.cpu.time",
+		map[string]string{"cpu": "0", "state": "idle"},
+		map[string]string{"cpu": "1", "state": "idle"},
+		map[string]string{"cpu": "0", "state": "user"},
+	)
+
+	metrics = process(t, md, Transform{
+		Include:    "system.cpu.time",
+		Action:     Update,
+		Operations: []Operation{{Action: DeleteLabel, Label: "cpu"}},
+	})
+
+	dps := metrics.At(0).IntSum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, map[string]string{"state": "idle"}, labelsOf(dps.At(0).LabelsMap()))
+	assert.Equal(t, int64(3), dps.At(0).Value())
+	assert.Equal(t, timestamp(0), dps.At(0).StartTimestamp())
+	assert.Equal(t, timestamp(11), dps.At(0).Timestamp())
+	assert.Equal(t, map[string]string{"state": "user"}, labelsOf(dps.At(1).LabelsMap()))
+	assert.Equal(t, int64(3), dps.At(1).Value())
+}
+
+func TestMetricsTransform_AggregateLabels(t *testing.T) {
+	tests := []struct {
+		aggregationType AggregationType
+		intValue        int64
+		doubleValue     float64
+	}{
+		{aggregationType: Sum, intValue: 6, doubleValue: 7.5},
+		{aggregationType: Mean, intValue: 2, doubleValue: 2.5},
+		{aggregationType: Max, intValue: 3, doubleValue: 4.5},
+		{aggregationType: Min, intValue: 1, doubleValue: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.aggregationType), func(t *testing.T) {
+			md, metrics := newMetrics()
+			appendIntSum(metrics, "requests",
+				map[string]string{"method": "GET", "code": "200"},
+				map[string]string{"method": "GET", "code": "500"},
+				map[string]string{"method": "GET", "code": "404"},
+			)
+			gauge := metrics.AppendEmpty()
+			gauge.SetName("queue_size")
+			gauge.SetDataType(pdata.MetricDataTypeDoubleGauge)
+			for _, v := range []float64{1, 2, 4.5} {
+				dp := gauge.DoubleGauge().DataPoints().AppendEmpty()
+				dp.LabelsMap().Insert("queue", "q")
+				dp.SetValue(v)
+			}
+
+			metrics = process(t, md, Transform{
+				Include:    ".*",
+				MatchType:  filterset.Regexp,
+				Action:     Update,
+				Operations: []Operation{{Action: AggregateLabels, LabelSet: []string{"method"}, AggregationType: tt.aggregationType}},
+			})
+
+			intDps := metrics.At(0).IntSum().DataPoints()
+			require.Equal(t, 1, intDps.Len())
+			assert.Equal(t, map[string]string{"method": "GET"}, labelsOf(intDps.At(0).LabelsMap()))
+			assert.Equal(t, tt.intValue, intDps.At(0).Value())
+			doubleDps := metrics.At(1).DoubleGauge().DataPoints()
+			require.Equal(t, 1, doubleDps.Len())
+			assert.Equal(t, 0, doubleDps.At(0).LabelsMap().Len())
+			assert.Equal(t, tt.doubleValue, doubleDps.At(0).Value())
+		})
+	}
+}
+
+func TestMetricsTransform_AggregateHistograms(t *testing.T) {
+	md, metrics := newMetrics()
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	for i, host := range []string{"a", "b"} {
+		dp := histogram.Histogram().DataPoints().AppendEmpty()
+		dp.LabelsMap().Insert("host", host)
+		dp.SetStartTimestamp(timestamp(i))
+		dp.SetTimestamp(timestamp(10 + i))
+		dp.SetCount(3)
+		dp.SetSum(10)
+		dp.SetBucketCounts([]uint64{1, 2})
+		dp.SetExplicitBounds([]float64{5})
+	}
+	summary := metrics.AppendEmpty()
+	summary.SetName("latency_summary")
+	summary.SetDataType(pdata.MetricDataTypeSummary)
+	for _, host := range []string{"a", "b"} {
+		dp := summary.Summary().DataPoints().AppendEmpty()
+		dp.LabelsMap().Insert("host", host)
+		dp.SetCount(2)
+		dp.SetSum(3)
+		dp.QuantileValues().AppendEmpty().SetQuantile(0.5)
+	}
+
+	metrics = process(t, md, Transform{
+		Include:   "latency.*",
+		MatchType: filterset.Regexp,
+		Action:    Update,
+		// Histograms and summaries are always summed.
+		Operations: []Operation{{Action: AggregateLabels, AggregationType: Max}},
+	})
+
+	hdps := metrics.At(0).Histogram().DataPoints()
+	require.Equal(t, 1, hdps.Len())
+	assert.Equal(t, timestamp(0), hdps.At(0).StartTimestamp())
+	assert.Equal(t, timestamp(11), hdps.At(0).Timestamp())
+	assert.Equal(t, uint64(6), hdps.At(0).Count())
+	assert.Equal(t, float64(20), hdps.At(0).Sum())
+	assert.Equal(t, []uint64{2, 4}, hdps.At(0).BucketCounts())
+	assert.Equal(t, []float64{5}, hdps.At(0).ExplicitBounds())
+
+	sdps := metrics.At(1).Summary().DataPoints()
+	require.Equal(t, 1, sdps.Len())
+	assert.Equal(t, uint64(4), sdps.At(0).Count())
+	assert.Equal(t, float64(6), sdps.At(0).Sum())
+	assert.Equal(t, 0, sdps.At(0).QuantileValues().Len())
+}
+
+func TestMetricsTransform_AggregateHistogramsDifferentBounds(t *testing.T) {
+	md, metrics := newMetrics()
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetDataType(pdata.MetricDataTypeIntHistogram)
+	for _, bounds := range [][]float64{{5}, {10}} {
+		dp := histogram.IntHistogram().DataPoints().AppendEmpty()
+		dp.SetCount(3)
+		dp.SetSum(10)
+		dp.SetBucketCounts([]uint64{1, 2})
+		dp.SetExplicitBounds(bounds)
+	}
+	histogram.IntHistogram().DataPoints().At(0).LabelsMap().Insert("host", "a")
+
+	metrics = process(t, md, Transform{
+		Include:    "latency",
+		Action:     Update,
+		Operations: []Operation{{Action: DeleteLabel, Label: "host"}},
+	})
+
+	dps := metrics.At(0).IntHistogram().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, uint64(6), dps.At(0).Count())
+	assert.Equal(t, int64(20), dps.At(0).Sum())
+	// The buckets cannot be added.
+	assert.Len(t, dps.At(0).BucketCounts(), 0)
+	assert.Len(t, dps.At(0).ExplicitBounds(), 0)
+}
@@ -15,6 +15,9 @@
 package probabilisticsamplerprocessor
 
 import (
+	"fmt"
+	"strings"
+
 	"go.opentelemetry.io/collector/config"
 )
 
@@ -30,11 +33,44 @@ type Config struct {
 	// have different sampling rates: if they use the same seed all passing one layer may pass the other even if they have
 	// different sampling rates, configuring different seeds avoids that.
 	HashSeed uint32 `mapstructure:"hash_seed"`
+
+	// FromAttribute is the name of the log record attribute whose value is hashed to take the sampling decision of
+	// the log records without a trace ID. Log records that have neither a trace ID nor this attribute are sampled
+	// randomly. Only used for logs.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// SeverityRates overrides SamplingPercentage for the log records of the given severities. Log records of other
+	// severities are sampled at SamplingPercentage. Only used for logs.
+	SeverityRates []SeverityRate `mapstructure:"severity_rates"`
+}
+
+// SeverityRate is the sampling percentage of the log records of a severity.
+type SeverityRate struct {
+	// Severity is the severity of the log records, one of TRACE, DEBUG, INFO, WARN, ERROR or FATAL. It matches all
+	// the severity numbers of the range, e.g. ERROR matches ERROR to ERROR4.
+	Severity string `mapstructure:"severity"`
+
+	// SamplingPercentage is the percentage rate at which the log records of this severity are going to be sampled.
+	SamplingPercentage float32 `mapstructure:"sampling_percentage"`
 }
 
 var _ config.Processor = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
+	seen := make(map[string]bool, len(cfg.SeverityRates))
+	for i, sr := range cfg.SeverityRates {
+		severity := strings.ToUpper(sr.Severity)
+		if _, ok := severityRanges[severity]; !ok {
+			return fmt.Errorf("severity_rates[%d]: unknown severity %q, must be TRACE, DEBUG, INFO, WARN, ERROR or FATAL", i, sr.Severity)
+		}
+		if seen[severity] {
+			return fmt.Errorf("severity_rates[%d]: duplicated severity %q", i, sr.Severity)
+		}
+		seen[severity] = true
+		if sr.SamplingPercentage < 0 {
+			return fmt.Errorf("severity_rates[%d]: sampling_percentage must not be negative", i)
+		}
+	}
 	return nil
 }
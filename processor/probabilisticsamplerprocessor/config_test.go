@@ -44,6 +44,19 @@ func TestLoadConfig(t *testing.T) {
 			HashSeed:           22,
 		})
 
+	p1 := cfg.Processors[config.NewIDWithName(typeStr, "logs")]
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings:  config.NewProcessorSettings(config.NewIDWithName(typeStr, "logs")),
+			SamplingPercentage: 1,
+			HashSeed:           22,
+			FromAttribute:      "request.id",
+			SeverityRates: []SeverityRate{
+				{Severity: "ERROR", SamplingPercentage: 100},
+				{Severity: "FATAL", SamplingPercentage: 100},
+				{Severity: "WARN", SamplingPercentage: 50},
+			},
+		}, p1)
 }
 
 func TestLoadConfigEmpty(t *testing.T) {
@@ -60,3 +73,35 @@ func TestLoadConfigEmpty(t *testing.T) {
 	p0 := cfg.Processors[config.NewID(typeStr)]
 	assert.Equal(t, p0, createDefaultConfig())
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		severityRates []SeverityRate
+		err           string
+	}{
+		{
+			name:          "unknown severity",
+			severityRates: []SeverityRate{{Severity: "CRITICAL", SamplingPercentage: 100}},
+			err:           `severity_rates[0]: unknown severity "CRITICAL", must be TRACE, DEBUG, INFO, WARN, ERROR or FATAL`,
+		},
+		{
+			name:          "duplicated severity",
+			severityRates: []SeverityRate{{Severity: "ERROR", SamplingPercentage: 100}, {Severity: "error", SamplingPercentage: 50}},
+			err:           `severity_rates[1]: duplicated severity "error"`,
+		},
+		{
+			name:          "negative sampling percentage",
+			severityRates: []SeverityRate{{Severity: "DEBUG", SamplingPercentage: -1}},
+			err:           "severity_rates[0]: sampling_percentage must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.SeverityRates = tt.severityRates
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// severityRanges maps the severities that can be configured in SeverityRates to the first severity number of their
+// range, each range has 4 severity numbers.
+var severityRanges = map[string]pdata.SeverityNumber{
+	"TRACE": pdata.SeverityNumberTRACE,
+	"DEBUG": pdata.SeverityNumberDEBUG,
+	"INFO":  pdata.SeverityNumberINFO,
+	"WARN":  pdata.SeverityNumberWARN,
+	"ERROR": pdata.SeverityNumberERROR,
+	"FATAL": pdata.SeverityNumberFATAL,
+}
+
+type logsamplerprocessor struct {
+	// scaledSamplingRates is indexed by severity number, the index 0 (undefined severity) has the default rate.
+	scaledSamplingRates [pdata.SeverityNumberFATAL4 + 1]uint32
+	hashSeed            uint32
+	fromAttribute       string
+}
+
+// newLogsProcessor returns a processor.LogsProcessor that will perform head sampling according to the given
+// configuration.
+func newLogsProcessor(nextConsumer consumer.Logs, cfg *Config) (component.LogsProcessor, error) {
+	lsp := &logsamplerprocessor{
+		hashSeed:      cfg.HashSeed,
+		fromAttribute: cfg.FromAttribute,
+	}
+	// Adjust sampling percentages on private so recalculations are avoided.
+	for i := range lsp.scaledSamplingRates {
+		lsp.scaledSamplingRates[i] = uint32(cfg.SamplingPercentage * percentageScaleFactor)
+	}
+	for _, sr := range cfg.SeverityRates {
+		first := severityRanges[strings.ToUpper(sr.Severity)]
+		for sn := first; sn < first+4; sn++ {
+			lsp.scaledSamplingRates[sn] = uint32(sr.SamplingPercentage * percentageScaleFactor)
+		}
+	}
+
+	return processorhelper.NewLogsProcessor(
+		cfg,
+		nextConsumer,
+		lsp,
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
+
+func (lsp *logsamplerprocessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	ld.ResourceLogs().RemoveIf(func(rl pdata.ResourceLogs) bool {
+		rl.InstrumentationLibraryLogs().RemoveIf(func(ill pdata.InstrumentationLibraryLogs) bool {
+			ill.Logs().RemoveIf(func(lr pdata.LogRecord) bool {
+				return lsp.bucket(lr) >= lsp.scaledSamplingRates[severityIndex(lr)]
+			})
+			// Filter out empty InstrumentationLibraryLogs
+			return ill.Logs().Len() == 0
+		})
+		// Filter out empty ResourceLogs
+		return rl.InstrumentationLibraryLogs().Len() == 0
+	})
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// bucket returns the hash bucket of the log record, computed from its trace ID if it has one, else from the value
+// of the FromAttribute attribute. Log records without any of them are assigned a random bucket.
+func (lsp *logsamplerprocessor) bucket(lr pdata.LogRecord) uint32 {
+	if tid := lr.TraceID(); !tid.IsEmpty() {
+		// Same decision as for the spans of the trace, when using the same seed.
+		tidBytes := tid.Bytes()
+		return hash(tidBytes[:], lsp.hashSeed) & bitMaskHashBuckets
+	}
+	if lsp.fromAttribute != "" {
+		if v, ok := lr.Attributes().Get(lsp.fromAttribute); ok {
+			return hash([]byte(tracetranslator.AttributeValueToString(v)), lsp.hashSeed) & bitMaskHashBuckets
+		}
+	}
+	return rand.Uint32() & bitMaskHashBuckets
+}
+
+// severityIndex returns the index of the sampling rate of the log record in scaledSamplingRates. The severity text
+// is used when the severity number is not set.
+func severityIndex(lr pdata.LogRecord) pdata.SeverityNumber {
+	if sn := lr.SeverityNumber(); sn > pdata.SeverityNumberUNDEFINED && sn <= pdata.SeverityNumberFATAL4 {
+		return sn
+	}
+	text := strings.ToUpper(strings.TrimRight(lr.SeverityText(), "1234"))
+	if text == "WARNING" {
+		text = "WARN"
+	}
+	return severityRanges[text]
+}
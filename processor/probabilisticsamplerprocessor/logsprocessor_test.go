@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"context"
+	"encoding/binary"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func newTestLogsConfig(samplingPercentage float32) *Config {
+	return &Config{
+		ProcessorSettings:  config.NewProcessorSettings(config.NewID(typeStr)),
+		SamplingPercentage: samplingPercentage,
+		HashSeed:           22,
+	}
+}
+
+func genLogs(n int, fill func(i int, lr pdata.LogRecord)) pdata.Logs {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	logs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for i := 0; i < n; i++ {
+		fill(i, logs.AppendEmpty())
+	}
+	return ld
+}
+
+func genTraceID(i int) pdata.TraceID {
+	var tid [16]byte
+	binary.BigEndian.PutUint64(tid[8:], uint64(i)+1)
+	return pdata.NewTraceID(tid)
+}
+
+func sampledLogs(t *testing.T, cfg *Config, ld pdata.Logs) []pdata.LogRecord {
+	sink := new(consumertest.LogsSink)
+	lsp, err := newLogsProcessor(sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, lsp.ConsumeLogs(context.Background(), ld))
+
+	var sampled []pdata.LogRecord
+	for _, ld := range sink.AllLogs() {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			ills := rls.At(i).InstrumentationLibraryLogs()
+			for j := 0; j < ills.Len(); j++ {
+				logs := ills.At(j).Logs()
+				for k := 0; k < logs.Len(); k++ {
+					sampled = append(sampled, logs.At(k))
+				}
+			}
+		}
+	}
+	return sampled
+}
+
+func TestNewLogsProcessor(t *testing.T) {
+	_, err := newLogsProcessor(nil, newTestLogsConfig(15.5))
+	assert.Error(t, err)
+
+	lp, err := newLogsProcessor(consumertest.NewNop(), newTestLogsConfig(15.5))
+	assert.NoError(t, err)
+	assert.NotNil(t, lp)
+}
+
+func TestLogsSampler_SamplingPercentageRange(t *testing.T) {
+	tests := []struct {
+		name               string
+		samplingPercentage float32
+		fill               func(i int, lr pdata.LogRecord)
+	}{
+		{
+			name:               "trace_id",
+			samplingPercentage: 25,
+			fill:               func(i int, lr pdata.LogRecord) { lr.SetTraceID(genTraceID(i)) },
+		},
+		{
+			name:               "attribute",
+			samplingPercentage: 25,
+			fill:               func(i int, lr pdata.LogRecord) { lr.Attributes().InsertString("request.id", strconv.Itoa(i)) },
+		},
+		{
+			name:               "random",
+			samplingPercentage: 25,
+			fill:               func(int, pdata.LogRecord) {},
+		},
+		{
+			name:               "all",
+			samplingPercentage: 100,
+			fill:               func(int, pdata.LogRecord) {},
+		},
+	}
+	const numLogs = 10000
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestLogsConfig(tt.samplingPercentage)
+			cfg.FromAttribute = "request.id"
+			sampled := sampledLogs(t, cfg, genLogs(numLogs, tt.fill))
+			assert.InDelta(t, tt.samplingPercentage/100, float64(len(sampled))/numLogs, 0.02)
+		})
+	}
+}
+
+func TestLogsSampler_ConsistentWithTraces(t *testing.T) {
+	const numTraces = 1000
+	cfg := newTestLogsConfig(30)
+
+	td := pdata.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for i := 0; i < numTraces; i++ {
+		spans.AppendEmpty().SetTraceID(genTraceID(i))
+	}
+	tracesSink := new(consumertest.TracesSink)
+	tsp, err := newTracesProcessor(tracesSink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), td))
+	sampledTraces := tracesSink.AllTraces()[0].ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+
+	sampled := sampledLogs(t, cfg, genLogs(numTraces, func(i int, lr pdata.LogRecord) { lr.SetTraceID(genTraceID(i)) }))
+	require.Equal(t, sampledTraces.Len(), len(sampled))
+	for i, lr := range sampled {
+		assert.Equal(t, sampledTraces.At(i).TraceID(), lr.TraceID())
+	}
+}
+
+func TestLogsSampler_SameAttributeSameDecision(t *testing.T) {
+	cfg := newTestLogsConfig(50)
+	cfg.FromAttribute = "request.id"
+
+	// Each request has 10 log records, they are either all sampled or all dropped.
+	sampled := sampledLogs(t, cfg, genLogs(1000, func(i int, lr pdata.LogRecord) {
+		lr.Attributes().InsertInt("request.id", int64(i/10))
+	}))
+	counts := make(map[int64]int)
+	for _, lr := range sampled {
+		v, _ := lr.Attributes().Get("request.id")
+		counts[v.IntVal()]++
+	}
+	assert.NotEmpty(t, counts)
+	for id, count := range counts {
+		assert.Equal(t, 10, count, "request %d", id)
+	}
+}
+
+func TestLogsSampler_SeverityRates(t *testing.T) {
+	cfg := newTestLogsConfig(0)
+	cfg.SeverityRates = []SeverityRate{
+		{Severity: "error", SamplingPercentage: 100},
+		{Severity: "DEBUG", SamplingPercentage: 0},
+		{Severity: "WARN", SamplingPercentage: 100},
+	}
+
+	severities := []struct {
+		number pdata.SeverityNumber
+		text   string
+	}{
+		{number: pdata.SeverityNumberERROR},
+		{number: pdata.SeverityNumberERROR4},
+		{text: "Error"},
+		{text: "WARNING"},
+		{number: pdata.SeverityNumberDEBUG},
+		{number: pdata.SeverityNumberINFO},
+		{number: pdata.SeverityNumberFATAL},
+		{text: "ERROR", number: pdata.SeverityNumberINFO},
+		{},
+	}
+	sampled := sampledLogs(t, cfg, genLogs(len(severities), func(i int, lr pdata.LogRecord) {
+		lr.SetName(strconv.Itoa(i))
+		lr.SetSeverityNumber(severities[i].number)
+		lr.SetSeverityText(severities[i].text)
+	}))
+
+	var names []string
+	for _, lr := range sampled {
+		names = append(names, lr.Name())
+	}
+	assert.Equal(t, []string{"0", "1", "2", "3"}, names)
+}
+
+func TestLogsSampler_NothingSampled(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	lsp, err := newLogsProcessor(sink, newTestLogsConfig(0))
+	require.NoError(t, err)
+	require.NoError(t, lsp.ConsumeLogs(context.Background(), genLogs(10, func(int, pdata.LogRecord) {})))
+	assert.Len(t, sink.AllLogs(), 0)
+}
@@ -16,17 +16,22 @@ package processorhelper
 
 import (
 	"fmt"
+	"math"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/processor/filterhelper"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
 )
 
 // Settings specifies the processor settings.
 type Settings struct {
 	// Actions specifies the list of attributes to act on.
-	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT}.
+	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT,
+	// CONVERT, TRUNCATE, COPY, MOVE}.
 	// This is a required field.
 	Actions []ActionKeyValue `mapstructure:"actions"`
 }
@@ -55,8 +60,22 @@ type ActionKeyValue struct {
 	// the value. If the attribute doesn't exist, no action is performed.
 	FromAttribute string `mapstructure:"from_attribute"`
 
+	// ConvertedType specifies the type the value is converted to, for the
+	// action CONVERT. The set of values are {string, int, double, bool}.
+	ConvertedType string `mapstructure:"converted_type"`
+
+	// MaxLength specifies the maximum length of the value, for the action
+	// TRUNCATE. It is the number of bytes for strings and the number of
+	// elements for arrays.
+	MaxLength int `mapstructure:"max_length"`
+
+	// ToAttribute specifies the attribute to set in the target, for the
+	// actions COPY and MOVE. Defaults to `key`.
+	ToAttribute string `mapstructure:"to_attribute"`
+
 	// Action specifies the type of action to perform.
-	// The set of values are {INSERT, UPDATE, UPSERT, DELETE, HASH}.
+	// The set of values are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT,
+	// CONVERT, TRUNCATE, COPY, MOVE}.
 	// Both lower case and upper case are supported.
 	// INSERT -  Inserts the key/value to attributes when the key does not exist.
 	//           No action is applied to attributes where the key already exists.
@@ -76,6 +95,16 @@ type ActionKeyValue struct {
 	// EXTRACT - Extracts values using a regular expression rule from the input
 	//           'key' to target keys specified in the 'rule'. If a target key
 	//           already exists, it will be overridden.
+	// CONVERT - Converts the value of an existing key to `converted_type`.
+	//           If the value cannot be converted, it is left unchanged.
+	// TRUNCATE- Truncates the string value or the array value of an existing
+	//           key to `max_length`.
+	// COPY    - Copies the value of an existing key to `to_attribute` of the
+	//           target attributes, e.g. from the span to the resource. If the
+	//           key already exists in the target, it is overridden. A target
+	//           shared by several spans is only set if they all have the same
+	//           value.
+	// MOVE    - Performs the COPY action, then deletes the key.
 	// This is a required field.
 	Action Action `mapstructure:"action"`
 }
@@ -109,6 +138,29 @@ const (
 	// 'key' to target keys specified in the 'rule'. If a target key already
 	// exists, it will be overridden.
 	EXTRACT Action = "extract"
+
+	// CONVERT converts the value of an existing key to another type. If the
+	// value cannot be converted, it is left unchanged.
+	CONVERT Action = "convert"
+
+	// TRUNCATE truncates the string value or the array value of an existing key.
+	TRUNCATE Action = "truncate"
+
+	// COPY copies the value of an existing key to the target attributes, e.g.
+	// from the span attributes to the resource attributes.
+	COPY Action = "copy"
+
+	// MOVE copies the value of an existing key to the target attributes, and
+	// deletes the key.
+	MOVE Action = "move"
+)
+
+// The types supported by the CONVERT action.
+const (
+	convertToString = "string"
+	convertToInt    = "int"
+	convertToDouble = "double"
+	convertToBool   = "bool"
 )
 
 type attributeAction struct {
@@ -120,6 +172,10 @@ type attributeAction struct {
 	AttrNames []string
 	// Number of non empty strings in above array
 
+	ConvertedType string
+	MaxLength     int
+	ToAttribute   string
+
 	// TODO https://go.opentelemetry.io/collector/issues/296
 	// Do benchmark testing between having action be of type string vs integer.
 	// The reason is attributes processor will most likely be commonly used
@@ -202,47 +258,174 @@ func NewAttrProc(settings *Settings) (*AttrProc, error) {
 			}
 			action.Regex = re
 			action.AttrNames = attrNames
+		case CONVERT:
+			if a.Value != nil || a.FromAttribute != "" || a.RegexPattern != "" || a.MaxLength != 0 || a.ToAttribute != "" {
+				return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use \"value\", \"pattern\", \"from_attribute\", \"max_length\" or \"to_attribute\" field. These must not be specified for %d-th action", a.Action, i)
+			}
+			switch a.ConvertedType {
+			case convertToString, convertToInt, convertToDouble, convertToBool:
+			case "":
+				return nil, fmt.Errorf("error creating AttrProc due to missing required field \"converted_type\" for action \"%s\" at the %d-th action", a.Action, i)
+			default:
+				return nil, fmt.Errorf("error creating AttrProc due to unsupported converted_type %q at the %d-th actions", a.ConvertedType, i)
+			}
+			action.ConvertedType = a.ConvertedType
+		case TRUNCATE:
+			if a.Value != nil || a.FromAttribute != "" || a.RegexPattern != "" || a.ConvertedType != "" || a.ToAttribute != "" {
+				return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use \"value\", \"pattern\", \"from_attribute\", \"converted_type\" or \"to_attribute\" field. These must not be specified for %d-th action", a.Action, i)
+			}
+			if a.MaxLength <= 0 {
+				return nil, fmt.Errorf("error creating AttrProc. Field \"max_length\" must be positive for action \"%s\" at the %d-th action", a.Action, i)
+			}
+			action.MaxLength = a.MaxLength
+		case COPY, MOVE:
+			if a.Value != nil || a.FromAttribute != "" || a.RegexPattern != "" || a.ConvertedType != "" || a.MaxLength != 0 {
+				return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use \"value\", \"pattern\", \"from_attribute\", \"converted_type\" or \"max_length\" field. These must not be specified for %d-th action", a.Action, i)
+			}
+			action.ToAttribute = a.ToAttribute
+			if action.ToAttribute == "" {
+				action.ToAttribute = a.Key
+			}
 		default:
 			return nil, fmt.Errorf("error creating AttrProc due to unsupported action %q at the %d-th actions", a.Action, i)
 		}
+		if a.Action != CONVERT && a.ConvertedType != "" {
+			return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use the \"converted_type\" field. This must not be specified for %d-th action", a.Action, i)
+		}
+		if a.Action != TRUNCATE && a.MaxLength != 0 {
+			return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use the \"max_length\" field. This must not be specified for %d-th action", a.Action, i)
+		}
+		if a.Action != COPY && a.Action != MOVE && a.ToAttribute != "" {
+			return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use the \"to_attribute\" field. This must not be specified for %d-th action", a.Action, i)
+		}
 
 		attributeActions = append(attributeActions, action)
 	}
 	return &AttrProc{actions: attributeActions}, nil
 }
 
-// Process applies the AttrProc to an attribute map.
-func (ap *AttrProc) Process(attrs pdata.AttributeMap) {
+// UsesTargets returns whether the AttrProc has COPY or MOVE actions, which
+// require target attribute maps.
+func (ap *AttrProc) UsesTargets() bool {
+	for _, action := range ap.actions {
+		if action.Action == COPY || action.Action == MOVE {
+			return true
+		}
+	}
+	return false
+}
+
+// Process applies the AttrProc to an attribute map. The COPY and MOVE
+// actions copy the attributes to the targets.
+func (ap *AttrProc) Process(attrs pdata.AttributeMap, targets ...pdata.AttributeMap) {
+	for _, action := range ap.actions {
+		processAction(action, attrs, targets)
+	}
+}
+
+// ProcessShared applies the AttrProc to attribute maps sharing the same
+// target, e.g. the attributes of the spans of a resource. The COPY and MOVE
+// actions only set an attribute of the target if all the maps have the same
+// value for it, otherwise the maps are left unchanged.
+func (ap *AttrProc) ProcessShared(attrsList []pdata.AttributeMap, target pdata.AttributeMap) {
+	for _, action := range ap.actions {
+		if action.Action != COPY && action.Action != MOVE {
+			for _, attrs := range attrsList {
+				processAction(action, attrs, nil)
+			}
+			continue
+		}
+
+		value, agree := sharedAttributeValue(action.Key, attrsList)
+		if !agree {
+			continue
+		}
+		target.Upsert(action.ToAttribute, value)
+		if action.Action == MOVE {
+			for _, attrs := range attrsList {
+				attrs.Delete(action.Key)
+			}
+		}
+	}
+}
+
+func processAction(action attributeAction, attrs pdata.AttributeMap, targets []pdata.AttributeMap) {
+	// TODO https://go.opentelemetry.io/collector/issues/296
+	// Do benchmark testing between having action be of type string vs integer.
+	// The reason is attributes processor will most likely be commonly used
+	// and could impact performance.
+	switch action.Action {
+	case DELETE:
+		attrs.Delete(action.Key)
+	case INSERT:
+		av, found := getSourceAttributeValue(action, attrs)
+		if !found {
+			return
+		}
+		attrs.Insert(action.Key, av)
+	case UPDATE:
+		av, found := getSourceAttributeValue(action, attrs)
+		if !found {
+			return
+		}
+		attrs.Update(action.Key, av)
+	case UPSERT:
+		av, found := getSourceAttributeValue(action, attrs)
+		if !found {
+			return
+		}
+		attrs.Upsert(action.Key, av)
+	case HASH:
+		hashAttribute(action, attrs)
+	case EXTRACT:
+		extractAttributes(action, attrs)
+	case CONVERT:
+		convertAttribute(action, attrs)
+	case TRUNCATE:
+		truncateAttribute(action, attrs)
+	case COPY:
+		copyAttribute(action, attrs, targets)
+	case MOVE:
+		copyAttribute(action, attrs, targets)
+		attrs.Delete(action.Key)
+	}
+}
+
+// ProcessLabels applies the AttrProc to the labels of a metric data point.
+// The values are converted to strings, since labels only have string values,
+// so the CONVERT action has no effect. The COPY and MOVE actions are not
+// supported, see UsesTargets.
+func (ap *AttrProc) ProcessLabels(labels pdata.StringMap) {
 	for _, action := range ap.actions {
-		// TODO https://go.opentelemetry.io/collector/issues/296
-		// Do benchmark testing between having action be of type string vs integer.
-		// The reason is attributes processor will most likely be commonly used
-		// and could impact performance.
 		switch action.Action {
 		case DELETE:
-			attrs.Delete(action.Key)
+			labels.Delete(action.Key)
 		case INSERT:
-			av, found := getSourceAttributeValue(action, attrs)
+			v, found := getSourceLabelValue(action, labels)
 			if !found {
 				continue
 			}
-			attrs.Insert(action.Key, av)
+			labels.Insert(action.Key, v)
 		case UPDATE:
-			av, found := getSourceAttributeValue(action, attrs)
+			v, found := getSourceLabelValue(action, labels)
 			if !found {
 				continue
 			}
-			attrs.Update(action.Key, av)
+			labels.Update(action.Key, v)
 		case UPSERT:
-			av, found := getSourceAttributeValue(action, attrs)
+			v, found := getSourceLabelValue(action, labels)
 			if !found {
 				continue
 			}
-			attrs.Upsert(action.Key, av)
+			labels.Upsert(action.Key, v)
 		case HASH:
-			hashAttribute(action, attrs)
+			hashLabel(action, labels)
 		case EXTRACT:
-			extractAttributes(action, attrs)
+			extractLabels(action, labels)
+		case TRUNCATE:
+			if value, exists := labels.Get(action.Key); exists {
+				labels.Update(action.Key, truncateString(value, action.MaxLength))
+			}
 		}
 	}
 }
@@ -283,3 +466,161 @@ func extractAttributes(action attributeAction, attrs pdata.AttributeMap) {
 		attrs.UpsertString(action.AttrNames[i], matches[i])
 	}
 }
+
+func convertAttribute(action attributeAction, attrs pdata.AttributeMap) {
+	value, found := attrs.Get(action.Key)
+	if !found {
+		return
+	}
+
+	switch action.ConvertedType {
+	case convertToString:
+		if value.Type() != pdata.AttributeValueTypeString {
+			attrs.UpdateString(action.Key, tracetranslator.AttributeValueToString(value))
+		}
+	case convertToInt:
+		switch value.Type() {
+		case pdata.AttributeValueTypeString:
+			if i, err := strconv.ParseInt(strings.TrimSpace(value.StringVal()), 10, 64); err == nil {
+				attrs.UpdateInt(action.Key, i)
+			} else if d, err := strconv.ParseFloat(strings.TrimSpace(value.StringVal()), 64); err == nil && isIntRange(d) {
+				attrs.UpdateInt(action.Key, int64(d))
+			}
+		case pdata.AttributeValueTypeDouble:
+			if isIntRange(value.DoubleVal()) {
+				attrs.UpdateInt(action.Key, int64(value.DoubleVal()))
+			}
+		case pdata.AttributeValueTypeBool:
+			if value.BoolVal() {
+				attrs.UpdateInt(action.Key, 1)
+			} else {
+				attrs.UpdateInt(action.Key, 0)
+			}
+		}
+	case convertToDouble:
+		switch value.Type() {
+		case pdata.AttributeValueTypeString:
+			if d, err := strconv.ParseFloat(strings.TrimSpace(value.StringVal()), 64); err == nil {
+				attrs.UpdateDouble(action.Key, d)
+			}
+		case pdata.AttributeValueTypeInt:
+			attrs.UpdateDouble(action.Key, float64(value.IntVal()))
+		case pdata.AttributeValueTypeBool:
+			if value.BoolVal() {
+				attrs.UpdateDouble(action.Key, 1)
+			} else {
+				attrs.UpdateDouble(action.Key, 0)
+			}
+		}
+	case convertToBool:
+		switch value.Type() {
+		case pdata.AttributeValueTypeString:
+			if b, err := strconv.ParseBool(strings.TrimSpace(value.StringVal())); err == nil {
+				attrs.UpdateBool(action.Key, b)
+			}
+		case pdata.AttributeValueTypeInt:
+			attrs.UpdateBool(action.Key, value.IntVal() != 0)
+		case pdata.AttributeValueTypeDouble:
+			attrs.UpdateBool(action.Key, value.DoubleVal() != 0)
+		}
+	}
+}
+
+// isIntRange returns whether the double has a finite value that fits in an int64 once truncated.
+func isIntRange(d float64) bool {
+	return !math.IsNaN(d) && d > math.MinInt64-1 && d < math.MaxInt64
+}
+
+func truncateAttribute(action attributeAction, attrs pdata.AttributeMap) {
+	value, found := attrs.Get(action.Key)
+	if !found {
+		return
+	}
+
+	switch value.Type() {
+	case pdata.AttributeValueTypeString:
+		if len(value.StringVal()) > action.MaxLength {
+			value.SetStringVal(truncateString(value.StringVal(), action.MaxLength))
+		}
+	case pdata.AttributeValueTypeArray:
+		if arr := value.ArrayVal(); arr.Len() > action.MaxLength {
+			arr.Resize(action.MaxLength)
+		}
+	}
+}
+
+// truncateString truncates the string to at most maxLength bytes, without
+// splitting a multi-byte UTF-8 character.
+func truncateString(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+	for maxLength > 0 && !utf8.RuneStart(s[maxLength]) {
+		maxLength--
+	}
+	return s[:maxLength]
+}
+
+func copyAttribute(action attributeAction, attrs pdata.AttributeMap, targets []pdata.AttributeMap) {
+	value, found := attrs.Get(action.Key)
+	if !found {
+		return
+	}
+
+	for _, target := range targets {
+		target.Upsert(action.ToAttribute, value)
+	}
+}
+
+// sharedAttributeValue returns the value of the key if all the maps have it
+// with the same value.
+func sharedAttributeValue(key string, attrsList []pdata.AttributeMap) (pdata.AttributeValue, bool) {
+	if len(attrsList) == 0 {
+		return pdata.AttributeValue{}, false
+	}
+	value, found := attrsList[0].Get(key)
+	if !found {
+		return pdata.AttributeValue{}, false
+	}
+	for _, attrs := range attrsList[1:] {
+		if other, found := attrs.Get(key); !found || !other.Equal(value) {
+			return pdata.AttributeValue{}, false
+		}
+	}
+	return value, true
+}
+
+func getSourceLabelValue(action attributeAction, labels pdata.StringMap) (string, bool) {
+	// Set the key with a value from the configuration.
+	if action.AttributeValue != nil {
+		return tracetranslator.AttributeValueToString(*action.AttributeValue), true
+	}
+
+	return labels.Get(action.FromAttribute)
+}
+
+func hashLabel(action attributeAction, labels pdata.StringMap) {
+	if value, exists := labels.Get(action.Key); exists {
+		var hashed string
+		if value != "" {
+			hashed = sha1HexString([]byte(value))
+		}
+		labels.Update(action.Key, hashed)
+	}
+}
+
+func extractLabels(action attributeAction, labels pdata.StringMap) {
+	value, found := labels.Get(action.Key)
+	if !found {
+		return
+	}
+
+	matches := action.Regex.FindStringSubmatch(value)
+	if matches == nil {
+		return
+	}
+
+	for i := 1; i < len(matches); i++ {
+		labels.Upsert(action.AttrNames[i], matches[i])
+	}
+}
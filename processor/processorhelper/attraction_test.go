@@ -738,6 +738,193 @@ func TestAttributes_Ordering(t *testing.T) {
 	}
 }
 
+func TestAttributes_Convert(t *testing.T) {
+	testCases := []struct {
+		name          string
+		convertedType string
+		input         pdata.AttributeValue
+		expected      pdata.AttributeValue
+	}{
+		{name: "IntToString", convertedType: "string", input: pdata.NewAttributeValueInt(200), expected: pdata.NewAttributeValueString("200")},
+		{name: "BoolToString", convertedType: "string", input: pdata.NewAttributeValueBool(true), expected: pdata.NewAttributeValueString("true")},
+		{name: "StringToInt", convertedType: "int", input: pdata.NewAttributeValueString(" 404 "), expected: pdata.NewAttributeValueInt(404)},
+		{name: "DecimalStringToInt", convertedType: "int", input: pdata.NewAttributeValueString("12.7"), expected: pdata.NewAttributeValueInt(12)},
+		{name: "DoubleToInt", convertedType: "int", input: pdata.NewAttributeValueDouble(-3.9), expected: pdata.NewAttributeValueInt(-3)},
+		{name: "BoolToInt", convertedType: "int", input: pdata.NewAttributeValueBool(true), expected: pdata.NewAttributeValueInt(1)},
+		{name: "InvalidStringToInt", convertedType: "int", input: pdata.NewAttributeValueString("abc"), expected: pdata.NewAttributeValueString("abc")},
+		{name: "InfiniteDoubleToInt", convertedType: "int", input: pdata.NewAttributeValueDouble(math.Inf(1)), expected: pdata.NewAttributeValueDouble(math.Inf(1))},
+		{name: "StringToDouble", convertedType: "double", input: pdata.NewAttributeValueString("0.25"), expected: pdata.NewAttributeValueDouble(0.25)},
+		{name: "IntToDouble", convertedType: "double", input: pdata.NewAttributeValueInt(3), expected: pdata.NewAttributeValueDouble(3)},
+		{name: "StringToBool", convertedType: "bool", input: pdata.NewAttributeValueString("TRUE"), expected: pdata.NewAttributeValueBool(true)},
+		{name: "IntToBool", convertedType: "bool", input: pdata.NewAttributeValueInt(0), expected: pdata.NewAttributeValueBool(false)},
+		{name: "InvalidStringToBool", convertedType: "bool", input: pdata.NewAttributeValueString("yes"), expected: pdata.NewAttributeValueString("yes")},
+	}
+
+	for _, tt := range testCases {
+		ap, err := NewAttrProc(&Settings{
+			Actions: []ActionKeyValue{
+				{Key: "attribute1", Action: CONVERT, ConvertedType: tt.convertedType},
+				{Key: "missing", Action: CONVERT, ConvertedType: tt.convertedType},
+			},
+		})
+		require.NoError(t, err)
+
+		runIndividualTestCase(t, testCase{
+			name: tt.name,
+			inputAttributes: map[string]pdata.AttributeValue{
+				"attribute1": tt.input,
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"attribute1": tt.expected,
+			},
+		}, ap)
+	}
+}
+
+func TestAttributes_Truncate(t *testing.T) {
+	longArray := pdata.NewAttributeValueArray()
+	shortArray := pdata.NewAttributeValueArray()
+	for i := 0; i < 6; i++ {
+		longArray.ArrayVal().AppendEmpty().SetIntVal(int64(i))
+		if i < 4 {
+			shortArray.ArrayVal().AppendEmpty().SetIntVal(int64(i))
+		}
+	}
+
+	testCases := []testCase{
+		// Ensure the strings are truncated, without splitting a character.
+		{
+			name: "TruncateString",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"attribute1": pdata.NewAttributeValueString("abcdefgh"),
+				"attribute2": pdata.NewAttributeValueString("abcé"),
+				"attribute3": pdata.NewAttributeValueString("ab"),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"attribute1": pdata.NewAttributeValueString("abcd"),
+				"attribute2": pdata.NewAttributeValueString("abc"),
+				"attribute3": pdata.NewAttributeValueString("ab"),
+			},
+		},
+		// Ensure the arrays are truncated, and the other types are left unchanged.
+		{
+			name: "TruncateArray",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"attribute1": longArray,
+				"attribute2": pdata.NewAttributeValueInt(123456),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"attribute1": shortArray,
+				"attribute2": pdata.NewAttributeValueInt(123456),
+			},
+		},
+	}
+
+	cfg := &Settings{
+		Actions: []ActionKeyValue{
+			{Key: "attribute1", Action: TRUNCATE, MaxLength: 4},
+			{Key: "attribute2", Action: TRUNCATE, MaxLength: 4},
+			{Key: "attribute3", Action: TRUNCATE, MaxLength: 4},
+		},
+	}
+
+	ap, err := NewAttrProc(cfg)
+	require.Nil(t, err)
+	require.NotNil(t, ap)
+
+	for _, tt := range testCases {
+		runIndividualTestCase(t, tt, ap)
+	}
+}
+
+func TestAttributes_CopyMove(t *testing.T) {
+	cfg := &Settings{
+		Actions: []ActionKeyValue{
+			{Key: "service.version", Action: COPY},
+			{Key: "host", Action: MOVE, ToAttribute: "host.name"},
+			{Key: "missing", Action: MOVE},
+		},
+	}
+
+	ap, err := NewAttrProc(cfg)
+	require.Nil(t, err)
+	require.True(t, ap.UsesTargets())
+
+	attrs := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"service.version": pdata.NewAttributeValueString("1.2.3"),
+		"host":            pdata.NewAttributeValueString("node-1"),
+	})
+	target1 := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"host.name": pdata.NewAttributeValueString("unknown"),
+	})
+	target2 := pdata.NewAttributeMap()
+	ap.Process(attrs, target1, target2)
+
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"service.version": pdata.NewAttributeValueString("1.2.3"),
+	}), attrs)
+	expectedTarget := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"service.version": pdata.NewAttributeValueString("1.2.3"),
+		"host.name":       pdata.NewAttributeValueString("node-1"),
+	}).Sort()
+	assert.Equal(t, expectedTarget, target1.Sort())
+	assert.Equal(t, expectedTarget, target2.Sort())
+
+	ap, err = NewAttrProc(&Settings{Actions: []ActionKeyValue{{Key: "one", Action: DELETE}}})
+	require.Nil(t, err)
+	assert.False(t, ap.UsesTargets())
+}
+
+func TestAttributes_ProcessShared(t *testing.T) {
+	cfg := &Settings{
+		Actions: []ActionKeyValue{
+			{Key: "region", Action: UPSERT, Value: "eu"},
+			{Key: "service.version", Action: COPY},
+			{Key: "host", Action: MOVE, ToAttribute: "host.name"},
+			{Key: "region", Action: MOVE},
+		},
+	}
+
+	ap, err := NewAttrProc(cfg)
+	require.Nil(t, err)
+
+	attrs1 := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"service.version": pdata.NewAttributeValueString("1.2.3"),
+		"host":            pdata.NewAttributeValueString("node-1"),
+	})
+	attrs2 := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"service.version": pdata.NewAttributeValueString("1.2.3"),
+		"host":            pdata.NewAttributeValueString("node-2"),
+	})
+	target := pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"host.name": pdata.NewAttributeValueString("unknown"),
+	})
+	ap.ProcessShared([]pdata.AttributeMap{attrs1, attrs2}, target)
+
+	// The values that differ are left in the attributes, the target is not changed for them.
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"service.version": pdata.NewAttributeValueString("1.2.3"),
+		"host":            pdata.NewAttributeValueString("node-1"),
+	}).Sort(), attrs1.Sort())
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"service.version": pdata.NewAttributeValueString("1.2.3"),
+		"host":            pdata.NewAttributeValueString("node-2"),
+	}).Sort(), attrs2.Sort())
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"service.version": pdata.NewAttributeValueString("1.2.3"),
+		"host.name":       pdata.NewAttributeValueString("unknown"),
+		"region":          pdata.NewAttributeValueString("eu"),
+	}).Sort(), target.Sort())
+
+	// A value missing from one of the attributes is not shared either.
+	attrs3 := pdata.NewAttributeMap()
+	target = pdata.NewAttributeMap()
+	ap.ProcessShared([]pdata.AttributeMap{attrs1, attrs3}, target)
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"region": pdata.NewAttributeValueString("eu"),
+	}), target)
+}
+
 func TestInvalidConfig(t *testing.T) {
 	testcase := []struct {
 		name        string
@@ -835,6 +1022,55 @@ func TestInvalidConfig(t *testing.T) {
 			},
 			errorString: "error creating AttrProc. Field \"pattern\" contains at least one unnamed matcher group at the 0-th actions",
 		},
+		{
+			name: "missing converted type",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", Action: CONVERT},
+			},
+			errorString: "error creating AttrProc due to missing required field \"converted_type\" for action \"convert\" at the 0-th action",
+		},
+		{
+			name: "unsupported converted type",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", Action: CONVERT, ConvertedType: "bytes"},
+			},
+			errorString: "error creating AttrProc due to unsupported converted_type \"bytes\" at the 0-th actions",
+		},
+		{
+			name: "convert with value",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", Action: CONVERT, ConvertedType: "int", Value: 1},
+			},
+			errorString: "error creating AttrProc. Action \"convert\" does not use \"value\", \"pattern\", \"from_attribute\", \"max_length\" or \"to_attribute\" field. These must not be specified for 0-th action",
+		},
+		{
+			name: "missing max length",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", Action: TRUNCATE},
+			},
+			errorString: "error creating AttrProc. Field \"max_length\" must be positive for action \"truncate\" at the 0-th action",
+		},
+		{
+			name: "truncate with to attribute",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", Action: TRUNCATE, MaxLength: 10, ToAttribute: "bb"},
+			},
+			errorString: "error creating AttrProc. Action \"truncate\" does not use \"value\", \"pattern\", \"from_attribute\", \"converted_type\" or \"to_attribute\" field. These must not be specified for 0-th action",
+		},
+		{
+			name: "copy with from attribute",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", Action: COPY, FromAttribute: "bb"},
+			},
+			errorString: "error creating AttrProc. Action \"copy\" does not use \"value\", \"pattern\", \"from_attribute\", \"converted_type\" or \"max_length\" field. These must not be specified for 0-th action",
+		},
+		{
+			name: "insert with max length",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", Action: INSERT, Value: "value", MaxLength: 10},
+			},
+			errorString: "error creating AttrProc. Action \"insert\" does not use the \"max_length\" field. This must not be specified for 0-th action",
+		},
 	}
 
 	for _, tc := range testcase {
@@ -854,6 +1090,10 @@ func TestValidConfiguration(t *testing.T) {
 			{Key: "three", FromAttribute: "two", Action: "upDaTE"},
 			{Key: "five", FromAttribute: "two", Action: "upsert"},
 			{Key: "two", RegexPattern: "^\\/api\\/v1\\/document\\/(?P<documentId>.*)\\/update$", Action: "EXTRact"},
+			{Key: "six", ConvertedType: "int", Action: "CONVERT"},
+			{Key: "seven", MaxLength: 16, Action: "truncate"},
+			{Key: "eight", Action: "copy"},
+			{Key: "nine", ToAttribute: "ten", Action: "Move"},
 		},
 	}
 	ap, err := NewAttrProc(cfg)
@@ -869,6 +1109,10 @@ func TestValidConfiguration(t *testing.T) {
 		{Key: "three", FromAttribute: "two", Action: UPDATE},
 		{Key: "five", FromAttribute: "two", Action: UPSERT},
 		{Key: "two", Regex: compiledRegex, AttrNames: []string{"", "documentId"}, Action: EXTRACT},
+		{Key: "six", ConvertedType: "int", Action: CONVERT},
+		{Key: "seven", MaxLength: 16, Action: TRUNCATE},
+		{Key: "eight", ToAttribute: "eight", Action: COPY},
+		{Key: "nine", ToAttribute: "ten", Action: MOVE},
 	}, ap.actions)
 
 }
@@ -879,3 +1123,78 @@ func sha1Hash(b []byte) string {
 	h.Write(b)
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
+
+func TestLabels_Process(t *testing.T) {
+	testCases := []struct {
+		name     string
+		actions  []ActionKeyValue
+		input    map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "InsertValue",
+			actions:  []ActionKeyValue{{Key: "region", Action: INSERT, Value: "eu-west-1"}, {Key: "http.status_code", Action: INSERT, Value: 200}},
+			input:    map[string]string{"region": "us-east-1"},
+			expected: map[string]string{"region": "us-east-1", "http.status_code": "200"},
+		},
+		{
+			name:     "UpdateFromLabel",
+			actions:  []ActionKeyValue{{Key: "host", Action: UPDATE, FromAttribute: "pod"}, {Key: "missing", Action: UPDATE, FromAttribute: "pod"}},
+			input:    map[string]string{"host": "node-1", "pod": "pod-1"},
+			expected: map[string]string{"host": "pod-1", "pod": "pod-1"},
+		},
+		{
+			name:     "UpsertValue",
+			actions:  []ActionKeyValue{{Key: "env", Action: UPSERT, Value: "prod"}, {Key: "team", Action: UPSERT, Value: "core"}},
+			input:    map[string]string{"env": "dev"},
+			expected: map[string]string{"env": "prod", "team": "core"},
+		},
+		{
+			name:     "Delete",
+			actions:  []ActionKeyValue{{Key: "user.id", Action: DELETE}},
+			input:    map[string]string{"user.id": "1234", "method": "GET"},
+			expected: map[string]string{"method": "GET"},
+		},
+		{
+			name:     "Hash",
+			actions:  []ActionKeyValue{{Key: "user.email", Action: HASH}, {Key: "empty", Action: HASH}, {Key: "missing", Action: HASH}},
+			input:    map[string]string{"user.email": "john.doe@example.com", "empty": ""},
+			expected: map[string]string{"user.email": sha1Hash([]byte("john.doe@example.com")), "empty": ""},
+		},
+		{
+			name:     "Extract",
+			actions:  []ActionKeyValue{{Key: "path", Action: EXTRACT, RegexPattern: `^/api/(?P<version>v\d+)/(?P<resource>\w+)`}},
+			input:    map[string]string{"path": "/api/v1/users/1234", "resource": "unknown"},
+			expected: map[string]string{"path": "/api/v1/users/1234", "version": "v1", "resource": "users"},
+		},
+		{
+			name:     "ExtractNoMatch",
+			actions:  []ActionKeyValue{{Key: "path", Action: EXTRACT, RegexPattern: `^/api/(?P<version>v\d+)/`}},
+			input:    map[string]string{"path": "/health"},
+			expected: map[string]string{"path": "/health"},
+		},
+		{
+			name:     "Truncate",
+			actions:  []ActionKeyValue{{Key: "url", Action: TRUNCATE, MaxLength: 10}},
+			input:    map[string]string{"url": "https://example.com/users"},
+			expected: map[string]string{"url": "https://ex"},
+		},
+		{
+			name:     "Convert",
+			actions:  []ActionKeyValue{{Key: "http.status_code", Action: CONVERT, ConvertedType: "int"}},
+			input:    map[string]string{"http.status_code": "200"},
+			expected: map[string]string{"http.status_code": "200"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ap, err := NewAttrProc(&Settings{Actions: tt.actions})
+			require.NoError(t, err)
+
+			labels := pdata.NewStringMap().InitFromMap(tt.input)
+			ap.ProcessLabels(labels)
+			assert.Equal(t, pdata.NewStringMap().InitFromMap(tt.expected).Sort(), labels.Sort())
+		})
+	}
+}
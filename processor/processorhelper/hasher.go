@@ -59,14 +59,19 @@ func sha1Hasher(attr pdata.AttributeValue) {
 
 	var hashed string
 	if len(val) > 0 {
-		// #nosec
-		h := sha1.New()
-		h.Write(val) // nolint: errcheck
-		val = h.Sum(nil)
-		hashedBytes := make([]byte, hex.EncodedLen(len(val)))
-		hex.Encode(hashedBytes, val)
-		hashed = string(hashedBytes)
+		hashed = sha1HexString(val)
 	}
 
 	attr.SetStringVal(hashed)
 }
+
+// sha1HexString returns the hex encoded SHA1 hash of val.
+func sha1HexString(val []byte) string {
+	// #nosec
+	h := sha1.New()
+	h.Write(val) // nolint: errcheck
+	val = h.Sum(nil)
+	hashedBytes := make([]byte, hex.EncodedLen(len(val)))
+	hex.Encode(hashedBytes, val)
+	return string(hashedBytes)
+}
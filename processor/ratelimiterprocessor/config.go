@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiterprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Action is what the processor does with the data over the rate limit.
+type Action string
+
+const (
+	// Drop removes the data over the rate limit, the rest of the data is sent to the next consumer.
+	Drop Action = "drop"
+	// Refuse rejects the whole data with a retryable error if any of it is over the rate limit.
+	Refuse Action = "refuse"
+)
+
+// Config defines configuration for the rate limiter processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Rate is the number of spans, log records or data points per second admitted for each key.
+	Rate float64 `mapstructure:"rate"`
+
+	// Burst is the maximum number of spans, log records or data points admitted at once for each key. Defaults to
+	// Rate, it must be larger than the size of the batches when Action is refuse.
+	Burst int `mapstructure:"burst"`
+
+	// KeyAttribute is the resource attribute whose values have independent rate limits, e.g. service.name.
+	// All the data shares the same rate limit if empty, as well as the resources without this attribute.
+	KeyAttribute string `mapstructure:"key_attribute"`
+
+	// Action is what is done with the data over the rate limit, drop (default) or refuse.
+	Action Action `mapstructure:"action"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Rate <= 0 {
+		return errors.New("rate must be positive")
+	}
+	if cfg.Burst < 0 {
+		return errors.New("burst must not be negative")
+	}
+	switch cfg.Action {
+	case Drop, Refuse:
+	default:
+		return fmt.Errorf("unsupported action %q, must be drop or refuse", cfg.Action)
+	}
+	return nil
+}
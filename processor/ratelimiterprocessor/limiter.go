@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiterprocessor
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// tokenBucket holds the tokens of a key, each token admits a span, log record or data point.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// limiter is a set of token buckets, one per key. They are refilled at the same rate up to the same burst.
+type limiter struct {
+	rate  float64
+	burst float64
+	// now is overridable by tests.
+	now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	if burst == 0 {
+		burst = int(math.Ceil(rate))
+	}
+	return &limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// take takes n tokens from the bucket of the key, and returns false if it does not have enough tokens.
+func (l *limiter) take(key string, n int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.refill(key, l.now())
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+// takeAll takes the given number of tokens from the bucket of each key, only if all of them have enough tokens.
+// It returns errRateLimited if a bucket does not have enough tokens, or a permanent error if more tokens than
+// the burst are needed since the bucket would never have enough.
+func (l *limiter) takeAll(counts map[string]int) error {
+	for key, n := range counts {
+		if float64(n) > l.burst {
+			return consumererror.Permanent(fmt.Errorf("%d items of key %q exceed the burst of %v", n, key, l.burst))
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	for key, n := range counts {
+		if l.refill(key, now).tokens < float64(n) {
+			return errRateLimited
+		}
+	}
+	for key, n := range counts {
+		l.buckets[key].tokens -= float64(n)
+	}
+	return nil
+}
+
+// refill adds the tokens accumulated since the last call to the bucket of the key, creating it if needed.
+// Must be called with the lock held.
+func (l *limiter) refill(key string, now time.Time) *tokenBucket {
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+		return b
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * l.rate
+		if b.tokens > l.burst {
+			b.tokens = l.burst
+		}
+		b.last = now
+	}
+	return b
+}
+
+// sweep removes, at most once per second, the buckets that are full since they are the same as new buckets.
+// This bounds the memory used by the keys that are not seen anymore. Must be called with the lock held.
+func (l *limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Second {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiterprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (fc *fakeClock) advance(d time.Duration) {
+	fc.now = fc.now.Add(d)
+}
+
+func newTestLimiter(rate float64, burst int) (*limiter, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	l := newLimiter(rate, burst)
+	l.now = func() time.Time { return clock.now }
+	return l, clock
+}
+
+func TestLimiter_Take(t *testing.T) {
+	l, clock := newTestLimiter(10, 20)
+
+	// New buckets are full.
+	assert.True(t, l.take("a", 15))
+	assert.False(t, l.take("a", 6))
+	assert.True(t, l.take("a", 5))
+	assert.False(t, l.take("a", 1))
+
+	// The keys are independent.
+	assert.True(t, l.take("b", 20))
+
+	clock.advance(500 * time.Millisecond)
+	assert.True(t, l.take("a", 5))
+	assert.False(t, l.take("a", 1))
+
+	// Refilled up to the burst.
+	clock.advance(time.Hour)
+	assert.False(t, l.take("a", 21))
+	assert.True(t, l.take("a", 20))
+}
+
+func TestLimiter_DefaultBurst(t *testing.T) {
+	l, clock := newTestLimiter(0.5, 0)
+	assert.True(t, l.take("", 1))
+	assert.False(t, l.take("", 1))
+
+	clock.advance(2 * time.Second)
+	assert.True(t, l.take("", 1))
+}
+
+func TestLimiter_TakeAll(t *testing.T) {
+	l, _ := newTestLimiter(10, 10)
+	assert.True(t, l.take("a", 8))
+
+	// Nothing is taken if one of the buckets does not have enough tokens.
+	assert.Equal(t, errRateLimited, l.takeAll(map[string]int{"a": 3, "b": 5}))
+	assert.NoError(t, l.takeAll(map[string]int{"a": 2, "b": 10}))
+	assert.False(t, l.take("a", 1))
+	assert.False(t, l.take("b", 1))
+}
+
+func TestLimiter_TakeAllOverBurst(t *testing.T) {
+	l, _ := newTestLimiter(10, 10)
+
+	// More tokens than the burst are never available, nothing is taken.
+	err := l.takeAll(map[string]int{"a": 1, "b": 11})
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.EqualError(t, err, `Permanent error: 11 items of key "b" exceed the burst of 10`)
+	assert.True(t, l.take("a", 10))
+}
+
+func TestLimiter_Sweep(t *testing.T) {
+	l, clock := newTestLimiter(10, 20)
+	assert.True(t, l.take("a", 20))
+	assert.True(t, l.take("b", 1))
+
+	// "b" is full again, it is removed.
+	clock.advance(time.Second)
+	assert.True(t, l.take("c", 1))
+	assert.Len(t, l.buckets, 2)
+	assert.Contains(t, l.buckets, "a")
+	assert.Contains(t, l.buckets, "c")
+}
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiterprocessor
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// errRateLimited is returned to the callers when the data is refused, it is not permanent so that they can retry.
+var errRateLimited = errors.New("data refused due to rate limit")
+
+type rateLimiterProcessor struct {
+	limiter      *limiter
+	keyAttribute string
+	action       Action
+	obsrep       *obsreport.Processor
+}
+
+func newRateLimiterProcessor(cfg *Config) *rateLimiterProcessor {
+	return &rateLimiterProcessor{
+		limiter:      newLimiter(cfg.Rate, cfg.Burst),
+		keyAttribute: cfg.KeyAttribute,
+		action:       cfg.Action,
+		obsrep: obsreport.NewProcessor(obsreport.ProcessorSettings{
+			Level:       configtelemetry.GetMetricsLevelFlagValue(),
+			ProcessorID: cfg.ID(),
+		}),
+	}
+}
+
+// key returns the key of the rate limit of the resource.
+func (rlp *rateLimiterProcessor) key(resource pdata.Resource) string {
+	if rlp.keyAttribute == "" {
+		return ""
+	}
+	if v, ok := resource.Attributes().Get(rlp.keyAttribute); ok {
+		return tracetranslator.AttributeValueToString(v)
+	}
+	return ""
+}
+
+// ProcessTraces implements the TProcessor interface
+func (rlp *rateLimiterProcessor) ProcessTraces(ctx context.Context, td pdata.Traces) (pdata.Traces, error) {
+	numSpans := td.SpanCount()
+	if rlp.action == Refuse {
+		counts := make(map[string]int)
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			rs := rss.At(i)
+			ilss := rs.InstrumentationLibrarySpans()
+			for j := 0; j < ilss.Len(); j++ {
+				counts[rlp.key(rs.Resource())] += ilss.At(j).Spans().Len()
+			}
+		}
+		if err := rlp.limiter.takeAll(counts); err != nil {
+			rlp.obsrep.TracesRefused(ctx, numSpans)
+			return td, err
+		}
+		rlp.obsrep.TracesAccepted(ctx, numSpans)
+		return td, nil
+	}
+
+	td.ResourceSpans().RemoveIf(func(rs pdata.ResourceSpans) bool {
+		key := rlp.key(rs.Resource())
+		rs.InstrumentationLibrarySpans().RemoveIf(func(ils pdata.InstrumentationLibrarySpans) bool {
+			ils.Spans().RemoveIf(func(pdata.Span) bool {
+				return !rlp.limiter.take(key, 1)
+			})
+			// Filter out empty InstrumentationLibrarySpans
+			return ils.Spans().Len() == 0
+		})
+		// Filter out empty ResourceSpans
+		return rs.InstrumentationLibrarySpans().Len() == 0
+	})
+	admitted := td.SpanCount()
+	rlp.obsrep.TracesAccepted(ctx, admitted)
+	if dropped := numSpans - admitted; dropped > 0 {
+		rlp.obsrep.TracesDropped(ctx, dropped)
+	}
+	if td.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+// ProcessMetrics implements the MProcessor interface. The data points of a metric are admitted or dropped together,
+// a metric with more data points than the burst is always dropped.
+func (rlp *rateLimiterProcessor) ProcessMetrics(ctx context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	_, numDataPoints := md.MetricAndDataPointCount()
+	if rlp.action == Refuse {
+		counts := make(map[string]int)
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			rm := rms.At(i)
+			ilms := rm.InstrumentationLibraryMetrics()
+			for j := 0; j < ilms.Len(); j++ {
+				metrics := ilms.At(j).Metrics()
+				for k := 0; k < metrics.Len(); k++ {
+					counts[rlp.key(rm.Resource())] += dataPointCount(metrics.At(k))
+				}
+			}
+		}
+		if err := rlp.limiter.takeAll(counts); err != nil {
+			rlp.obsrep.MetricsRefused(ctx, numDataPoints)
+			return md, err
+		}
+		rlp.obsrep.MetricsAccepted(ctx, numDataPoints)
+		return md, nil
+	}
+
+	md.ResourceMetrics().RemoveIf(func(rm pdata.ResourceMetrics) bool {
+		key := rlp.key(rm.Resource())
+		rm.InstrumentationLibraryMetrics().RemoveIf(func(ilm pdata.InstrumentationLibraryMetrics) bool {
+			ilm.Metrics().RemoveIf(func(m pdata.Metric) bool {
+				return !rlp.limiter.take(key, dataPointCount(m))
+			})
+			// Filter out empty InstrumentationLibraryMetrics
+			return ilm.Metrics().Len() == 0
+		})
+		// Filter out empty ResourceMetrics
+		return rm.InstrumentationLibraryMetrics().Len() == 0
+	})
+	_, admitted := md.MetricAndDataPointCount()
+	rlp.obsrep.MetricsAccepted(ctx, admitted)
+	if dropped := numDataPoints - admitted; dropped > 0 {
+		rlp.obsrep.MetricsDropped(ctx, dropped)
+	}
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// ProcessLogs implements the LProcessor interface
+func (rlp *rateLimiterProcessor) ProcessLogs(ctx context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	numRecords := ld.LogRecordCount()
+	if rlp.action == Refuse {
+		counts := make(map[string]int)
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			rl := rls.At(i)
+			ills := rl.InstrumentationLibraryLogs()
+			for j := 0; j < ills.Len(); j++ {
+				counts[rlp.key(rl.Resource())] += ills.At(j).Logs().Len()
+			}
+		}
+		if err := rlp.limiter.takeAll(counts); err != nil {
+			rlp.obsrep.LogsRefused(ctx, numRecords)
+			return ld, err
+		}
+		rlp.obsrep.LogsAccepted(ctx, numRecords)
+		return ld, nil
+	}
+
+	ld.ResourceLogs().RemoveIf(func(rl pdata.ResourceLogs) bool {
+		key := rlp.key(rl.Resource())
+		rl.InstrumentationLibraryLogs().RemoveIf(func(ill pdata.InstrumentationLibraryLogs) bool {
+			ill.Logs().RemoveIf(func(pdata.LogRecord) bool {
+				return !rlp.limiter.take(key, 1)
+			})
+			// Filter out empty InstrumentationLibraryLogs
+			return ill.Logs().Len() == 0
+		})
+		// Filter out empty ResourceLogs
+		return rl.InstrumentationLibraryLogs().Len() == 0
+	})
+	admitted := ld.LogRecordCount()
+	rlp.obsrep.LogsAccepted(ctx, admitted)
+	if dropped := numRecords - admitted; dropped > 0 {
+		rlp.obsrep.LogsDropped(ctx, dropped)
+	}
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+func dataPointCount(m pdata.Metric) int {
+	switch m.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		return m.IntGauge().DataPoints().Len()
+	case pdata.MetricDataTypeDoubleGauge:
+		return m.DoubleGauge().DataPoints().Len()
+	case pdata.MetricDataTypeIntSum:
+		return m.IntSum().DataPoints().Len()
+	case pdata.MetricDataTypeDoubleSum:
+		return m.DoubleSum().DataPoints().Len()
+	case pdata.MetricDataTypeIntHistogram:
+		return m.IntHistogram().DataPoints().Len()
+	case pdata.MetricDataTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pdata.MetricDataTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
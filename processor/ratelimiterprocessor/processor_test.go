@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiterprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+var testParams = component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+func newTestConfig(name string, burst int, keyAttribute string, action Action) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SetIDName(name)
+	// The rate is low enough to not refill the buckets during the tests.
+	cfg.Rate = 0.001
+	cfg.Burst = burst
+	cfg.KeyAttribute = keyAttribute
+	cfg.Action = action
+	return cfg
+}
+
+func genTraces(spansPerService map[string]int) pdata.Traces {
+	td := pdata.NewTraces()
+	for service, n := range spansPerService {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("service.name", service)
+		spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+		for i := 0; i < n; i++ {
+			spans.AppendEmpty().SetName(service)
+		}
+	}
+	return td
+}
+
+func genLogs(n int) pdata.Logs {
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for i := 0; i < n; i++ {
+		logs.AppendEmpty()
+	}
+	return ld
+}
+
+func genMetrics(numMetrics, pointsPerMetric int) pdata.Metrics {
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	for i := 0; i < numMetrics; i++ {
+		m := metrics.AppendEmpty()
+		m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		for j := 0; j < pointsPerMetric; j++ {
+			m.DoubleGauge().DataPoints().AppendEmpty().SetTimestamp(pdata.TimestampFromTime(time.Unix(int64(j), 0)))
+		}
+	}
+	return md
+}
+
+func TestRateLimiter_DropTracesPerKey(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	cfg := newTestConfig("drop_traces", 3, "service.name", Drop)
+	sink := new(consumertest.TracesSink)
+	tp, err := createTracesProcessor(context.Background(), testParams, cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), genTraces(map[string]int{"a": 5, "b": 2})))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), genTraces(map[string]int{"a": 1, "b": 2})))
+	require.Len(t, sink.AllTraces(), 2)
+	assert.Equal(t, 5, sink.AllTraces()[0].SpanCount())
+	assert.Equal(t, 1, sink.AllTraces()[1].SpanCount())
+	assert.Equal(t, "b", sink.AllTraces()[1].ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
+
+	obsreporttest.CheckProcessorTraces(t, cfg.ID(), 6, 0, 4)
+}
+
+func TestRateLimiter_RefuseTraces(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	cfg := newTestConfig("refuse_traces", 8, "service.name", Refuse)
+	sink := new(consumertest.TracesSink)
+	tp, err := createTracesProcessor(context.Background(), testParams, cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), genTraces(map[string]int{"a": 5, "b": 5})))
+	// The whole data is refused if one of the keys is over the limit, without taking the tokens of the others.
+	err = tp.ConsumeTraces(context.Background(), genTraces(map[string]int{"a": 4, "b": 1}))
+	assert.Equal(t, errRateLimited, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), genTraces(map[string]int{"b": 3})))
+	assert.Equal(t, 13, sink.SpansCount())
+
+	obsreporttest.CheckProcessorTraces(t, cfg.ID(), 13, 5, 0)
+}
+
+func TestRateLimiter_DropMetrics(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	cfg := newTestConfig("drop_metrics", 5, "", Drop)
+	sink := new(consumertest.MetricsSink)
+	mp, err := createMetricsProcessor(context.Background(), testParams, cfg, sink)
+	require.NoError(t, err)
+
+	// The data points of a metric are admitted or dropped together.
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), genMetrics(3, 2)))
+	require.Len(t, sink.AllMetrics(), 1)
+	metricCount, dataPointCount := sink.AllMetrics()[0].MetricAndDataPointCount()
+	assert.Equal(t, 2, metricCount)
+	assert.Equal(t, 4, dataPointCount)
+
+	obsreporttest.CheckProcessorMetrics(t, cfg.ID(), 4, 0, 2)
+}
+
+func TestRateLimiter_RefuseMetrics(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	cfg := newTestConfig("refuse_metrics", 5, "", Refuse)
+	sink := new(consumertest.MetricsSink)
+	mp, err := createMetricsProcessor(context.Background(), testParams, cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), genMetrics(1, 3)))
+	assert.Equal(t, errRateLimited, mp.ConsumeMetrics(context.Background(), genMetrics(1, 3)))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), genMetrics(1, 2)))
+	assert.Len(t, sink.AllMetrics(), 2)
+
+	obsreporttest.CheckProcessorMetrics(t, cfg.ID(), 5, 3, 0)
+}
+
+func TestRateLimiter_MetricOverBurst(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	dropCfg := newTestConfig("drop_over_burst", 5, "", Drop)
+	sink := new(consumertest.MetricsSink)
+	mp, err := createMetricsProcessor(context.Background(), testParams, dropCfg, sink)
+	require.NoError(t, err)
+
+	// A metric with more data points than the burst is dropped without taking the tokens.
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), genMetrics(1, 6)))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), genMetrics(1, 5)))
+	assert.Len(t, sink.AllMetrics(), 1)
+
+	refuseCfg := newTestConfig("refuse_over_burst", 5, "", Refuse)
+	mp, err = createMetricsProcessor(context.Background(), testParams, refuseCfg, sink)
+	require.NoError(t, err)
+
+	// A batch with more data points than the burst is refused permanently, it would never be admitted.
+	err = mp.ConsumeMetrics(context.Background(), genMetrics(2, 3))
+	assert.True(t, consumererror.IsPermanent(err))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), genMetrics(1, 5)))
+	assert.Len(t, sink.AllMetrics(), 2)
+
+	obsreporttest.CheckProcessorMetrics(t, dropCfg.ID(), 5, 0, 6)
+	obsreporttest.CheckProcessorMetrics(t, refuseCfg.ID(), 5, 6, 0)
+}
+
+func TestRateLimiter_DropLogs(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	cfg := newTestConfig("drop_logs", 4, "", Drop)
+	sink := new(consumertest.LogsSink)
+	lp, err := createLogsProcessor(context.Background(), testParams, cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, lp.ConsumeLogs(context.Background(), genLogs(3)))
+	require.NoError(t, lp.ConsumeLogs(context.Background(), genLogs(3)))
+	// Nothing is sent to the next consumer once the limit is reached.
+	require.NoError(t, lp.ConsumeLogs(context.Background(), genLogs(3)))
+	require.Len(t, sink.AllLogs(), 2)
+	assert.Equal(t, 4, sink.LogRecordsCount())
+
+	obsreporttest.CheckProcessorLogs(t, cfg.ID(), 4, 0, 5)
+}
+
+func TestRateLimiter_RefuseLogs(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	cfg := newTestConfig("refuse_logs", 4, "", Refuse)
+	sink := new(consumertest.LogsSink)
+	lp, err := createLogsProcessor(context.Background(), testParams, cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, lp.ConsumeLogs(context.Background(), genLogs(3)))
+	assert.Equal(t, errRateLimited, lp.ConsumeLogs(context.Background(), genLogs(3)))
+	assert.Equal(t, 3, sink.LogRecordsCount())
+
+	obsreporttest.CheckProcessorLogs(t, cfg.ID(), 3, 3, 0)
+}
+
+func TestRateLimiter_ResourceWithoutKey(t *testing.T) {
+	cfg := newTestConfig("no_key", 2, "service.name", Drop)
+	sink := new(consumertest.LogsSink)
+	lp, err := createLogsProcessor(context.Background(), testParams, cfg, sink)
+	require.NoError(t, err)
+
+	// The resources without the key attribute share the same rate limit.
+	require.NoError(t, lp.ConsumeLogs(context.Background(), genLogs(3)))
+	require.NoError(t, lp.ConsumeLogs(context.Background(), genLogs(3)))
+	assert.Equal(t, 2, sink.LogRecordsCount())
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Summary is the level of details of the summary of the redaction, recorded as attributes.
+type Summary string
+
+const (
+	// SummaryDebug records the names and the number of the redacted and masked attributes.
+	SummaryDebug Summary = "debug"
+	// SummaryInfo records the number of the redacted and masked attributes.
+	SummaryInfo Summary = "info"
+	// SummarySilent records nothing.
+	SummarySilent Summary = "silent"
+)
+
+// Config defines configuration for the redaction processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// AllowAllKeys disables the allow-list, only the values matching BlockedValues are masked.
+	AllowAllKeys bool `mapstructure:"allow_all_keys"`
+
+	// AllowedKeys are the keys of the attributes that are kept, all the other attributes are removed.
+	AllowedKeys []string `mapstructure:"allowed_keys"`
+
+	// BlockedValues are the regular expressions matching the parts of the string values that are masked, in the
+	// attributes that are kept and in the log bodies.
+	BlockedValues []string `mapstructure:"blocked_values"`
+
+	// Summary is the level of details of the summary recorded as attributes, debug, info (default) or silent.
+	Summary Summary `mapstructure:"summary"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.AllowAllKeys && len(cfg.AllowedKeys) > 0 {
+		return errors.New("allowed_keys cannot be set when allow_all_keys is true")
+	}
+	if !cfg.AllowAllKeys && len(cfg.AllowedKeys) == 0 {
+		return errors.New("allowed_keys must be set unless allow_all_keys is true")
+	}
+	if cfg.AllowAllKeys && len(cfg.BlockedValues) == 0 {
+		return errors.New("blocked_values must be set when allow_all_keys is true")
+	}
+	for i, pattern := range cfg.BlockedValues {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("blocked_values[%d]: %w", i, err)
+		}
+	}
+	switch cfg.Summary {
+	case SummaryDebug, SummaryInfo, SummarySilent:
+	default:
+		return fmt.Errorf("unsupported summary %q, must be debug, info or silent", cfg.Summary)
+	}
+	return nil
+}
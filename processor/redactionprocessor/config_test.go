@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+			AllowedKeys:       []string{"http.method", "http.route", "http.status_code"},
+			BlockedValues: []string{
+				`\b(?:\d[ -]*?){13,16}\b`,
+				`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`,
+			},
+			Summary: SummaryDebug,
+		}, cfg.Processors[config.NewID(typeStr)])
+
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "mask_only")),
+			AllowAllKeys:      true,
+			BlockedValues:     []string{`(?i)bearer\s+[a-z0-9._~+/-]+=*`},
+			Summary:           SummaryInfo,
+		}, cfg.Processors[config.NewIDWithName(typeStr, "mask_only")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "no allowed keys",
+			modify: func(cfg *Config) { cfg.AllowedKeys = nil },
+			err:    "allowed_keys must be set unless allow_all_keys is true",
+		},
+		{
+			name:   "allowed keys and allow all keys",
+			modify: func(cfg *Config) { cfg.AllowAllKeys = true },
+			err:    "allowed_keys cannot be set when allow_all_keys is true",
+		},
+		{
+			name: "allow all keys without blocked values",
+			modify: func(cfg *Config) {
+				cfg.AllowAllKeys = true
+				cfg.AllowedKeys = nil
+				cfg.BlockedValues = nil
+			},
+			err: "blocked_values must be set when allow_all_keys is true",
+		},
+		{
+			name:   "invalid blocked value",
+			modify: func(cfg *Config) { cfg.BlockedValues = []string{"valid", "("} },
+			err:    "blocked_values[1]: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:   "unsupported summary",
+			modify: func(cfg *Config) { cfg.Summary = "verbose" },
+			err:    `unsupported summary "verbose", must be debug, info or silent`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.AllowedKeys = []string{"http.method"}
+			cfg.BlockedValues = []string{"[0-9]{16}"}
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	// mask replaces the parts of the values matching a blocked value.
+	mask = "****"
+
+	redactedKeysAttribute  = "redaction.redacted.keys"
+	redactedCountAttribute = "redaction.redacted.count"
+	maskedKeysAttribute    = "redaction.masked.keys"
+	maskedCountAttribute   = "redaction.masked.count"
+	bodyMaskedAttribute    = "redaction.body.masked"
+)
+
+// redactionProcessor removes the attributes that are not allowed and masks the blocked values of the spans and the
+// log records.
+type redactionProcessor struct {
+	allowAllKeys  bool
+	allowedKeys   map[string]struct{}
+	blockedValues []*regexp.Regexp
+	summary       Summary
+}
+
+func newRedactionProcessor(cfg *Config) (*redactionProcessor, error) {
+	rp := &redactionProcessor{
+		allowAllKeys: cfg.AllowAllKeys,
+		allowedKeys:  make(map[string]struct{}, len(cfg.AllowedKeys)),
+		summary:      cfg.Summary,
+	}
+	for _, key := range cfg.AllowedKeys {
+		rp.allowedKeys[key] = struct{}{}
+	}
+	for _, pattern := range cfg.BlockedValues {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		rp.blockedValues = append(rp.blockedValues, re)
+	}
+	return rp, nil
+}
+
+// ProcessTraces redacts the attributes of the spans.
+func (rp *redactionProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				rp.processAttributes(spans.At(k).Attributes())
+			}
+		}
+	}
+	return td, nil
+}
+
+// ProcessLogs redacts the attributes and masks the bodies of the log records.
+func (rp *redactionProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				rp.processAttributes(lr.Attributes())
+				rp.processBody(lr)
+			}
+		}
+	}
+	return ld, nil
+}
+
+// processAttributes removes the attributes that are not allowed, masks the blocked values of the others, and records
+// the summary.
+func (rp *redactionProcessor) processAttributes(attrs pdata.AttributeMap) {
+	var redactedKeys, maskedKeys []string
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		if !rp.allowAllKeys {
+			if _, ok := rp.allowedKeys[k]; !ok {
+				redactedKeys = append(redactedKeys, k)
+				return true
+			}
+		}
+		if rp.maskValue(v) {
+			maskedKeys = append(maskedKeys, k)
+		}
+		return true
+	})
+	// The map cannot be modified while ranging over it.
+	for _, k := range redactedKeys {
+		attrs.Delete(k)
+	}
+
+	rp.addSummary(attrs, redactedKeysAttribute, redactedCountAttribute, redactedKeys)
+	rp.addSummary(attrs, maskedKeysAttribute, maskedCountAttribute, maskedKeys)
+}
+
+// processBody masks the blocked values of the body, including the strings nested in a map or array body.
+func (rp *redactionProcessor) processBody(lr pdata.LogRecord) {
+	if rp.maskValue(lr.Body()) && rp.summary != SummarySilent {
+		lr.Attributes().UpsertBool(bodyMaskedAttribute, true)
+	}
+}
+
+// maskValue masks the blocked values of a string, or of the strings nested in a map or array, and returns whether
+// the value changed.
+func (rp *redactionProcessor) maskValue(v pdata.AttributeValue) bool {
+	switch v.Type() {
+	case pdata.AttributeValueTypeString:
+		masked, ok := rp.mask(v.StringVal())
+		if ok {
+			v.SetStringVal(masked)
+		}
+		return ok
+	case pdata.AttributeValueTypeMap:
+		changed := false
+		v.MapVal().Range(func(_ string, nested pdata.AttributeValue) bool {
+			changed = rp.maskValue(nested) || changed
+			return true
+		})
+		return changed
+	case pdata.AttributeValueTypeArray:
+		changed := false
+		arr := v.ArrayVal()
+		for i := 0; i < arr.Len(); i++ {
+			changed = rp.maskValue(arr.At(i)) || changed
+		}
+		return changed
+	}
+	return false
+}
+
+// mask replaces the parts of the value matching the blocked values, and returns whether the value changed.
+func (rp *redactionProcessor) mask(value string) (string, bool) {
+	changed := false
+	for _, re := range rp.blockedValues {
+		if re.MatchString(value) {
+			value = re.ReplaceAllLiteralString(value, mask)
+			changed = true
+		}
+	}
+	return value, changed
+}
+
+// addSummary records the number of keys, and their names in debug, in the attributes.
+func (rp *redactionProcessor) addSummary(attrs pdata.AttributeMap, keysAttribute, countAttribute string, keys []string) {
+	if rp.summary == SummarySilent || len(keys) == 0 {
+		return
+	}
+	if rp.summary == SummaryDebug {
+		sort.Strings(keys)
+		attrs.UpsertString(keysAttribute, strings.Join(keys, ","))
+	}
+	attrs.UpsertInt(countAttribute, int64(len(keys)))
+}
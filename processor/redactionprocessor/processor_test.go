@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func newTestProcessor(t *testing.T, modify func(cfg *Config)) *redactionProcessor {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AllowedKeys = []string{"http.method", "http.url", "user.email", "http.status_code"}
+	cfg.BlockedValues = []string{
+		`\b(?:\d[ -]*?){13,16}\b`,
+		`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`,
+	}
+	modify(cfg)
+	require.NoError(t, cfg.Validate())
+	rp, err := newRedactionProcessor(cfg)
+	require.NoError(t, err)
+	return rp
+}
+
+func testAttributes() map[string]pdata.AttributeValue {
+	return map[string]pdata.AttributeValue{
+		"http.method":      pdata.NewAttributeValueString("POST"),
+		"http.url":         pdata.NewAttributeValueString("/pay?card=4111 1111 1111 1111"),
+		"http.status_code": pdata.NewAttributeValueInt(200),
+		"user.email":       pdata.NewAttributeValueString("john.doe@example.com"),
+		"user.id":          pdata.NewAttributeValueString("1234"),
+		"session.id":       pdata.NewAttributeValueString("abcd"),
+	}
+}
+
+func TestProcessTraces(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		expected map[string]pdata.AttributeValue
+	}{
+		{
+			name:   "info",
+			modify: func(cfg *Config) {},
+			expected: map[string]pdata.AttributeValue{
+				"http.method":              pdata.NewAttributeValueString("POST"),
+				"http.url":                 pdata.NewAttributeValueString("/pay?card=****"),
+				"http.status_code":         pdata.NewAttributeValueInt(200),
+				"user.email":               pdata.NewAttributeValueString("****"),
+				"redaction.redacted.count": pdata.NewAttributeValueInt(2),
+				"redaction.masked.count":   pdata.NewAttributeValueInt(2),
+			},
+		},
+		{
+			name:   "debug",
+			modify: func(cfg *Config) { cfg.Summary = SummaryDebug },
+			expected: map[string]pdata.AttributeValue{
+				"http.method":              pdata.NewAttributeValueString("POST"),
+				"http.url":                 pdata.NewAttributeValueString("/pay?card=****"),
+				"http.status_code":         pdata.NewAttributeValueInt(200),
+				"user.email":               pdata.NewAttributeValueString("****"),
+				"redaction.redacted.keys":  pdata.NewAttributeValueString("session.id,user.id"),
+				"redaction.redacted.count": pdata.NewAttributeValueInt(2),
+				"redaction.masked.keys":    pdata.NewAttributeValueString("http.url,user.email"),
+				"redaction.masked.count":   pdata.NewAttributeValueInt(2),
+			},
+		},
+		{
+			name:   "silent",
+			modify: func(cfg *Config) { cfg.Summary = SummarySilent },
+			expected: map[string]pdata.AttributeValue{
+				"http.method":      pdata.NewAttributeValueString("POST"),
+				"http.url":         pdata.NewAttributeValueString("/pay?card=****"),
+				"http.status_code": pdata.NewAttributeValueInt(200),
+				"user.email":       pdata.NewAttributeValueString("****"),
+			},
+		},
+		{
+			name: "allow all keys",
+			modify: func(cfg *Config) {
+				cfg.AllowAllKeys = true
+				cfg.AllowedKeys = nil
+			},
+			expected: map[string]pdata.AttributeValue{
+				"http.method":            pdata.NewAttributeValueString("POST"),
+				"http.url":               pdata.NewAttributeValueString("/pay?card=****"),
+				"http.status_code":       pdata.NewAttributeValueInt(200),
+				"user.email":             pdata.NewAttributeValueString("****"),
+				"user.id":                pdata.NewAttributeValueString("1234"),
+				"session.id":             pdata.NewAttributeValueString("abcd"),
+				"redaction.masked.count": pdata.NewAttributeValueInt(2),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := newTestProcessor(t, tt.modify)
+
+			td := pdata.NewTraces()
+			span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+			span.Attributes().InitFromMap(testAttributes())
+
+			td, err := rp.ProcessTraces(context.Background(), td)
+			require.NoError(t, err)
+			attrs := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()
+			assert.Equal(t, pdata.NewAttributeMap().InitFromMap(tt.expected).Sort(), attrs.Sort())
+		})
+	}
+}
+
+func TestProcessLogs(t *testing.T) {
+	rp := newTestProcessor(t, func(cfg *Config) {
+		cfg.BlockedValues = append(cfg.BlockedValues, `(?i)bearer\s+[a-z0-9._~+/-]+=*`)
+	})
+
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	lr := logs.AppendEmpty()
+	lr.Body().SetStringVal("login of john.doe@example.com with Authorization: Bearer abc.def-123")
+	lr.Attributes().InitFromMap(map[string]pdata.AttributeValue{
+		"http.method": pdata.NewAttributeValueString("POST"),
+		"password":    pdata.NewAttributeValueString("secret"),
+	})
+	// The scalar bodies that are not strings are not masked.
+	notString := logs.AppendEmpty()
+	notString.Body().SetIntVal(4111111111111111)
+
+	ld, err := rp.ProcessLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	logs = ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	assert.Equal(t, "login of **** with Authorization: ****", logs.At(0).Body().StringVal())
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"http.method":              pdata.NewAttributeValueString("POST"),
+		"redaction.redacted.count": pdata.NewAttributeValueInt(1),
+		"redaction.body.masked":    pdata.NewAttributeValueBool(true),
+	}).Sort(), logs.At(0).Attributes().Sort())
+
+	assert.Equal(t, int64(4111111111111111), logs.At(1).Body().IntVal())
+	assert.Equal(t, 0, logs.At(1).Attributes().Len())
+}
+
+func TestProcessLogsMapBody(t *testing.T) {
+	rp := newTestProcessor(t, func(cfg *Config) {})
+
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	pdata.NewAttributeValueMap().CopyTo(lr.Body())
+	body := lr.Body().MapVal()
+	body.InsertString("message", "login of john.doe@example.com")
+	body.InsertInt("status", 200)
+	cards := pdata.NewAttributeValueArray()
+	cards.ArrayVal().AppendEmpty().SetStringVal("4111 1111 1111 1111")
+	cards.ArrayVal().AppendEmpty().SetStringVal("none")
+	body.Insert("cards", cards)
+
+	ld, err := rp.ProcessLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	lr = ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	body = lr.Body().MapVal()
+	message, _ := body.Get("message")
+	assert.Equal(t, "login of ****", message.StringVal())
+	status, _ := body.Get("status")
+	assert.Equal(t, int64(200), status.IntVal())
+	cards, _ = body.Get("cards")
+	assert.Equal(t, "****", cards.ArrayVal().At(0).StringVal())
+	assert.Equal(t, "none", cards.ArrayVal().At(1).StringVal())
+	masked, _ := lr.Attributes().Get("redaction.body.masked")
+	assert.True(t, masked.BoolVal())
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the resource detection processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Detectors are the names of the detectors run at startup, "env", "system" or "container". When several
+	// detectors detect the same attribute, the value of the first one in the list is used.
+	Detectors []string `mapstructure:"detectors"`
+
+	// Override specifies if the detected attributes replace the attributes already present in the resources. If it
+	// is false, the detected attributes are only added to the resources that do not have them.
+	Override bool `mapstructure:"override"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Detectors) == 0 {
+		return errors.New("at least one detector must be specified")
+	}
+	seen := make(map[string]bool, len(cfg.Detectors))
+	for i, name := range cfg.Detectors {
+		if _, ok := detectors[name]; !ok {
+			return fmt.Errorf("detectors[%d]: unknown detector %q, must be env, system or container", i, name)
+		}
+		if seen[name] {
+			return fmt.Errorf("detectors[%d]: duplicated detector %q", i, name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
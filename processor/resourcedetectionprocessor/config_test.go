@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, createDefaultConfig(), cfg.Processors[config.NewID(typeStr)])
+
+	p1 := cfg.Processors[config.NewIDWithName(typeStr, "all")]
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName(typeStr, "all")),
+			Detectors:         []string{"env", "system", "container"},
+		}, p1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		detectors []string
+		err       string
+	}{
+		{
+			name: "no detectors",
+			err:  "at least one detector must be specified",
+		},
+		{
+			name:      "unknown detector",
+			detectors: []string{"env", "ec2"},
+			err:       `detectors[1]: unknown detector "ec2", must be env, system or container`,
+		},
+		{
+			name:      "duplicated detector",
+			detectors: []string{"env", "env"},
+			err:       `detectors[1]: duplicated detector "env"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Detectors = tt.detectors
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/shirou/gopsutil/host"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// detectFunc detects the attributes of the resource from the local environment.
+type detectFunc func(ctx context.Context) (pdata.AttributeMap, error)
+
+// detectors are the detectors that can be configured, by name.
+var detectors = map[string]detectFunc{
+	"env":       detectEnv,
+	"system":    detectSystem,
+	"container": detectContainer,
+}
+
+// envResourceAttributes is the environment variable holding the resource attributes, as a comma separated list of
+// key=value pairs.
+const envResourceAttributes = "OTEL_RESOURCE_ATTRIBUTES"
+
+// detectEnv detects the attributes set in the OTEL_RESOURCE_ATTRIBUTES environment variable.
+func detectEnv(context.Context) (pdata.AttributeMap, error) {
+	attrs := pdata.NewAttributeMap()
+	value := strings.TrimSpace(os.Getenv(envResourceAttributes))
+	if value == "" {
+		return attrs, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return attrs, fmt.Errorf("invalid %s pair %q, must be key=value", envResourceAttributes, pair)
+		}
+		// The values are percent-encoded to allow commas and equal signs.
+		v, err := url.PathUnescape(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return attrs, fmt.Errorf("invalid %s value of %q: %w", envResourceAttributes, kv[0], err)
+		}
+		attrs.UpsertString(strings.TrimSpace(kv[0]), v)
+	}
+	return attrs, nil
+}
+
+// hostnameFn and platformInformationFn are overridable by tests.
+var (
+	hostnameFn            = os.Hostname
+	platformInformationFn = host.PlatformInformationWithContext
+)
+
+// detectSystem detects the host name, the OS type and the OS version.
+func detectSystem(ctx context.Context) (pdata.AttributeMap, error) {
+	attrs := pdata.NewAttributeMap()
+	hostname, err := hostnameFn()
+	if err != nil {
+		return attrs, fmt.Errorf("failed to get the host name: %w", err)
+	}
+	attrs.InsertString(conventions.AttributeHostName, hostname)
+	attrs.InsertString(conventions.AttributeOSType, runtime.GOOS)
+
+	platform, _, version, err := platformInformationFn(ctx)
+	if err != nil {
+		return attrs, fmt.Errorf("failed to get the OS version: %w", err)
+	}
+	if description := strings.TrimSpace(platform + " " + version); description != "" {
+		attrs.InsertString(conventions.AttributeOSDescription, description)
+	}
+	return attrs, nil
+}
+
+// cgroupPath is overridable by tests.
+var cgroupPath = "/proc/self/cgroup"
+
+// containerIDRegexp matches the container ID at the end of a cgroup path, e.g. "/docker/<id>" or
+// "/kubepods.slice/.../docker-<id>.scope".
+var containerIDRegexp = regexp.MustCompile(`([0-9a-f]{64})(?:\.scope)?$`)
+
+// detectContainer detects the ID of the container the collector is running in, from its cgroups. No attribute is
+// detected when the collector is not running in a container, or not on Linux.
+func detectContainer(context.Context) (pdata.AttributeMap, error) {
+	attrs := pdata.NewAttributeMap()
+	f, err := os.Open(cgroupPath)
+	if os.IsNotExist(err) {
+		return attrs, nil
+	}
+	if err != nil {
+		return attrs, fmt.Errorf("failed to read the cgroups: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if match := containerIDRegexp.FindStringSubmatch(strings.TrimSpace(scanner.Text())); match != nil {
+			attrs.InsertString(conventions.AttributeContainerID, match[1])
+			return attrs, nil
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return attrs, fmt.Errorf("failed to read the cgroups: %w", err)
+	}
+	return attrs, nil
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func setEnv(t *testing.T, value string) {
+	require.NoError(t, os.Setenv(envResourceAttributes, value))
+	t.Cleanup(func() {
+		assert.NoError(t, os.Unsetenv(envResourceAttributes))
+	})
+}
+
+func TestDetectEnv(t *testing.T) {
+	setEnv(t, "service.name=checkout, deployment.environment = prod,team=a%2Cb%3Dc")
+	attrs, err := detectEnv(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		"service.name":           pdata.NewAttributeValueString("checkout"),
+		"deployment.environment": pdata.NewAttributeValueString("prod"),
+		"team":                   pdata.NewAttributeValueString("a,b=c"),
+	}).Sort(), attrs.Sort())
+}
+
+func TestDetectEnv_Empty(t *testing.T) {
+	setEnv(t, "")
+	attrs, err := detectEnv(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, attrs.Len())
+}
+
+func TestDetectEnv_Invalid(t *testing.T) {
+	setEnv(t, "service.name=checkout,invalid")
+	_, err := detectEnv(context.Background())
+	assert.EqualError(t, err, `invalid OTEL_RESOURCE_ATTRIBUTES pair "invalid", must be key=value`)
+
+	setEnv(t, "team=%zz")
+	_, err = detectEnv(context.Background())
+	assert.EqualError(t, err, `invalid OTEL_RESOURCE_ATTRIBUTES value of "team": invalid URL escape "%zz"`)
+}
+
+func TestDetectSystem(t *testing.T) {
+	defer func(hostname func() (string, error), platformInformation func(context.Context) (string, string, string, error)) {
+		hostnameFn = hostname
+		platformInformationFn = platformInformation
+	}(hostnameFn, platformInformationFn)
+
+	hostnameFn = func() (string, error) { return "host-1", nil }
+	platformInformationFn = func(context.Context) (string, string, string, error) { return "ubuntu", "debian", "20.04", nil }
+	attrs, err := detectSystem(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, pdata.NewAttributeMap().InitFromMap(map[string]pdata.AttributeValue{
+		conventions.AttributeHostName:      pdata.NewAttributeValueString("host-1"),
+		conventions.AttributeOSType:        pdata.NewAttributeValueString(runtime.GOOS),
+		conventions.AttributeOSDescription: pdata.NewAttributeValueString("ubuntu 20.04"),
+	}).Sort(), attrs.Sort())
+
+	hostnameFn = func() (string, error) { return "", errors.New("no host name") }
+	_, err = detectSystem(context.Background())
+	assert.EqualError(t, err, "failed to get the host name: no host name")
+}
+
+func TestDetectContainer(t *testing.T) {
+	defer func(path string) { cgroupPath = path }(cgroupPath)
+	const containerID = "a3b9f9c8c1d1f2b1e0d5c6a7b8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7"
+
+	tests := []struct {
+		name   string
+		cgroup string
+		want   string
+	}{
+		{
+			name:   "docker",
+			cgroup: "12:cpuset:/docker/" + containerID + "\n11:memory:/docker/" + containerID + "\n",
+			want:   containerID,
+		},
+		{
+			name:   "kubernetes",
+			cgroup: "1:name=systemd:/kubepods.slice/kubepods-pod1.slice/docker-" + containerID + ".scope\n",
+			want:   containerID,
+		},
+		{
+			name:   "not in a container",
+			cgroup: "12:cpuset:/\n0::/user.slice/user-1000.slice/session-1.scope\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cgroupPath = filepath.Join(t.TempDir(), "cgroup")
+			require.NoError(t, ioutil.WriteFile(cgroupPath, []byte(tt.cgroup), 0600))
+
+			attrs, err := detectContainer(context.Background())
+			require.NoError(t, err)
+			id, ok := attrs.Get(conventions.AttributeContainerID)
+			assert.Equal(t, tt.want != "", ok)
+			if ok {
+				assert.Equal(t, tt.want, id.StringVal())
+			}
+		})
+	}
+
+	// Not on Linux.
+	cgroupPath = filepath.Join(t.TempDir(), "missing")
+	attrs, err := detectContainer(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, attrs.Len())
+}
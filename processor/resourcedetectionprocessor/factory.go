@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "resourcedetection"
+)
+
+var processorCapabilities = consumer.Capabilities{MutatesData: true}
+
+// NewFactory returns a new factory for the resource detection processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTracesProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
+		processorhelper.WithLogs(createLogsProcessor))
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+		Detectors:         []string{"env"},
+		Override:          true,
+	}
+}
+
+func createTracesProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	rdp := newResourceDetectionProcessor(params.Logger, cfg.(*Config))
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		nextConsumer,
+		rdp,
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(rdp.start))
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg config.Processor,
+	nextConsumer consumer.Metrics,
+) (component.MetricsProcessor, error) {
+	rdp := newResourceDetectionProcessor(params.Logger, cfg.(*Config))
+	return processorhelper.NewMetricsProcessor(
+		cfg,
+		nextConsumer,
+		rdp,
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(rdp.start))
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg config.Processor,
+	nextConsumer consumer.Logs,
+) (component.LogsProcessor, error) {
+	rdp := newResourceDetectionProcessor(params.Logger, cfg.(*Config))
+	return processorhelper.NewLogsProcessor(
+		cfg,
+		nextConsumer,
+		rdp,
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(rdp.start))
+}
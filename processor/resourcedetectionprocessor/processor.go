@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+type resourceDetectionProcessor struct {
+	logger   *zap.Logger
+	names    []string
+	override bool
+	// detected holds the attributes detected at startup.
+	detected pdata.AttributeMap
+}
+
+func newResourceDetectionProcessor(logger *zap.Logger, cfg *Config) *resourceDetectionProcessor {
+	return &resourceDetectionProcessor{
+		logger:   logger,
+		names:    cfg.Detectors,
+		override: cfg.Override,
+		detected: pdata.NewAttributeMap(),
+	}
+}
+
+// start runs the detectors, the attributes of the first detectors take precedence.
+func (rdp *resourceDetectionProcessor) start(ctx context.Context, _ component.Host) error {
+	for _, name := range rdp.names {
+		attrs, err := detectors[name](ctx)
+		if err != nil {
+			return fmt.Errorf("failed to detect the resource with the %q detector: %w", name, err)
+		}
+		attrs.Range(func(k string, v pdata.AttributeValue) bool {
+			rdp.detected.Insert(k, v)
+			return true
+		})
+	}
+	detected := make(map[string]string, rdp.detected.Len())
+	rdp.detected.Range(func(k string, v pdata.AttributeValue) bool {
+		detected[k] = tracetranslator.AttributeValueToString(v)
+		return true
+	})
+	rdp.logger.Info("Detected resource attributes.", zap.Any("attributes", detected))
+	return nil
+}
+
+// ProcessTraces implements the TProcessor interface
+func (rdp *resourceDetectionProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rdp.merge(rss.At(i).Resource())
+	}
+	return td, nil
+}
+
+// ProcessMetrics implements the MProcessor interface
+func (rdp *resourceDetectionProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rdp.merge(rms.At(i).Resource())
+	}
+	return md, nil
+}
+
+// ProcessLogs implements the LProcessor interface
+func (rdp *resourceDetectionProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rdp.merge(rls.At(i).Resource())
+	}
+	return ld, nil
+}
+
+// merge adds the detected attributes to the resource, replacing the existing ones if override is true.
+func (rdp *resourceDetectionProcessor) merge(resource pdata.Resource) {
+	attrs := resource.Attributes()
+	rdp.detected.Range(func(k string, v pdata.AttributeValue) bool {
+		if rdp.override {
+			attrs.Upsert(k, v)
+		} else {
+			attrs.Insert(k, v)
+		}
+		return true
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var testParams = component.ProcessorCreateParams{Logger: zap.NewNop()}
+
+// withTestDetectors replaces the detectors by detectors returning the given attributes or errors.
+func withTestDetectors(t *testing.T, results map[string]interface{}) {
+	saved := detectors
+	detectors = make(map[string]detectFunc)
+	for name, result := range results {
+		result := result
+		detectors[name] = func(context.Context) (pdata.AttributeMap, error) {
+			if err, ok := result.(error); ok {
+				return pdata.NewAttributeMap(), err
+			}
+			return pdata.NewAttributeMap().InitFromMap(result.(map[string]pdata.AttributeValue)), nil
+		}
+	}
+	t.Cleanup(func() { detectors = saved })
+}
+
+func genResource(attrs map[string]pdata.AttributeValue) pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().InitFromMap(attrs)
+	return td
+}
+
+func TestResourceDetection_Merge(t *testing.T) {
+	withTestDetectors(t, map[string]interface{}{
+		"env": map[string]pdata.AttributeValue{
+			"host.name":              pdata.NewAttributeValueString("env-host"),
+			"deployment.environment": pdata.NewAttributeValueString("prod"),
+		},
+		"system": map[string]pdata.AttributeValue{
+			"host.name": pdata.NewAttributeValueString("system-host"),
+			"os.type":   pdata.NewAttributeValueString("linux"),
+		},
+	})
+
+	tests := []struct {
+		name     string
+		override bool
+		want     map[string]pdata.AttributeValue
+	}{
+		{
+			name:     "override",
+			override: true,
+			want: map[string]pdata.AttributeValue{
+				"host.name":              pdata.NewAttributeValueString("env-host"),
+				"deployment.environment": pdata.NewAttributeValueString("prod"),
+				"os.type":                pdata.NewAttributeValueString("linux"),
+				"service.name":           pdata.NewAttributeValueString("checkout"),
+			},
+		},
+		{
+			name: "merge",
+			want: map[string]pdata.AttributeValue{
+				"host.name":              pdata.NewAttributeValueString("app-host"),
+				"deployment.environment": pdata.NewAttributeValueString("prod"),
+				"os.type":                pdata.NewAttributeValueString("linux"),
+				"service.name":           pdata.NewAttributeValueString("checkout"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Detectors = []string{"env", "system"}
+			cfg.Override = tt.override
+			sink := new(consumertest.TracesSink)
+			tp, err := createTracesProcessor(context.Background(), testParams, cfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+
+			require.NoError(t, tp.ConsumeTraces(context.Background(), genResource(map[string]pdata.AttributeValue{
+				"host.name":    pdata.NewAttributeValueString("app-host"),
+				"service.name": pdata.NewAttributeValueString("checkout"),
+			})))
+			require.Len(t, sink.AllTraces(), 1)
+			assert.Equal(t,
+				pdata.NewAttributeMap().InitFromMap(tt.want).Sort(),
+				sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Sort())
+		})
+	}
+}
+
+func TestResourceDetection_MetricsAndLogs(t *testing.T) {
+	withTestDetectors(t, map[string]interface{}{
+		"env": map[string]pdata.AttributeValue{"deployment.environment": pdata.NewAttributeValueString("prod")},
+	})
+	cfg := createDefaultConfig()
+
+	metricsSink := new(consumertest.MetricsSink)
+	mp, err := createMetricsProcessor(context.Background(), testParams, cfg, metricsSink)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+	_, ok := metricsSink.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().Get("deployment.environment")
+	assert.True(t, ok)
+
+	logsSink := new(consumertest.LogsSink)
+	lp, err := createLogsProcessor(context.Background(), testParams, cfg, logsSink)
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().AppendEmpty()
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+	_, ok = logsSink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Get("deployment.environment")
+	assert.True(t, ok)
+}
+
+func TestResourceDetection_StartError(t *testing.T) {
+	withTestDetectors(t, map[string]interface{}{
+		"env": errors.New("invalid"),
+	})
+
+	tp, err := createTracesProcessor(context.Background(), testParams, createDefaultConfig(), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, tp.Start(context.Background(), componenttest.NewNopHost()), `failed to detect the resource with the "env" detector: invalid`)
+}
@@ -24,7 +24,8 @@ type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
 	// AttributesActions specifies the list of actions to be applied on resource attributes.
-	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT}.
+	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT,
+	// CONVERT, TRUNCATE, COPY, MOVE}.
 	AttributesActions []processorhelper.ActionKeyValue `mapstructure:"attributes"`
 }
 
@@ -73,6 +73,9 @@ func createMetricsProcessor(
 	if err != nil {
 		return nil, err
 	}
+	if attrProc.UsesTargets() {
+		return nil, fmt.Errorf("error creating \"%v\" processor: copy and move actions are not supported for metrics", cfg.ID())
+	}
 	return processorhelper.NewMetricsProcessor(
 		cfg,
 		nextConsumer,
@@ -29,7 +29,18 @@ type resourceProcessor struct {
 func (rp *resourceProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
 	rss := td.ResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
-		rp.attrProc.Process(rss.At(i).Resource().Attributes())
+		rs := rss.At(i)
+		var targets []pdata.AttributeMap
+		if rp.attrProc.UsesTargets() {
+			ilss := rs.InstrumentationLibrarySpans()
+			for j := 0; j < ilss.Len(); j++ {
+				spans := ilss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					targets = append(targets, spans.At(k).Attributes())
+				}
+			}
+		}
+		rp.attrProc.Process(rs.Resource().Attributes(), targets...)
 	}
 	return td, nil
 }
@@ -47,7 +58,18 @@ func (rp *resourceProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics)
 func (rp *resourceProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
 	rls := ld.ResourceLogs()
 	for i := 0; i < rls.Len(); i++ {
-		rp.attrProc.Process(rls.At(i).Resource().Attributes())
+		rl := rls.At(i)
+		var targets []pdata.AttributeMap
+		if rp.attrProc.UsesTargets() {
+			ills := rl.InstrumentationLibraryLogs()
+			for j := 0; j < ills.Len(); j++ {
+				logs := ills.At(j).Logs()
+				for k := 0; k < logs.Len(); k++ {
+					targets = append(targets, logs.At(k).Attributes())
+				}
+			}
+		}
+		rp.attrProc.Process(rl.Resource().Attributes(), targets...)
 	}
 	return ld, nil
 }
@@ -170,6 +170,56 @@ func TestResourceProcessorError(t *testing.T) {
 	require.Nil(t, rlp)
 }
 
+func TestResourceProcessorCopyToSpansAndLogs(t *testing.T) {
+	copyCfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+		AttributesActions: []processorhelper.ActionKeyValue{
+			{Key: "service.version", Action: processorhelper.TRUNCATE, MaxLength: 5},
+			{Key: "service.version", Action: processorhelper.COPY},
+			{Key: "k8s-cluster", Action: processorhelper.MOVE, ToAttribute: "k8s.cluster.name"},
+		},
+	}
+	sourceAttributes := map[string]string{"service.version": "1.2.3-beta", "k8s-cluster": "test-cluster"}
+	factory := NewFactory()
+
+	ttn := new(consumertest.TracesSink)
+	rtp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{}, copyCfg, ttn)
+	require.NoError(t, err)
+	require.NoError(t, rtp.ConsumeTraces(context.Background(), generateTraceData(sourceAttributes)))
+	traces := ttn.AllTraces()
+	require.Len(t, traces, 1)
+	rs := traces[0].ResourceSpans().At(0)
+	assert.Equal(t, map[string]string{"service.version": "1.2.3"}, attributesToMap(rs.Resource().Attributes()))
+	spanAttrs := attributesToMap(rs.InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes())
+	assert.Equal(t, "1.2.3", spanAttrs["service.version"])
+	assert.Equal(t, "test-cluster", spanAttrs["k8s.cluster.name"])
+
+	tln := new(consumertest.LogsSink)
+	rlp, err := factory.CreateLogsProcessor(context.Background(), component.ProcessorCreateParams{}, copyCfg, tln)
+	require.NoError(t, err)
+	require.NoError(t, rlp.ConsumeLogs(context.Background(), generateLogData(sourceAttributes)))
+	logs := tln.AllLogs()
+	require.Len(t, logs, 1)
+	rl := logs[0].ResourceLogs().At(0)
+	assert.Equal(t, map[string]string{"service.version": "1.2.3"}, attributesToMap(rl.Resource().Attributes()))
+	logAttrs := attributesToMap(rl.InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes())
+	assert.Equal(t, "1.2.3", logAttrs["service.version"])
+	assert.Equal(t, "test-cluster", logAttrs["k8s.cluster.name"])
+
+	rmp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, copyCfg, consumertest.NewNop())
+	assert.EqualError(t, err, `error creating "resource" processor: copy and move actions are not supported for metrics`)
+	assert.Nil(t, rmp)
+}
+
+func attributesToMap(attrs pdata.AttributeMap) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		m[k] = v.StringVal()
+		return true
+	})
+	return m
+}
+
 func generateTraceData(attributes map[string]string) pdata.Traces {
 	td := testdata.GenerateTracesOneSpanNoResource()
 	if attributes == nil {
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// AttributeSource is where the value used for routing is read from.
+type AttributeSource string
+
+const (
+	// ContextAttributeSource reads the value from the metadata of the incoming request.
+	ContextAttributeSource AttributeSource = "context"
+	// ResourceAttributeSource reads the value from the resource attributes.
+	ResourceAttributeSource AttributeSource = "resource"
+)
+
+// Config defines configuration for the routing processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// AttributeSource is context, to route by a header of the incoming request, or resource, to route by a
+	// resource attribute. Defaults to context.
+	AttributeSource AttributeSource `mapstructure:"attribute_source"`
+
+	// FromAttribute is the name of the header or resource attribute whose value selects the route.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// DefaultExporters are the IDs of the exporters that receive the data without value, or with a value that is
+	// not in the Table. The data is dropped if not set.
+	DefaultExporters []string `mapstructure:"default_exporters"`
+
+	// Table maps the values to the exporters.
+	Table []RoutingTableItem `mapstructure:"table"`
+}
+
+// RoutingTableItem is a route of the routing table.
+type RoutingTableItem struct {
+	// Value is the value of the attribute routed to the Exporters.
+	Value string `mapstructure:"value"`
+
+	// Exporters are the IDs of the exporters that receive the data with the Value.
+	Exporters []string `mapstructure:"exporters"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	switch cfg.AttributeSource {
+	case ContextAttributeSource, ResourceAttributeSource:
+	default:
+		return fmt.Errorf("unsupported attribute_source %q, must be context or resource", cfg.AttributeSource)
+	}
+	if cfg.FromAttribute == "" {
+		return errors.New("from_attribute must be specified")
+	}
+	if err := validateExporters(cfg.DefaultExporters); err != nil {
+		return fmt.Errorf("default_exporters: %w", err)
+	}
+	if len(cfg.Table) == 0 {
+		return errors.New("table must have at least one route")
+	}
+	seen := make(map[string]bool, len(cfg.Table))
+	for i, item := range cfg.Table {
+		if item.Value == "" {
+			return fmt.Errorf("table[%d] must have a value", i)
+		}
+		if seen[item.Value] {
+			return fmt.Errorf("table[%d] duplicates value %q", i, item.Value)
+		}
+		seen[item.Value] = true
+		if len(item.Exporters) == 0 {
+			return fmt.Errorf("table[%d] must have at least one exporter", i)
+		}
+		if err := validateExporters(item.Exporters); err != nil {
+			return fmt.Errorf("table[%d] exporters: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateExporters(exporters []string) error {
+	for _, exporter := range exporters {
+		if _, err := config.NewIDFromString(exporter); err != nil {
+			return fmt.Errorf("invalid exporter %q: %w", exporter, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+			AttributeSource:   ResourceAttributeSource,
+			FromAttribute:     "tenant",
+			DefaultExporters:  []string{"nop"},
+			Table: []RoutingTableItem{
+				{Value: "acme", Exporters: []string{"nop/acme"}},
+				{Value: "globex", Exporters: []string{"nop/globex", "nop"}},
+			},
+		}, cfg.Processors[config.NewID(typeStr)])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "attribute source",
+			modify: func(cfg *Config) { cfg.AttributeSource = "span" },
+			err:    `unsupported attribute_source "span", must be context or resource`,
+		},
+		{
+			name:   "no attribute",
+			modify: func(cfg *Config) { cfg.FromAttribute = "" },
+			err:    "from_attribute must be specified",
+		},
+		{
+			name:   "invalid default exporter",
+			modify: func(cfg *Config) { cfg.DefaultExporters = []string{"otlp/"} },
+			err:    `default_exporters: invalid exporter "otlp/": name part must be specified after / in type/name key`,
+		},
+		{
+			name:   "empty table",
+			modify: func(cfg *Config) { cfg.Table = nil },
+			err:    "table must have at least one route",
+		},
+		{
+			name:   "no value",
+			modify: func(cfg *Config) { cfg.Table[0].Value = "" },
+			err:    "table[0] must have a value",
+		},
+		{
+			name:   "duplicated value",
+			modify: func(cfg *Config) { cfg.Table = append(cfg.Table, cfg.Table[0]) },
+			err:    `table[1] duplicates value "acme"`,
+		},
+		{
+			name:   "no exporters",
+			modify: func(cfg *Config) { cfg.Table[0].Exporters = nil },
+			err:    "table[0] must have at least one exporter",
+		},
+		{
+			name:   "invalid exporter",
+			modify: func(cfg *Config) { cfg.Table[0].Exporters = []string{""} },
+			err:    `table[0] exporters: invalid exporter "": idStr must have non empty type`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.FromAttribute = "tenant"
+			cfg.Table = []RoutingTableItem{{Value: "acme", Exporters: []string{"otlp/acme"}}}
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// routedExporter is an exporter of a route.
+type routedExporter struct {
+	id       config.ComponentID
+	exporter component.Exporter
+	// mutatesData is true if the data must be cloned before it is sent to the exporter, since it may be shared
+	// with other exporters or pipelines.
+	mutatesData bool
+}
+
+// route is the list of exporters that receive the data with a value.
+type route struct {
+	exporters []routedExporter
+}
+
+// routingProcessor sends the data to the exporters of the route selected by the value of a header of the incoming
+// request, or of a resource attribute. The data is not sent to the next consumer.
+type routingProcessor struct {
+	cfg      *Config
+	dataType config.DataType
+
+	defaultRoute *route
+	routes       map[string]*route
+}
+
+var (
+	_ component.TracesProcessor  = (*routingProcessor)(nil)
+	_ component.MetricsProcessor = (*routingProcessor)(nil)
+	_ component.LogsProcessor    = (*routingProcessor)(nil)
+)
+
+func newRoutingProcessor(cfg *Config, dataType config.DataType) *routingProcessor {
+	return &routingProcessor{
+		cfg:      cfg,
+		dataType: dataType,
+	}
+}
+
+// Start looks up the exporters of the routing table. They are only available from the host.
+func (rp *routingProcessor) Start(_ context.Context, host component.Host) error {
+	exporters := host.GetExporters()[rp.dataType]
+
+	var err error
+	if rp.defaultRoute, err = rp.newRoute(exporters, rp.cfg.DefaultExporters); err != nil {
+		return err
+	}
+	rp.routes = make(map[string]*route, len(rp.cfg.Table))
+	for _, item := range rp.cfg.Table {
+		if rp.routes[item.Value], err = rp.newRoute(exporters, item.Exporters); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rp *routingProcessor) newRoute(exporters map[config.ComponentID]component.Exporter, ids []string) (*route, error) {
+	r := &route{exporters: make([]routedExporter, 0, len(ids))}
+	for _, idStr := range ids {
+		id, err := config.NewIDFromString(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exporter %q: %w", idStr, err)
+		}
+		exporter, ok := exporters[id]
+		if !ok {
+			return nil, fmt.Errorf("exporter %q is not used in any %s pipeline", idStr, rp.dataType)
+		}
+		r.exporters = append(r.exporters, routedExporter{id: id, exporter: exporter, mutatesData: mutatesData(exporter)})
+	}
+	return r, nil
+}
+
+// mutatesData returns whether the exporter modifies the data it consumes.
+func mutatesData(exporter component.Exporter) bool {
+	if c, ok := exporter.(interface{ Capabilities() consumer.Capabilities }); ok {
+		return c.Capabilities().MutatesData
+	}
+	return false
+}
+
+// Shutdown does nothing, the exporters are shut down by their pipelines.
+func (rp *routingProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// Capabilities returns the consumer capabilities of the processor, the data is not modified.
+func (rp *routingProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// routeFor returns the route of the value, or the default route if there is none.
+func (rp *routingProcessor) routeFor(value string) *route {
+	if r, ok := rp.routes[value]; ok {
+		return r
+	}
+	return rp.defaultRoute
+}
+
+// routeFromContext returns the route of the first value of the header in the metadata of the incoming request.
+func (rp *routingProcessor) routeFromContext(ctx context.Context) *route {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return rp.defaultRoute
+	}
+	values := md.Get(strings.ToLower(rp.cfg.FromAttribute))
+	if len(values) == 0 {
+		return rp.defaultRoute
+	}
+	return rp.routeFor(values[0])
+}
+
+// groupByResource returns the routes of the resources, in order of first appearance, and the indexes of the
+// resources of each route. The resources without route are dropped.
+func (rp *routingProcessor) groupByResource(n int, resourceAt func(i int) pdata.Resource) ([]*route, map[*route][]int) {
+	var routes []*route
+	indexes := make(map[*route][]int)
+	for i := 0; i < n; i++ {
+		r := rp.defaultRoute
+		if value, ok := resourceAt(i).Attributes().Get(rp.cfg.FromAttribute); ok {
+			r = rp.routeFor(tracetranslator.AttributeValueToString(value))
+		}
+		if len(r.exporters) == 0 {
+			continue
+		}
+		if _, ok := indexes[r]; !ok {
+			routes = append(routes, r)
+		}
+		indexes[r] = append(indexes[r], i)
+	}
+	return routes, indexes
+}
+
+// ConsumeTraces sends the spans to the exporters of their route.
+func (rp *routingProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if rp.cfg.AttributeSource == ContextAttributeSource {
+		return rp.routeFromContext(ctx).sendTraces(ctx, td)
+	}
+
+	rss := td.ResourceSpans()
+	routes, indexes := rp.groupByResource(rss.Len(), func(i int) pdata.Resource { return rss.At(i).Resource() })
+	if len(routes) == 1 && len(indexes[routes[0]]) == rss.Len() {
+		return routes[0].sendTraces(ctx, td)
+	}
+	var errs []error
+	for _, r := range routes {
+		group := pdata.NewTraces()
+		for _, i := range indexes[r] {
+			rss.At(i).CopyTo(group.ResourceSpans().AppendEmpty())
+		}
+		if err := r.sendTraces(ctx, group); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+// ConsumeMetrics sends the metrics to the exporters of their route.
+func (rp *routingProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if rp.cfg.AttributeSource == ContextAttributeSource {
+		return rp.routeFromContext(ctx).sendMetrics(ctx, md)
+	}
+
+	rms := md.ResourceMetrics()
+	routes, indexes := rp.groupByResource(rms.Len(), func(i int) pdata.Resource { return rms.At(i).Resource() })
+	if len(routes) == 1 && len(indexes[routes[0]]) == rms.Len() {
+		return routes[0].sendMetrics(ctx, md)
+	}
+	var errs []error
+	for _, r := range routes {
+		group := pdata.NewMetrics()
+		for _, i := range indexes[r] {
+			rms.At(i).CopyTo(group.ResourceMetrics().AppendEmpty())
+		}
+		if err := r.sendMetrics(ctx, group); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+// ConsumeLogs sends the logs to the exporters of their route.
+func (rp *routingProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if rp.cfg.AttributeSource == ContextAttributeSource {
+		return rp.routeFromContext(ctx).sendLogs(ctx, ld)
+	}
+
+	rls := ld.ResourceLogs()
+	routes, indexes := rp.groupByResource(rls.Len(), func(i int) pdata.Resource { return rls.At(i).Resource() })
+	if len(routes) == 1 && len(indexes[routes[0]]) == rls.Len() {
+		return routes[0].sendLogs(ctx, ld)
+	}
+	var errs []error
+	for _, r := range routes {
+		group := pdata.NewLogs()
+		for _, i := range indexes[r] {
+			rls.At(i).CopyTo(group.ResourceLogs().AppendEmpty())
+		}
+		if err := r.sendLogs(ctx, group); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+func (r *route) sendTraces(ctx context.Context, td pdata.Traces) error {
+	var errs []error
+	for _, e := range r.exporters {
+		data := td
+		if e.mutatesData {
+			data = td.Clone()
+		}
+		if err := e.exporter.(consumer.Traces).ConsumeTraces(ctx, data); err != nil {
+			errs = append(errs, fmt.Errorf("failed to export to %q: %w", e.id, err))
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+func (r *route) sendMetrics(ctx context.Context, md pdata.Metrics) error {
+	var errs []error
+	for _, e := range r.exporters {
+		data := md
+		if e.mutatesData {
+			data = md.Clone()
+		}
+		if err := e.exporter.(consumer.Metrics).ConsumeMetrics(ctx, data); err != nil {
+			errs = append(errs, fmt.Errorf("failed to export to %q: %w", e.id, err))
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+func (r *route) sendLogs(ctx context.Context, ld pdata.Logs) error {
+	var errs []error
+	for _, e := range r.exporters {
+		data := ld
+		if e.mutatesData {
+			data = ld.Clone()
+		}
+		if err := e.exporter.(consumer.Logs).ConsumeLogs(ctx, data); err != nil {
+			errs = append(errs, fmt.Errorf("failed to export to %q: %w", e.id, err))
+		}
+	}
+	return consumererror.Combine(errs)
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+// mockBackend records the data sent to the exporter with the same name.
+type mockBackend struct {
+	traces  consumertest.TracesSink
+	metrics consumertest.MetricsSink
+	logs    consumertest.LogsSink
+}
+
+type mockHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (mh *mockHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return mh.exporters
+}
+
+func newMockHost(t *testing.T, backends map[string]*mockBackend) component.Host {
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.TracesDataType:  {},
+			config.MetricsDataType: {},
+			config.LogsDataType:    {},
+		},
+	}
+	for name, backend := range backends {
+		exporterCfg := config.NewExporterSettings(config.NewIDWithName("mock", name))
+		te, err := exporterhelper.NewTracesExporter(&exporterCfg, zap.NewNop(), backend.traces.ConsumeTraces)
+		require.NoError(t, err)
+		host.exporters[config.TracesDataType][exporterCfg.ID()] = te
+		me, err := exporterhelper.NewMetricsExporter(&exporterCfg, zap.NewNop(), backend.metrics.ConsumeMetrics)
+		require.NoError(t, err)
+		host.exporters[config.MetricsDataType][exporterCfg.ID()] = me
+		le, err := exporterhelper.NewLogsExporter(&exporterCfg, zap.NewNop(), backend.logs.ConsumeLogs)
+		require.NoError(t, err)
+		host.exporters[config.LogsDataType][exporterCfg.ID()] = le
+	}
+	return host
+}
+
+func newTestConfig(source AttributeSource) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AttributeSource = source
+	cfg.FromAttribute = "X-Tenant"
+	cfg.DefaultExporters = []string{"mock/default"}
+	cfg.Table = []RoutingTableItem{
+		{Value: "acme", Exporters: []string{"mock/acme"}},
+		{Value: "globex", Exporters: []string{"mock/globex", "mock/default"}},
+	}
+	return cfg
+}
+
+func startTestProcessor(t *testing.T, cfg *Config, dataType config.DataType) (*routingProcessor, map[string]*mockBackend) {
+	backends := map[string]*mockBackend{"default": {}, "acme": {}, "globex": {}}
+	rp := newRoutingProcessor(cfg, dataType)
+	require.NoError(t, rp.Start(context.Background(), newMockHost(t, backends)))
+	t.Cleanup(func() {
+		assert.NoError(t, rp.Shutdown(context.Background()))
+	})
+	return rp, backends
+}
+
+func withTenant(tenant string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", tenant))
+}
+
+func TestRouting_Context(t *testing.T) {
+	rp, backends := startTestProcessor(t, newTestConfig(ContextAttributeSource), config.TracesDataType)
+
+	require.NoError(t, rp.ConsumeTraces(withTenant("acme"), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, 1, backends["acme"].traces.SpansCount())
+	assert.Equal(t, 0, backends["default"].traces.SpansCount())
+
+	require.NoError(t, rp.ConsumeTraces(withTenant("globex"), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, 1, backends["globex"].traces.SpansCount())
+	assert.Equal(t, 1, backends["default"].traces.SpansCount())
+
+	// Unknown value, and no metadata.
+	require.NoError(t, rp.ConsumeTraces(withTenant("initech"), testdata.GenerateTracesOneSpan()))
+	require.NoError(t, rp.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, 3, backends["default"].traces.SpansCount())
+	assert.Equal(t, 1, backends["acme"].traces.SpansCount())
+}
+
+func TestRouting_NoDefaultExporters(t *testing.T) {
+	cfg := newTestConfig(ContextAttributeSource)
+	cfg.DefaultExporters = nil
+	rp, backends := startTestProcessor(t, cfg, config.TracesDataType)
+
+	// Dropped.
+	require.NoError(t, rp.ConsumeTraces(withTenant("initech"), testdata.GenerateTracesOneSpan()))
+	for _, backend := range backends {
+		assert.Equal(t, 0, backend.traces.SpansCount())
+	}
+}
+
+func TestRouting_ResourceTraces(t *testing.T) {
+	rp, backends := startTestProcessor(t, newTestConfig(ResourceAttributeSource), config.TracesDataType)
+
+	td := testdata.GenerateTracesManySpansSameResource(2)
+	td.ResourceSpans().At(0).Resource().Attributes().InsertString("X-Tenant", "acme")
+	// All the resources have the same route, sent as is.
+	require.NoError(t, rp.ConsumeTraces(context.Background(), td))
+	require.Len(t, backends["acme"].traces.AllTraces(), 1)
+	assert.Equal(t, td, backends["acme"].traces.AllTraces()[0])
+
+	td = pdata.NewTraces()
+	for _, tenant := range []string{"acme", "globex", "acme", ""} {
+		testdata.GenerateTracesOneSpan().ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+		if tenant != "" {
+			td.ResourceSpans().At(td.ResourceSpans().Len()-1).Resource().Attributes().InsertString("X-Tenant", tenant)
+		}
+	}
+	require.NoError(t, rp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 4, backends["acme"].traces.SpansCount())
+	assert.Equal(t, 2, backends["acme"].traces.AllTraces()[1].ResourceSpans().Len())
+	assert.Equal(t, 1, backends["globex"].traces.SpansCount())
+	assert.Equal(t, 2, backends["default"].traces.SpansCount())
+	// The input is not modified.
+	assert.Equal(t, 4, td.SpanCount())
+}
+
+func TestRouting_ResourceMetrics(t *testing.T) {
+	rp, backends := startTestProcessor(t, newTestConfig(ResourceAttributeSource), config.MetricsDataType)
+
+	md := testdata.GenerateMetricsOneMetric()
+	testdata.GenerateMetricsOneMetric().ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	md.ResourceMetrics().At(0).Resource().Attributes().InsertString("X-Tenant", "acme")
+	require.NoError(t, rp.ConsumeMetrics(context.Background(), md))
+	assert.Equal(t, 1, backends["acme"].metrics.MetricsCount())
+	assert.Equal(t, 1, backends["default"].metrics.MetricsCount())
+	assert.Equal(t, 0, backends["globex"].metrics.MetricsCount())
+}
+
+func TestRouting_ResourceLogs(t *testing.T) {
+	rp, backends := startTestProcessor(t, newTestConfig(ResourceAttributeSource), config.LogsDataType)
+
+	ld := testdata.GenerateLogsOneLogRecord()
+	testdata.GenerateLogsOneLogRecord().ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+	ld.ResourceLogs().At(1).Resource().Attributes().InsertString("X-Tenant", "globex")
+	require.NoError(t, rp.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, 0, backends["acme"].logs.LogRecordsCount())
+	assert.Equal(t, 1, backends["globex"].logs.LogRecordsCount())
+	assert.Equal(t, 2, backends["default"].logs.LogRecordsCount())
+}
+
+func TestRouting_ContextMetricsAndLogs(t *testing.T) {
+	mp, backends := startTestProcessor(t, newTestConfig(ContextAttributeSource), config.MetricsDataType)
+	require.NoError(t, mp.ConsumeMetrics(withTenant("acme"), testdata.GenerateMetricsOneMetric()))
+	assert.Equal(t, 1, backends["acme"].metrics.MetricsCount())
+
+	lp, backends := startTestProcessor(t, newTestConfig(ContextAttributeSource), config.LogsDataType)
+	require.NoError(t, lp.ConsumeLogs(withTenant("acme"), testdata.GenerateLogsOneLogRecord()))
+	assert.Equal(t, 1, backends["acme"].logs.LogRecordsCount())
+}
+
+func TestRouting_ExporterNotFound(t *testing.T) {
+	cfg := newTestConfig(ContextAttributeSource)
+	cfg.Table[0].Exporters = []string{"otlp"}
+	rp := newRoutingProcessor(cfg, config.MetricsDataType)
+	err := rp.Start(context.Background(), newMockHost(t, map[string]*mockBackend{"default": {}, "globex": {}}))
+	assert.EqualError(t, err, `exporter "otlp" is not used in any metrics pipeline`)
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the span metrics processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MetricsExporter is the ID of the exporter that receives the metrics, it must be part of a metrics pipeline.
+	MetricsExporter string `mapstructure:"metrics_exporter"`
+
+	// LatencyHistogramBuckets are the upper bounds of the buckets of the latency histogram, from 2ms to 15s if
+	// not set.
+	LatencyHistogramBuckets []time.Duration `mapstructure:"latency_histogram_buckets"`
+
+	// Dimensions are the span attributes, or else resource attributes, added as labels to the metrics in addition
+	// to the service name, span name, kind and status code.
+	Dimensions []Dimension `mapstructure:"dimensions"`
+
+	// FlushInterval is the interval at which the metrics are sent to the exporter.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+
+	// MaxSeries is the maximum number of label combinations held in memory. The spans with a new combination
+	// are not aggregated when it is reached.
+	MaxSeries int `mapstructure:"max_series"`
+
+	// SeriesExpiration is the duration after which a label combination without spans is removed, its metrics
+	// start again from zero if new spans arrive. The combinations never expire if it is 0.
+	SeriesExpiration time.Duration `mapstructure:"series_expiration"`
+}
+
+// Dimension is an attribute added as a label to the metrics.
+type Dimension struct {
+	// Name is the key of the attribute.
+	Name string `mapstructure:"name"`
+	// Default is the value of the label if the attribute is missing. The label is omitted if it is not set.
+	Default *string `mapstructure:"default"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.MetricsExporter == "" {
+		return errors.New("metrics_exporter must be specified")
+	}
+	if _, err := config.NewIDFromString(cfg.MetricsExporter); err != nil {
+		return fmt.Errorf("invalid metrics_exporter %q: %w", cfg.MetricsExporter, err)
+	}
+	for i := 1; i < len(cfg.LatencyHistogramBuckets); i++ {
+		if cfg.LatencyHistogramBuckets[i] <= cfg.LatencyHistogramBuckets[i-1] {
+			return errors.New("latency_histogram_buckets must be in increasing order")
+		}
+	}
+	seen := make(map[string]bool, len(cfg.Dimensions))
+	for i, d := range cfg.Dimensions {
+		if d.Name == "" {
+			return fmt.Errorf("dimensions[%d] must have a name", i)
+		}
+		if seen[d.Name] || isReservedLabel(d.Name) {
+			return fmt.Errorf("dimensions[%d] duplicates label %q", i, d.Name)
+		}
+		seen[d.Name] = true
+	}
+	if cfg.FlushInterval <= 0 {
+		return errors.New("flush_interval must be positive")
+	}
+	if cfg.MaxSeries <= 0 {
+		return errors.New("max_series must be positive")
+	}
+	if cfg.SeriesExpiration < 0 {
+		return errors.New("series_expiration must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	get := "GET"
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings:       config.NewProcessorSettings(config.NewID(typeStr)),
+			MetricsExporter:         "nop/metrics",
+			LatencyHistogramBuckets: []time.Duration{time.Millisecond, 10 * time.Millisecond, 100 * time.Millisecond, time.Second},
+			Dimensions: []Dimension{
+				{Name: "http.method", Default: &get},
+				{Name: "deployment.environment"},
+			},
+			FlushInterval:    30 * time.Second,
+			MaxSeries:        1000,
+			SeriesExpiration: 10 * time.Minute,
+		}, cfg.Processors[config.NewID(typeStr)])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "no exporter",
+			modify: func(cfg *Config) { cfg.MetricsExporter = "" },
+			err:    "metrics_exporter must be specified",
+		},
+		{
+			name:   "invalid exporter",
+			modify: func(cfg *Config) { cfg.MetricsExporter = "prometheus/" },
+			err:    `invalid metrics_exporter "prometheus/": name part must be specified after / in type/name key`,
+		},
+		{
+			name:   "unordered buckets",
+			modify: func(cfg *Config) { cfg.LatencyHistogramBuckets = []time.Duration{time.Second, time.Millisecond} },
+			err:    "latency_histogram_buckets must be in increasing order",
+		},
+		{
+			name:   "dimension without name",
+			modify: func(cfg *Config) { cfg.Dimensions = []Dimension{{}} },
+			err:    "dimensions[0] must have a name",
+		},
+		{
+			name:   "duplicated dimension",
+			modify: func(cfg *Config) { cfg.Dimensions = []Dimension{{Name: "http.method"}, {Name: "http.method"}} },
+			err:    `dimensions[1] duplicates label "http.method"`,
+		},
+		{
+			name:   "reserved dimension",
+			modify: func(cfg *Config) { cfg.Dimensions = []Dimension{{Name: "operation"}} },
+			err:    `dimensions[0] duplicates label "operation"`,
+		},
+		{
+			name:   "flush interval",
+			modify: func(cfg *Config) { cfg.FlushInterval = 0 },
+			err:    "flush_interval must be positive",
+		},
+		{
+			name:   "max series",
+			modify: func(cfg *Config) { cfg.MaxSeries = 0 },
+			err:    "max_series must be positive",
+		},
+		{
+			name:   "series expiration",
+			modify: func(cfg *Config) { cfg.SeriesExpiration = -time.Second },
+			err:    "series_expiration must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.MetricsExporter = "prometheus"
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
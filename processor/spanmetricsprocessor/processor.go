@@ -0,0 +1,338 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	// Names of the metrics.
+	callsMetricName   = "calls_total"
+	latencyMetricName = "latency"
+
+	// Labels of every data point, in addition to the dimensions.
+	serviceNameLabel = conventions.AttributeServiceName
+	operationLabel   = "operation"
+	spanKindLabel    = "span.kind"
+	statusCodeLabel  = "status.code"
+)
+
+// isReservedLabel returns whether the label is set on every data point, it cannot be a dimension.
+func isReservedLabel(label string) bool {
+	switch label {
+	case serviceNameLabel, operationLabel, spanKindLabel, statusCodeLabel:
+		return true
+	}
+	return false
+}
+
+// aggregate holds the calls and latencies of the spans with the same labels, since its creation.
+type aggregate struct {
+	labels       map[string]string
+	calls        int64
+	latencySum   float64
+	bucketCounts []uint64
+	startTime    time.Time
+	// lastSpan is the time of the last aggregated span, for the expiration.
+	lastSpan time.Time
+}
+
+// spanMetricsProcessor aggregates the spans by labels into a calls counter and a latency histogram, sent to the
+// metrics exporter every FlushInterval, and passes the spans through unchanged.
+type spanMetricsProcessor struct {
+	nextConsumer  consumer.Traces
+	logger        *zap.Logger
+	exporterID    string
+	dimensions    []Dimension
+	flushInterval time.Duration
+	// bounds are the bounds of the latency histogram, in milliseconds.
+	bounds           []float64
+	maxSeries        int
+	seriesExpiration time.Duration
+	now              func() time.Time
+
+	exporter component.MetricsExporter
+
+	mu         sync.Mutex
+	aggregates map[string]*aggregate
+	// keys of the aggregates in creation order, for a stable order of the data points.
+	keys []string
+	// droppedSpans is the number of spans not aggregated because of MaxSeries since the last flush.
+	droppedSpans int
+
+	stopCh chan struct{}
+	stopWg sync.WaitGroup
+}
+
+var _ component.TracesProcessor = (*spanMetricsProcessor)(nil)
+
+func newSpanMetricsProcessor(params component.ProcessorCreateParams, nextConsumer consumer.Traces, cfg *Config) *spanMetricsProcessor {
+	buckets := cfg.LatencyHistogramBuckets
+	if len(buckets) == 0 {
+		buckets = defaultLatencyHistogramBuckets
+	}
+	bounds := make([]float64, len(buckets))
+	for i, b := range buckets {
+		bounds[i] = durationToMillis(b)
+	}
+	return &spanMetricsProcessor{
+		nextConsumer:     nextConsumer,
+		logger:           params.Logger,
+		exporterID:       cfg.MetricsExporter,
+		dimensions:       cfg.Dimensions,
+		flushInterval:    cfg.FlushInterval,
+		bounds:           bounds,
+		maxSeries:        cfg.MaxSeries,
+		seriesExpiration: cfg.SeriesExpiration,
+		now:              time.Now,
+		aggregates:       make(map[string]*aggregate),
+		stopCh:           make(chan struct{}),
+	}
+}
+
+// Start looks up the metrics exporter and starts the flushes.
+func (sp *spanMetricsProcessor) Start(_ context.Context, host component.Host) error {
+	id, err := config.NewIDFromString(sp.exporterID)
+	if err != nil {
+		return fmt.Errorf("invalid metrics_exporter %q: %w", sp.exporterID, err)
+	}
+	exporter, ok := host.GetExporters()[config.MetricsDataType][id]
+	if !ok {
+		return fmt.Errorf("metrics_exporter %q is not used in any %s pipeline", sp.exporterID, config.MetricsDataType)
+	}
+	sp.exporter = exporter.(component.MetricsExporter)
+
+	sp.stopWg.Add(1)
+	go func() {
+		defer sp.stopWg.Done()
+		ticker := time.NewTicker(sp.flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := sp.flush(context.Background()); err != nil {
+					sp.logger.Warn("Failed to send the span metrics.", zap.Error(err))
+				}
+			case <-sp.stopCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the flushes and sends the metrics a last time.
+func (sp *spanMetricsProcessor) Shutdown(ctx context.Context) error {
+	if sp.exporter == nil {
+		return nil
+	}
+	close(sp.stopCh)
+	sp.stopWg.Wait()
+	return sp.flush(ctx)
+}
+
+// Capabilities returns the consumer capabilities of the processor, the spans are not modified.
+func (sp *spanMetricsProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces aggregates the spans and sends them to the next consumer.
+func (sp *spanMetricsProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	sp.aggregateSpans(td)
+	return sp.nextConsumer.ConsumeTraces(ctx, td)
+}
+
+func (sp *spanMetricsProcessor) aggregateSpans(td pdata.Traces) {
+	now := sp.now()
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceAttrs := rs.Resource().Attributes()
+		serviceName := ""
+		if v, ok := resourceAttrs.Get(conventions.AttributeServiceName); ok {
+			serviceName = v.StringVal()
+		}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				agg, ok := sp.getAggregate(sp.spanLabels(serviceName, span, resourceAttrs), now)
+				if !ok {
+					sp.droppedSpans++
+					continue
+				}
+				agg.lastSpan = now
+				latency := durationToMillis(span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime()))
+				agg.calls++
+				agg.latencySum += latency
+				agg.bucketCounts[sort.SearchFloat64s(sp.bounds, latency)]++
+			}
+		}
+	}
+}
+
+// spanLabels returns the labels of the data points of the span.
+func (sp *spanMetricsProcessor) spanLabels(serviceName string, span pdata.Span, resourceAttrs pdata.AttributeMap) map[string]string {
+	labels := map[string]string{
+		serviceNameLabel: serviceName,
+		operationLabel:   span.Name(),
+		spanKindLabel:    span.Kind().String(),
+		statusCodeLabel:  span.Status().Code().String(),
+	}
+	for _, d := range sp.dimensions {
+		if v, ok := span.Attributes().Get(d.Name); ok {
+			labels[d.Name] = tracetranslator.AttributeValueToString(v)
+		} else if v, ok := resourceAttrs.Get(d.Name); ok {
+			labels[d.Name] = tracetranslator.AttributeValueToString(v)
+		} else if d.Default != nil {
+			labels[d.Name] = *d.Default
+		}
+	}
+	return labels
+}
+
+// getAggregate returns the aggregate of the labels, it is created if needed and MaxSeries is not reached.
+func (sp *spanMetricsProcessor) getAggregate(labels map[string]string, now time.Time) (*aggregate, bool) {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(0)
+		b.WriteString(labels[name])
+		b.WriteByte(0)
+	}
+	key := b.String()
+
+	agg, ok := sp.aggregates[key]
+	if !ok {
+		if len(sp.keys) >= sp.maxSeries {
+			return nil, false
+		}
+		agg = &aggregate{labels: labels, bucketCounts: make([]uint64, len(sp.bounds)+1), startTime: now}
+		sp.aggregates[key] = agg
+		sp.keys = append(sp.keys, key)
+	}
+	return agg, true
+}
+
+// removeExpired removes the aggregates without spans for SeriesExpiration.
+func (sp *spanMetricsProcessor) removeExpired(now time.Time) {
+	if sp.seriesExpiration == 0 {
+		return
+	}
+	kept := sp.keys[:0]
+	for _, key := range sp.keys {
+		if now.Sub(sp.aggregates[key].lastSpan) >= sp.seriesExpiration {
+			delete(sp.aggregates, key)
+			continue
+		}
+		kept = append(kept, key)
+	}
+	sp.keys = kept
+}
+
+// flush sends the cumulative metrics to the exporter.
+func (sp *spanMetricsProcessor) flush(ctx context.Context) error {
+	md, ok := sp.buildMetrics()
+	if !ok {
+		return nil
+	}
+	return sp.exporter.ConsumeMetrics(ctx, md)
+}
+
+// buildMetrics returns the calls and latency metrics after removing the expired aggregates, or false if there is
+// no aggregate.
+func (sp *spanMetricsProcessor) buildMetrics() (pdata.Metrics, bool) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	if sp.droppedSpans > 0 {
+		sp.logger.Warn("Spans were not aggregated, max_series is reached.", zap.Int("spans", sp.droppedSpans))
+		sp.droppedSpans = 0
+	}
+	nowTime := sp.now()
+	sp.removeExpired(nowTime)
+	if len(sp.keys) == 0 {
+		return pdata.Metrics{}, false
+	}
+	now := pdata.TimestampFromTime(nowTime)
+
+	md := pdata.NewMetrics()
+	ilm := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName(typeStr)
+
+	calls := ilm.Metrics().AppendEmpty()
+	calls.SetName(callsMetricName)
+	calls.SetDescription("Number of spans")
+	calls.SetUnit("1")
+	calls.SetDataType(pdata.MetricDataTypeIntSum)
+	calls.IntSum().SetIsMonotonic(true)
+	calls.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+	latency := ilm.Metrics().AppendEmpty()
+	latency.SetName(latencyMetricName)
+	latency.SetDescription("Duration of the spans")
+	latency.SetUnit("ms")
+	latency.SetDataType(pdata.MetricDataTypeHistogram)
+	latency.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+	for _, key := range sp.keys {
+		agg := sp.aggregates[key]
+		startTime := pdata.TimestampFromTime(agg.startTime)
+
+		callsDP := calls.IntSum().DataPoints().AppendEmpty()
+		callsDP.LabelsMap().InitFromMap(agg.labels)
+		callsDP.SetStartTimestamp(startTime)
+		callsDP.SetTimestamp(now)
+		callsDP.SetValue(agg.calls)
+
+		latencyDP := latency.Histogram().DataPoints().AppendEmpty()
+		latencyDP.LabelsMap().InitFromMap(agg.labels)
+		latencyDP.SetStartTimestamp(startTime)
+		latencyDP.SetTimestamp(now)
+		latencyDP.SetCount(uint64(agg.calls))
+		latencyDP.SetSum(agg.latencySum)
+		latencyDP.SetBucketCounts(append([]uint64(nil), agg.bucketCounts...))
+		latencyDP.SetExplicitBounds(sp.bounds)
+	}
+	return md, true
+}
+
+func durationToMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,263 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+type mockHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (mh *mockHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return mh.exporters
+}
+
+func newMockHost(t *testing.T, sink *consumertest.MetricsSink) component.Host {
+	exporterCfg := config.NewExporterSettings(config.NewIDWithName("mock", "metrics"))
+	exp, err := exporterhelper.NewMetricsExporter(&exporterCfg, zap.NewNop(), sink.ConsumeMetrics)
+	require.NoError(t, err)
+	return &mockHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.MetricsDataType: {exporterCfg.ID(): exp},
+		},
+	}
+}
+
+func newTestConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "mock/metrics"
+	cfg.LatencyHistogramBuckets = []time.Duration{10 * time.Millisecond, 100 * time.Millisecond}
+	cfg.FlushInterval = time.Hour
+	return cfg
+}
+
+// appendSpan adds a span of the service to the traces, and returns it.
+func appendSpan(td pdata.Traces, service, name string, latency time.Duration) pdata.Span {
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, service)
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName(name)
+	span.SetKind(pdata.SpanKindServer)
+	start := time.Unix(1600000000, 0)
+	span.SetStartTimestamp(pdata.TimestampFromTime(start))
+	span.SetEndTimestamp(pdata.TimestampFromTime(start.Add(latency)))
+	return span
+}
+
+func TestSpanMetrics_Aggregation(t *testing.T) {
+	metricsSink := &consumertest.MetricsSink{}
+	tracesSink := &consumertest.TracesSink{}
+	sp := newSpanMetricsProcessor(component.ProcessorCreateParams{Logger: zap.NewNop()}, tracesSink, newTestConfig())
+	require.NoError(t, sp.Start(context.Background(), newMockHost(t, metricsSink)))
+
+	td := pdata.NewTraces()
+	appendSpan(td, "checkout", "GET /cart", 5*time.Millisecond)
+	appendSpan(td, "checkout", "GET /cart", 50*time.Millisecond)
+	appendSpan(td, "checkout", "GET /cart", time.Second).Status().SetCode(pdata.StatusCodeError)
+	require.NoError(t, sp.ConsumeTraces(context.Background(), td))
+	td = pdata.NewTraces()
+	appendSpan(td, "checkout", "GET /cart", 500*time.Millisecond)
+	require.NoError(t, sp.ConsumeTraces(context.Background(), td))
+
+	// The spans are passed through.
+	assert.Equal(t, 4, tracesSink.SpansCount())
+	require.NoError(t, sp.Shutdown(context.Background()))
+
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	metrics := metricsSink.AllMetrics()[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	calls := metrics.At(0)
+	assert.Equal(t, callsMetricName, calls.Name())
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, calls.IntSum().AggregationTemporality())
+	require.Equal(t, 2, calls.IntSum().DataPoints().Len())
+	okCalls := calls.IntSum().DataPoints().At(0)
+	assert.Equal(t, int64(3), okCalls.Value())
+	assert.Equal(t, map[string]string{
+		serviceNameLabel: "checkout",
+		operationLabel:   "GET /cart",
+		spanKindLabel:    "SPAN_KIND_SERVER",
+		statusCodeLabel:  "STATUS_CODE_UNSET",
+	}, labelsOf(okCalls.LabelsMap()))
+	errorCalls := calls.IntSum().DataPoints().At(1)
+	assert.Equal(t, int64(1), errorCalls.Value())
+	assert.Equal(t, "STATUS_CODE_ERROR", labelsOf(errorCalls.LabelsMap())[statusCodeLabel])
+
+	latency := metrics.At(1)
+	assert.Equal(t, latencyMetricName, latency.Name())
+	require.Equal(t, 2, latency.Histogram().DataPoints().Len())
+	okLatency := latency.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(3), okLatency.Count())
+	assert.Equal(t, float64(555), okLatency.Sum())
+	assert.Equal(t, []float64{10, 100}, okLatency.ExplicitBounds())
+	assert.Equal(t, []uint64{1, 1, 1}, okLatency.BucketCounts())
+	assert.Equal(t, []uint64{0, 0, 1}, latency.Histogram().DataPoints().At(1).BucketCounts())
+}
+
+func TestSpanMetrics_Dimensions(t *testing.T) {
+	metricsSink := &consumertest.MetricsSink{}
+	cfg := newTestConfig()
+	get := "GET"
+	cfg.Dimensions = []Dimension{
+		{Name: "http.method", Default: &get},
+		{Name: "deployment.environment"},
+	}
+	sp := newSpanMetricsProcessor(component.ProcessorCreateParams{Logger: zap.NewNop()}, consumertest.NewNop(), cfg)
+	require.NoError(t, sp.Start(context.Background(), newMockHost(t, metricsSink)))
+
+	td := pdata.NewTraces()
+	// Span attribute.
+	appendSpan(td, "checkout", "cart", time.Millisecond).Attributes().InsertString("http.method", "POST")
+	// Default value, and resource attribute.
+	appendSpan(td, "checkout", "cart", time.Millisecond)
+	td.ResourceSpans().At(1).Resource().Attributes().InsertString("deployment.environment", "production")
+	require.NoError(t, sp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, sp.Shutdown(context.Background()))
+
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	dps := metricsSink.AllMetrics()[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	labels := labelsOf(dps.At(0).LabelsMap())
+	assert.Equal(t, "POST", labels["http.method"])
+	assert.NotContains(t, labels, "deployment.environment")
+	labels = labelsOf(dps.At(1).LabelsMap())
+	assert.Equal(t, "GET", labels["http.method"])
+	assert.Equal(t, "production", labels["deployment.environment"])
+}
+
+func TestSpanMetrics_PeriodicFlush(t *testing.T) {
+	metricsSink := &consumertest.MetricsSink{}
+	cfg := newTestConfig()
+	cfg.FlushInterval = 10 * time.Millisecond
+	sp := newSpanMetricsProcessor(component.ProcessorCreateParams{Logger: zap.NewNop()}, consumertest.NewNop(), cfg)
+	require.NoError(t, sp.Start(context.Background(), newMockHost(t, metricsSink)))
+	defer func() {
+		assert.NoError(t, sp.Shutdown(context.Background()))
+	}()
+
+	td := pdata.NewTraces()
+	appendSpan(td, "checkout", "cart", time.Millisecond)
+	require.NoError(t, sp.ConsumeTraces(context.Background(), td))
+
+	assert.Eventually(t, func() bool {
+		return len(metricsSink.AllMetrics()) >= 2
+	}, time.Second, 5*time.Millisecond)
+	// The metrics are cumulative.
+	for _, md := range metricsSink.AllMetrics() {
+		dp := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().DataPoints().At(0)
+		assert.Equal(t, int64(1), dp.Value())
+	}
+}
+
+func TestSpanMetrics_MaxSeries(t *testing.T) {
+	metricsSink := &consumertest.MetricsSink{}
+	cfg := newTestConfig()
+	cfg.MaxSeries = 2
+	sp := newSpanMetricsProcessor(component.ProcessorCreateParams{Logger: zap.NewNop()}, consumertest.NewNop(), cfg)
+	require.NoError(t, sp.Start(context.Background(), newMockHost(t, metricsSink)))
+
+	td := pdata.NewTraces()
+	appendSpan(td, "checkout", "cart", time.Millisecond)
+	appendSpan(td, "checkout", "pay", time.Millisecond)
+	appendSpan(td, "checkout", "ship", time.Millisecond)
+	appendSpan(td, "checkout", "cart", time.Millisecond)
+	require.NoError(t, sp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 1, sp.droppedSpans)
+	require.NoError(t, sp.Shutdown(context.Background()))
+
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	dps := metricsSink.AllMetrics()[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, "cart", labelsOf(dps.At(0).LabelsMap())[operationLabel])
+	assert.Equal(t, int64(2), dps.At(0).Value())
+	assert.Equal(t, "pay", labelsOf(dps.At(1).LabelsMap())[operationLabel])
+	assert.Equal(t, 0, sp.droppedSpans)
+}
+
+func TestSpanMetrics_SeriesExpiration(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.MaxSeries = 1
+	cfg.SeriesExpiration = time.Minute
+	sp := newSpanMetricsProcessor(component.ProcessorCreateParams{Logger: zap.NewNop()}, consumertest.NewNop(), cfg)
+	now := time.Unix(1000, 0)
+	sp.now = func() time.Time { return now }
+
+	td := pdata.NewTraces()
+	appendSpan(td, "checkout", "cart", time.Millisecond)
+	require.NoError(t, sp.ConsumeTraces(context.Background(), td))
+	now = now.Add(59 * time.Second)
+	_, ok := sp.buildMetrics()
+	assert.True(t, ok)
+
+	// The series without spans for a minute is removed, making room for a new one.
+	now = now.Add(time.Second)
+	_, ok = sp.buildMetrics()
+	assert.False(t, ok)
+	td = pdata.NewTraces()
+	appendSpan(td, "checkout", "pay", time.Millisecond)
+	require.NoError(t, sp.ConsumeTraces(context.Background(), td))
+
+	md, ok := sp.buildMetrics()
+	require.True(t, ok)
+	dps := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, "pay", labelsOf(dps.At(0).LabelsMap())[operationLabel])
+	assert.Equal(t, pdata.TimestampFromTime(now), dps.At(0).StartTimestamp())
+	assert.Equal(t, 0, sp.droppedSpans)
+}
+
+func TestSpanMetrics_NoSpans(t *testing.T) {
+	metricsSink := &consumertest.MetricsSink{}
+	sp := newSpanMetricsProcessor(component.ProcessorCreateParams{Logger: zap.NewNop()}, consumertest.NewNop(), newTestConfig())
+	require.NoError(t, sp.Start(context.Background(), newMockHost(t, metricsSink)))
+	require.NoError(t, sp.Shutdown(context.Background()))
+	assert.Len(t, metricsSink.AllMetrics(), 0)
+}
+
+func TestSpanMetrics_ExporterNotFound(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.MetricsExporter = "prometheus"
+	sp := newSpanMetricsProcessor(component.ProcessorCreateParams{Logger: zap.NewNop()}, consumertest.NewNop(), cfg)
+	err := sp.Start(context.Background(), newMockHost(t, &consumertest.MetricsSink{}))
+	assert.EqualError(t, err, `metrics_exporter "prometheus" is not used in any metrics pipeline`)
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func labelsOf(sm pdata.StringMap) map[string]string {
+	labels := make(map[string]string, sm.Len())
+	sm.Range(func(k, v string) bool {
+		labels[k] = v
+		return true
+	})
+	return labels
+}
@@ -15,7 +15,11 @@
 package spanprocessor
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/processor/filterconfig"
 )
 
@@ -34,6 +38,13 @@ type Config struct {
 	// Note: The field name is `Rename` to avoid collision with the Name() method
 	// from config.NamedEntity
 	Rename Name `mapstructure:"name"`
+
+	// Status specifies the rules setting the status of a span from its attributes.
+	Status *Status `mapstructure:"status"`
+
+	// Kind is the kind set on the spans, one of "INTERNAL", "SERVER", "CLIENT",
+	// "PRODUCER" or "CONSUMER". The kind is not changed if empty.
+	Kind string `mapstructure:"kind"`
 }
 
 // Name specifies the attributes to use to re-name a span.
@@ -79,9 +90,87 @@ type ToAttributes struct {
 	BreakAfterMatch bool `mapstructure:"break_after_match"`
 }
 
+// Status specifies the rules setting the status of a span from its attributes.
+type Status struct {
+	// Rules are checked in the order they are specified, the first rule matching
+	// the span sets its status.
+	Rules []StatusRule `mapstructure:"rules"`
+
+	// Override specifies if the status of the spans having a status other than
+	// "UNSET" is changed as well. If it is false, only the spans whose status was
+	// not set by their instrumentation are modified.
+	Override bool `mapstructure:"override"`
+}
+
+// StatusRule sets the status of the spans whose attribute matches either the
+// range of MinValue and MaxValue, or one of the Values.
+type StatusRule struct {
+	// Attribute is the key of the attribute whose value is checked.
+	Attribute string `mapstructure:"attribute"`
+
+	// MinValue is the minimum value, inclusive, of a numeric attribute. String
+	// attributes holding a number are compared as well.
+	MinValue *float64 `mapstructure:"min_value"`
+
+	// MaxValue is the maximum value, inclusive, of a numeric attribute. String
+	// attributes holding a number are compared as well.
+	MaxValue *float64 `mapstructure:"max_value"`
+
+	// Values are the values of the attribute, compared as strings.
+	Values []string `mapstructure:"values"`
+
+	// Code is the status code set on the matching spans, "OK", "ERROR" or "UNSET".
+	Code string `mapstructure:"code"`
+
+	// Message is the status message set on the matching spans.
+	Message string `mapstructure:"message"`
+}
+
+var (
+	statusCodes = map[string]pdata.StatusCode{
+		"UNSET": pdata.StatusCodeUnset,
+		"OK":    pdata.StatusCodeOk,
+		"ERROR": pdata.StatusCodeError,
+	}
+	spanKinds = map[string]pdata.SpanKind{
+		"INTERNAL": pdata.SpanKindInternal,
+		"SERVER":   pdata.SpanKindServer,
+		"CLIENT":   pdata.SpanKindClient,
+		"PRODUCER": pdata.SpanKindProducer,
+		"CONSUMER": pdata.SpanKindConsumer,
+	}
+)
+
 var _ config.Processor = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.Status != nil {
+		for i, rule := range cfg.Status.Rules {
+			if err := rule.validate(); err != nil {
+				return fmt.Errorf("status rules[%d]: %w", i, err)
+			}
+		}
+	}
+	if _, ok := spanKinds[cfg.Kind]; cfg.Kind != "" && !ok {
+		return fmt.Errorf("unknown kind %q, must be INTERNAL, SERVER, CLIENT, PRODUCER or CONSUMER", cfg.Kind)
+	}
+	return nil
+}
+
+func (rule *StatusRule) validate() error {
+	if rule.Attribute == "" {
+		return errors.New("attribute must be specified")
+	}
+	hasRange := rule.MinValue != nil || rule.MaxValue != nil
+	if hasRange == (len(rule.Values) > 0) {
+		return errors.New("either min_value and max_value, or values must be specified")
+	}
+	if rule.MinValue != nil && rule.MaxValue != nil && *rule.MinValue > *rule.MaxValue {
+		return errors.New("min_value must not be greater than max_value")
+	}
+	if _, ok := statusCodes[rule.Code]; !ok {
+		return fmt.Errorf("unknown status code %q, must be OK, ERROR or UNSET", rule.Code)
+	}
 	return nil
 }
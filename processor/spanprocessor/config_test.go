@@ -87,6 +87,84 @@ func TestLoadConfig(t *testing.T) {
 			},
 		},
 	})
+
+	minValue := 500.0
+	p4 := cfg.Processors[config.NewIDWithName("span", "status")]
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName("span", "status")),
+		Status: &Status{
+			Rules: []StatusRule{
+				{Attribute: "http.status_code", MinValue: &minValue, Code: "ERROR", Message: "server error"},
+				{Attribute: "error", Values: []string{"true"}, Code: "ERROR"},
+			},
+		},
+	}, p4)
+
+	p5 := cfg.Processors[config.NewIDWithName("span", "kind")]
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewIDWithName("span", "kind")),
+		MatchConfig: filterconfig.MatchConfig{
+			Include: &filterconfig.MatchProperties{
+				Config:   *createMatchConfig(filterset.Strict),
+				Services: []string{"legacy-gateway"},
+			},
+		},
+		Kind: "SERVER",
+	}, p5)
+}
+
+func TestValidateConfig(t *testing.T) {
+	minValue, maxValue := 500.0, 400.0
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "no attribute",
+			modify: func(cfg *Config) { cfg.Status.Rules[0].Attribute = "" },
+			err:    "status rules[0]: attribute must be specified",
+		},
+		{
+			name:   "no condition",
+			modify: func(cfg *Config) { cfg.Status.Rules[0].Values = nil },
+			err:    "status rules[0]: either min_value and max_value, or values must be specified",
+		},
+		{
+			name:   "range and values",
+			modify: func(cfg *Config) { cfg.Status.Rules[0].MinValue = &minValue },
+			err:    "status rules[0]: either min_value and max_value, or values must be specified",
+		},
+		{
+			name: "empty range",
+			modify: func(cfg *Config) {
+				cfg.Status.Rules[0].Values = nil
+				cfg.Status.Rules[0].MinValue = &minValue
+				cfg.Status.Rules[0].MaxValue = &maxValue
+			},
+			err: "status rules[0]: min_value must not be greater than max_value",
+		},
+		{
+			name:   "unknown status code",
+			modify: func(cfg *Config) { cfg.Status.Rules[0].Code = "Error" },
+			err:    `status rules[0]: unknown status code "Error", must be OK, ERROR or UNSET`,
+		},
+		{
+			name:   "unknown kind",
+			modify: func(cfg *Config) { cfg.Kind = "GATEWAY" },
+			err:    `unknown kind "GATEWAY", must be INTERNAL, SERVER, CLIENT, PRODUCER or CONSUMER`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Status = &Status{Rules: []StatusRule{{Attribute: "error", Values: []string{"true"}, Code: "ERROR"}}}
+			assert.NoError(t, cfg.Validate())
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
 }
 
 func createMatchConfig(matchType filterset.MatchType) *filterset.Config {
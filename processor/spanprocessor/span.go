@@ -30,6 +30,8 @@ type spanProcessor struct {
 	toAttributeRules []toAttributeRule
 	include          filterspan.Matcher
 	exclude          filterspan.Matcher
+	statusRules      []statusRule
+	kind             pdata.SpanKind
 }
 
 // toAttributeRule is the compiled equivalent of config.ToAttributes field.
@@ -76,6 +78,15 @@ func newSpanProcessor(config Config) (*spanProcessor, error) {
 		}
 	}
 
+	if config.Status != nil {
+		for _, rule := range config.Status.Rules {
+			sp.statusRules = append(sp.statusRules, newStatusRule(rule))
+		}
+	}
+	if config.Kind != "" {
+		sp.kind = spanKinds[config.Kind]
+	}
+
 	return sp, nil
 }
 
@@ -96,6 +107,8 @@ func (sp *spanProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdat
 				}
 				sp.processFromAttributes(s)
 				sp.processToAttributes(s)
+				sp.processStatus(s)
+				sp.processKind(s)
 			}
 		}
 	}
@@ -219,3 +232,33 @@ func (sp *spanProcessor) processToAttributes(span pdata.Span) {
 		}
 	}
 }
+
+func (sp *spanProcessor) processStatus(span pdata.Span) {
+	if len(sp.statusRules) == 0 {
+		// No rules to apply.
+		return
+	}
+
+	status := span.Status()
+	if !sp.config.Status.Override && status.Code() != pdata.StatusCodeUnset {
+		// The status was set by the instrumentation.
+		return
+	}
+
+	attrs := span.Attributes()
+	for _, rule := range sp.statusRules {
+		if rule.matches(attrs) {
+			status.SetCode(rule.code)
+			status.SetMessage(rule.message)
+			return
+		}
+	}
+}
+
+func (sp *spanProcessor) processKind(span pdata.Span) {
+	if sp.config.Kind == "" {
+		// The kind is not changed.
+		return
+	}
+	span.SetKind(sp.kind)
+}
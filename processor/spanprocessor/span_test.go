@@ -593,3 +593,130 @@ func TestSpanProcessor_skipSpan(t *testing.T) {
 		runIndividualTestCase(t, tc, tp)
 	}
 }
+
+func TestSpanProcessor_Status(t *testing.T) {
+	minServerError, minClientError, maxClientError := 500.0, 400.0, 499.0
+	factory := NewFactory()
+	oCfg := factory.CreateDefaultConfig().(*Config)
+	oCfg.Status = &Status{
+		Rules: []StatusRule{
+			{Attribute: "http.status_code", MinValue: &minServerError, Code: "ERROR", Message: "server error"},
+			{Attribute: "http.status_code", MinValue: &minClientError, MaxValue: &maxClientError, Code: "OK"},
+			{Attribute: "error", Values: []string{"true", "1"}, Code: "ERROR"},
+		},
+	}
+	tp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, oCfg, consumertest.NewNop())
+	require.Nil(t, err)
+	require.NotNil(t, tp)
+
+	testCases := []struct {
+		name        string
+		attrs       map[string]pdata.AttributeValue
+		inputCode   pdata.StatusCode
+		wantCode    pdata.StatusCode
+		wantMessage string
+	}{
+		{
+			name:        "int server error",
+			attrs:       map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(503)},
+			wantCode:    pdata.StatusCodeError,
+			wantMessage: "server error",
+		},
+		{
+			name:        "string server error",
+			attrs:       map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueString("500")},
+			wantCode:    pdata.StatusCodeError,
+			wantMessage: "server error",
+		},
+		{
+			name:     "client error",
+			attrs:    map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueDouble(404)},
+			wantCode: pdata.StatusCodeOk,
+		},
+		{
+			name:     "success",
+			attrs:    map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(200)},
+			wantCode: pdata.StatusCodeUnset,
+		},
+		{
+			name:     "not a number",
+			attrs:    map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueString("unknown")},
+			wantCode: pdata.StatusCodeUnset,
+		},
+		{
+			name:     "bool value",
+			attrs:    map[string]pdata.AttributeValue{"error": pdata.NewAttributeValueBool(true)},
+			wantCode: pdata.StatusCodeError,
+		},
+		{
+			name:     "int value",
+			attrs:    map[string]pdata.AttributeValue{"error": pdata.NewAttributeValueInt(1)},
+			wantCode: pdata.StatusCodeError,
+		},
+		{
+			name:     "no attribute",
+			wantCode: pdata.StatusCodeUnset,
+		},
+		{
+			name:      "status set by the instrumentation",
+			attrs:     map[string]pdata.AttributeValue{"http.status_code": pdata.NewAttributeValueInt(503)},
+			inputCode: pdata.StatusCodeOk,
+			wantCode:  pdata.StatusCodeOk,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			td := generateTraceData("", "span", tc.attrs)
+			span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+			span.Status().SetCode(tc.inputCode)
+
+			assert.NoError(t, tp.ConsumeTraces(context.Background(), td))
+			assert.Equal(t, tc.wantCode, span.Status().Code())
+			assert.Equal(t, tc.wantMessage, span.Status().Message())
+		})
+	}
+}
+
+func TestSpanProcessor_StatusOverride(t *testing.T) {
+	factory := NewFactory()
+	oCfg := factory.CreateDefaultConfig().(*Config)
+	oCfg.Status = &Status{
+		Rules:    []StatusRule{{Attribute: "error", Values: []string{"true"}, Code: "ERROR", Message: "failed"}},
+		Override: true,
+	}
+	tp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, oCfg, consumertest.NewNop())
+	require.Nil(t, err)
+
+	td := generateTraceData("", "span", map[string]pdata.AttributeValue{"error": pdata.NewAttributeValueBool(true)})
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.Status().SetCode(pdata.StatusCodeOk)
+
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, pdata.StatusCodeError, span.Status().Code())
+	assert.Equal(t, "failed", span.Status().Message())
+}
+
+func TestSpanProcessor_Kind(t *testing.T) {
+	factory := NewFactory()
+	oCfg := factory.CreateDefaultConfig().(*Config)
+	oCfg.Include = &filterconfig.MatchProperties{
+		Config:   *createMatchConfig(filterset.Strict),
+		Services: []string{"legacy-gateway"},
+	}
+	oCfg.Kind = "SERVER"
+	tp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, oCfg, consumertest.NewNop())
+	require.Nil(t, err)
+
+	for service, want := range map[string]pdata.SpanKind{
+		"legacy-gateway": pdata.SpanKindServer,
+		"frontend":       pdata.SpanKindClient,
+	} {
+		td := generateTraceData(service, "span", nil)
+		span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+		span.SetKind(pdata.SpanKindClient)
+
+		assert.NoError(t, tp.ConsumeTraces(context.Background(), td))
+		assert.Equal(t, want, span.Kind(), service)
+	}
+}
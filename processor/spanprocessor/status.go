@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanprocessor
+
+import (
+	"math"
+	"strconv"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// statusRule is the compiled equivalent of config.StatusRule.
+type statusRule struct {
+	attribute string
+	hasRange  bool
+	minValue  float64
+	maxValue  float64
+	values    map[string]bool
+	code      pdata.StatusCode
+	message   string
+}
+
+func newStatusRule(cfg StatusRule) statusRule {
+	rule := statusRule{
+		attribute: cfg.Attribute,
+		hasRange:  cfg.MinValue != nil || cfg.MaxValue != nil,
+		minValue:  math.Inf(-1),
+		maxValue:  math.Inf(1),
+		code:      statusCodes[cfg.Code],
+		message:   cfg.Message,
+	}
+	if cfg.MinValue != nil {
+		rule.minValue = *cfg.MinValue
+	}
+	if cfg.MaxValue != nil {
+		rule.maxValue = *cfg.MaxValue
+	}
+	if len(cfg.Values) > 0 {
+		rule.values = make(map[string]bool, len(cfg.Values))
+		for _, v := range cfg.Values {
+			rule.values[v] = true
+		}
+	}
+	return rule
+}
+
+// matches returns whether the attribute of the rule is in the range or has one of the values of the rule.
+func (rule *statusRule) matches(attrs pdata.AttributeMap) bool {
+	attr, ok := attrs.Get(rule.attribute)
+	if !ok {
+		return false
+	}
+	if !rule.hasRange {
+		return rule.values[tracetranslator.AttributeValueToString(attr)]
+	}
+
+	var value float64
+	switch attr.Type() {
+	case pdata.AttributeValueTypeInt:
+		value = float64(attr.IntVal())
+	case pdata.AttributeValueTypeDouble:
+		value = attr.DoubleVal()
+	case pdata.AttributeValueTypeString:
+		// Some instrumentations record numbers as strings, e.g. the HTTP status code.
+		v, err := strconv.ParseFloat(attr.StringVal(), 64)
+		if err != nil {
+			return false
+		}
+		value = v
+	default:
+		return false
+	}
+	return value >= rule.minValue && value <= rule.maxValue
+}
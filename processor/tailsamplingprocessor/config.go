@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+)
+
+// PolicyType is the type of a sampling policy.
+type PolicyType string
+
+const (
+	// StatusCode samples the traces with a span of one of the given status codes.
+	StatusCode PolicyType = "status_code"
+	// Latency samples the traces longer than a threshold.
+	Latency PolicyType = "latency"
+	// Attribute samples the traces with a span matching the given properties.
+	Attribute PolicyType = "attribute"
+	// RateLimiting samples the traces until a number of spans per second is reached.
+	RateLimiting PolicyType = "rate_limiting"
+	// Probabilistic samples a percentage of the traces, based on the hash of their trace ID.
+	Probabilistic PolicyType = "probabilistic"
+	// And samples the traces sampled by all its policies.
+	And PolicyType = "and"
+)
+
+// Config defines configuration for the tail sampling processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// DecisionWait is the time to wait after the first span of a trace before deciding whether to sample it.
+	DecisionWait time.Duration `mapstructure:"decision_wait"`
+
+	// NumTraces is the maximum number of traces kept in memory, waiting for a decision or keeping the decision
+	// for the late spans. When it is reached, the oldest trace is forgotten if it is decided, or decided early.
+	NumTraces int `mapstructure:"num_traces"`
+
+	// Policies are evaluated for every trace, it is sampled if any of them samples it.
+	Policies []PolicyCfg `mapstructure:"policies"`
+}
+
+// PolicyCfg holds the configuration of a sampling policy.
+type PolicyCfg struct {
+	// Name identifies the policy in the metrics.
+	Name string `mapstructure:"name"`
+
+	SubPolicyCfg `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	And AndCfg `mapstructure:"and"`
+}
+
+// SubPolicyCfg holds the configuration of a policy in the field of its type, any type but "and".
+type SubPolicyCfg struct {
+	// Type is the type of the policy.
+	Type PolicyType `mapstructure:"type"`
+
+	StatusCode    StatusCodeCfg                `mapstructure:"status_code"`
+	Latency       LatencyCfg                   `mapstructure:"latency"`
+	Attribute     filterconfig.MatchProperties `mapstructure:"attribute"`
+	RateLimiting  RateLimitingCfg              `mapstructure:"rate_limiting"`
+	Probabilistic ProbabilisticCfg             `mapstructure:"probabilistic"`
+}
+
+// StatusCodeCfg holds the configuration of the status_code policy.
+type StatusCodeCfg struct {
+	// StatusCodes are the status codes to sample, "OK", "ERROR" or "UNSET".
+	StatusCodes []string `mapstructure:"status_codes"`
+}
+
+// LatencyCfg holds the configuration of the latency policy.
+type LatencyCfg struct {
+	// Threshold is the duration of a trace, from the start of its first span to the end of its last span,
+	// from which it is sampled.
+	Threshold time.Duration `mapstructure:"threshold"`
+}
+
+// RateLimitingCfg holds the configuration of the rate_limiting policy.
+type RateLimitingCfg struct {
+	// SpansPerSecond is the number of spans per second of the sampled traces, no more trace is sampled once it
+	// is reached.
+	SpansPerSecond int64 `mapstructure:"spans_per_second"`
+}
+
+// ProbabilisticCfg holds the configuration of the probabilistic policy.
+type ProbabilisticCfg struct {
+	// SamplingPercentage is the percentage of the traces to sample.
+	SamplingPercentage float64 `mapstructure:"sampling_percentage"`
+	// HashSeed is the seed of the hash of the trace IDs, see the probabilistic_sampler processor.
+	HashSeed uint32 `mapstructure:"hash_seed"`
+}
+
+// AndCfg holds the configuration of the and policy.
+type AndCfg struct {
+	// Policies are evaluated in order, until one of them does not sample the trace. The rate_limiting
+	// policies should be last, so they only count the spans of the traces sampled by the others.
+	Policies []SubPolicyCfg `mapstructure:"policies"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.DecisionWait <= 0 {
+		return errors.New("decision_wait must be positive")
+	}
+	if cfg.NumTraces <= 0 {
+		return errors.New("num_traces must be positive")
+	}
+	_, err := newPolicies(cfg.Policies)
+	return err
+}
+
+// newPolicies creates the policies of the configuration.
+func newPolicies(cfgs []PolicyCfg) ([]namedPolicy, error) {
+	if len(cfgs) == 0 {
+		return nil, errors.New("must specify at least one policy")
+	}
+	policies := make([]namedPolicy, 0, len(cfgs))
+	seen := make(map[string]bool, len(cfgs))
+	for i := range cfgs {
+		cfg := &cfgs[i]
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("policies[%d] must have a name", i)
+		}
+		if seen[cfg.Name] {
+			return nil, fmt.Errorf("policies[%d] duplicates policy name %q", i, cfg.Name)
+		}
+		seen[cfg.Name] = true
+		p, err := newTopLevelPolicy(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("policy %q: %w", cfg.Name, err)
+		}
+		policies = append(policies, namedPolicy{name: cfg.Name, policy: p})
+	}
+	return policies, nil
+}
@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	p0 := cfg.Processors[config.NewID(typeStr)]
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+			DecisionWait:      10 * time.Second,
+			NumTraces:         100,
+			Policies: []PolicyCfg{
+				{Name: "errors", SubPolicyCfg: SubPolicyCfg{Type: StatusCode, StatusCode: StatusCodeCfg{StatusCodes: []string{"ERROR"}}}},
+				{Name: "slow", SubPolicyCfg: SubPolicyCfg{Type: Latency, Latency: LatencyCfg{Threshold: 2 * time.Second}}},
+				{Name: "checkout", SubPolicyCfg: SubPolicyCfg{Type: Attribute, Attribute: filterconfig.MatchProperties{
+					Config:   filterset.Config{MatchType: filterset.Strict},
+					Services: []string{"checkout"},
+				}}},
+				{Name: "limited-server-errors", SubPolicyCfg: SubPolicyCfg{Type: And}, And: AndCfg{Policies: []SubPolicyCfg{
+					{Type: Attribute, Attribute: filterconfig.MatchProperties{
+						Config:     filterset.Config{MatchType: filterset.Strict},
+						Attributes: []filterconfig.Attribute{{Key: "http.status_code", Value: 500}},
+					}},
+					{Type: RateLimiting, RateLimiting: RateLimitingCfg{SpansPerSecond: 100}},
+				}}},
+				{Name: "baseline", SubPolicyCfg: SubPolicyCfg{Type: Probabilistic, Probabilistic: ProbabilisticCfg{SamplingPercentage: 10}}},
+			},
+		}, p0)
+}
+
+func TestValidateConfig(t *testing.T) {
+	errorsPolicy := PolicyCfg{Name: "errors", SubPolicyCfg: SubPolicyCfg{Type: StatusCode, StatusCode: StatusCodeCfg{StatusCodes: []string{"ERROR"}}}}
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "decision wait",
+			modify: func(cfg *Config) { cfg.DecisionWait = 0 },
+			err:    "decision_wait must be positive",
+		},
+		{
+			name:   "num traces",
+			modify: func(cfg *Config) { cfg.NumTraces = 0 },
+			err:    "num_traces must be positive",
+		},
+		{
+			name:   "no policies",
+			modify: func(cfg *Config) { cfg.Policies = nil },
+			err:    "must specify at least one policy",
+		},
+		{
+			name:   "no name",
+			modify: func(cfg *Config) { cfg.Policies[0].Name = "" },
+			err:    "policies[0] must have a name",
+		},
+		{
+			name:   "duplicated name",
+			modify: func(cfg *Config) { cfg.Policies = append(cfg.Policies, errorsPolicy) },
+			err:    `policies[1] duplicates policy name "errors"`,
+		},
+		{
+			name:   "unknown type",
+			modify: func(cfg *Config) { cfg.Policies[0].Type = "unknown" },
+			err:    `policy "errors": unknown policy type "unknown"`,
+		},
+		{
+			name:   "unknown status code",
+			modify: func(cfg *Config) { cfg.Policies[0].StatusCode.StatusCodes = []string{"FAILED"} },
+			err:    `policy "errors": unknown status code "FAILED", must be OK, ERROR or UNSET`,
+		},
+		{
+			name:   "latency",
+			modify: func(cfg *Config) { cfg.Policies[0].Type = Latency },
+			err:    `policy "errors": latency threshold must be positive`,
+		},
+		{
+			name:   "attribute",
+			modify: func(cfg *Config) { cfg.Policies[0].Type = Attribute },
+			err:    `policy "errors": at least one of "services", "span_names", "attributes", "libraries" or "resources" field must be specified`,
+		},
+		{
+			name:   "rate limiting",
+			modify: func(cfg *Config) { cfg.Policies[0].Type = RateLimiting },
+			err:    `policy "errors": rate_limiting spans_per_second must be positive`,
+		},
+		{
+			name:   "probabilistic",
+			modify: func(cfg *Config) { cfg.Policies[0].Type = Probabilistic },
+			err:    `policy "errors": probabilistic sampling_percentage must be greater than 0 and at most 100`,
+		},
+		{
+			name: "and",
+			modify: func(cfg *Config) {
+				cfg.Policies[0].Type = And
+				cfg.Policies[0].And.Policies = []SubPolicyCfg{{Type: Latency}}
+			},
+			err: `policy "errors": and policies[0]: latency threshold must be positive`,
+		},
+		{
+			name: "nested and",
+			modify: func(cfg *Config) {
+				cfg.Policies[0].Type = And
+				cfg.Policies[0].And.Policies = []SubPolicyCfg{{Type: And}}
+			},
+			err: `policy "errors": and policies[0] cannot be an and policy`,
+		},
+		{
+			name: "empty and",
+			modify: func(cfg *Config) {
+				cfg.Policies[0].Type = And
+			},
+			err: `policy "errors": and must have at least one policy`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Policies = []PolicyCfg{errorsPolicy}
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
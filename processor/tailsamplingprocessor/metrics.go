@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+var (
+	processorTagKey = tag.MustNewKey(obsreport.ProcessorKey)
+	policyTagKey    = tag.MustNewKey("policy")
+
+	statTracesSampled    = stats.Int64("traces_sampled", "Number of traces sampled, by the first policy that sampled them", stats.UnitDimensionless)
+	statTracesNotSampled = stats.Int64("traces_not_sampled", "Number of traces sampled by none of the policies", stats.UnitDimensionless)
+	statEarlyDecisions   = stats.Int64("early_decisions", "Number of traces decided before the decision wait because num_traces was reached", stats.UnitDimensionless)
+	statLateSpans        = stats.Int64("late_spans", "Number of spans received after the decision of their trace", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views related to tail sampling
+func MetricViews() []*view.View {
+	processorTagKeys := []tag.Key{processorTagKey}
+
+	countTracesSampledView := &view.View{
+		Name:        statTracesSampled.Name(),
+		Measure:     statTracesSampled,
+		Description: statTracesSampled.Description(),
+		TagKeys:     []tag.Key{processorTagKey, policyTagKey},
+		Aggregation: view.Sum(),
+	}
+
+	countTracesNotSampledView := &view.View{
+		Name:        statTracesNotSampled.Name(),
+		Measure:     statTracesNotSampled,
+		Description: statTracesNotSampled.Description(),
+		TagKeys:     processorTagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	countEarlyDecisionsView := &view.View{
+		Name:        statEarlyDecisions.Name(),
+		Measure:     statEarlyDecisions,
+		Description: statEarlyDecisions.Description(),
+		TagKeys:     processorTagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	countLateSpansView := &view.View{
+		Name:        statLateSpans.Name(),
+		Measure:     statLateSpans,
+		Description: statLateSpans.Description(),
+		TagKeys:     processorTagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	legacyViews := []*view.View{
+		countTracesSampledView,
+		countTracesNotSampledView,
+		countEarlyDecisionsView,
+		countLateSpansView,
+	}
+
+	return obsreport.ProcessorMetricViews(typeStr, legacyViews)
+}
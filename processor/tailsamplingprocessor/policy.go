@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
+)
+
+// policy decides whether to sample a trace once its decision wait is over.
+type policy interface {
+	// evaluate returns whether to sample the trace.
+	evaluate(traceID pdata.TraceID, trace *traceData, now time.Time) bool
+}
+
+// namedPolicy is a policy of the configuration, its name identifies it in the metrics.
+type namedPolicy struct {
+	name   string
+	policy policy
+}
+
+func newTopLevelPolicy(cfg *PolicyCfg) (policy, error) {
+	if cfg.Type != And {
+		return newPolicy(&cfg.SubPolicyCfg)
+	}
+	if len(cfg.And.Policies) == 0 {
+		return nil, errors.New("and must have at least one policy")
+	}
+	ap := &andPolicy{}
+	for i := range cfg.And.Policies {
+		if cfg.And.Policies[i].Type == And {
+			return nil, fmt.Errorf("and policies[%d] cannot be an and policy", i)
+		}
+		p, err := newPolicy(&cfg.And.Policies[i])
+		if err != nil {
+			return nil, fmt.Errorf("and policies[%d]: %w", i, err)
+		}
+		if rlp, ok := p.(*rateLimitingPolicy); ok {
+			ap.rateLimiters = append(ap.rateLimiters, rlp)
+			continue
+		}
+		ap.policies = append(ap.policies, p)
+	}
+	return ap, nil
+}
+
+func newPolicy(cfg *SubPolicyCfg) (policy, error) {
+	switch cfg.Type {
+	case StatusCode:
+		return newStatusCodePolicy(cfg.StatusCode)
+	case Latency:
+		if cfg.Latency.Threshold <= 0 {
+			return nil, errors.New("latency threshold must be positive")
+		}
+		return &latencyPolicy{threshold: cfg.Latency.Threshold}, nil
+	case Attribute:
+		matcher, err := filterspan.NewMatcher(&cfg.Attribute)
+		if err != nil {
+			return nil, err
+		}
+		return &attributePolicy{matcher: matcher}, nil
+	case RateLimiting:
+		if cfg.RateLimiting.SpansPerSecond <= 0 {
+			return nil, errors.New("rate_limiting spans_per_second must be positive")
+		}
+		return &rateLimitingPolicy{spansPerSecond: cfg.RateLimiting.SpansPerSecond}, nil
+	case Probabilistic:
+		pct := cfg.Probabilistic.SamplingPercentage
+		if pct <= 0 || pct > 100 {
+			return nil, errors.New("probabilistic sampling_percentage must be greater than 0 and at most 100")
+		}
+		pp := &probabilisticPolicy{threshold: math.MaxUint64, hashSeed: cfg.Probabilistic.HashSeed}
+		if pct < 100 {
+			pp.threshold = uint64(pct / 100 * math.MaxUint64)
+		}
+		return pp, nil
+	}
+	return nil, fmt.Errorf("unknown policy type %q", cfg.Type)
+}
+
+// forEachSpan calls fn for every span of the trace, until it returns true. It returns whether fn returned true.
+func forEachSpan(td pdata.Traces, fn func(span pdata.Span, resource pdata.Resource, library pdata.InstrumentationLibrary) bool) bool {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if fn(spans.At(k), rs.Resource(), ils.InstrumentationLibrary()) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// statusCodePolicy samples the traces with a span of one of the status codes.
+type statusCodePolicy struct {
+	codes map[pdata.StatusCode]bool
+}
+
+var statusCodes = map[string]pdata.StatusCode{
+	"UNSET": pdata.StatusCodeUnset,
+	"OK":    pdata.StatusCodeOk,
+	"ERROR": pdata.StatusCodeError,
+}
+
+func newStatusCodePolicy(cfg StatusCodeCfg) (policy, error) {
+	if len(cfg.StatusCodes) == 0 {
+		return nil, errors.New("must specify at least one status code")
+	}
+	scp := &statusCodePolicy{codes: make(map[pdata.StatusCode]bool, len(cfg.StatusCodes))}
+	for _, name := range cfg.StatusCodes {
+		code, ok := statusCodes[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown status code %q, must be OK, ERROR or UNSET", name)
+		}
+		scp.codes[code] = true
+	}
+	return scp, nil
+}
+
+func (scp *statusCodePolicy) evaluate(_ pdata.TraceID, trace *traceData, _ time.Time) bool {
+	return forEachSpan(trace.spans, func(span pdata.Span, _ pdata.Resource, _ pdata.InstrumentationLibrary) bool {
+		return scp.codes[span.Status().Code()]
+	})
+}
+
+// latencyPolicy samples the traces longer than the threshold.
+type latencyPolicy struct {
+	threshold time.Duration
+}
+
+func (lp *latencyPolicy) evaluate(_ pdata.TraceID, trace *traceData, _ time.Time) bool {
+	var start, end pdata.Timestamp
+	return forEachSpan(trace.spans, func(span pdata.Span, _ pdata.Resource, _ pdata.InstrumentationLibrary) bool {
+		if start == 0 || span.StartTimestamp() < start {
+			start = span.StartTimestamp()
+		}
+		if span.EndTimestamp() > end {
+			end = span.EndTimestamp()
+		}
+		return end > start && end.AsTime().Sub(start.AsTime()) >= lp.threshold
+	})
+}
+
+// attributePolicy samples the traces with a span matching the properties.
+type attributePolicy struct {
+	matcher filterspan.Matcher
+}
+
+func (ap *attributePolicy) evaluate(_ pdata.TraceID, trace *traceData, _ time.Time) bool {
+	return forEachSpan(trace.spans, ap.matcher.MatchSpan)
+}
+
+// rateLimitingPolicy samples the traces until the spans per second of the sampled traces is reached.
+type rateLimitingPolicy struct {
+	spansPerSecond int64
+
+	mu     sync.Mutex
+	second int64
+	spans  int64
+}
+
+func (rlp *rateLimitingPolicy) evaluate(_ pdata.TraceID, trace *traceData, now time.Time) bool {
+	rlp.mu.Lock()
+	defer rlp.mu.Unlock()
+
+	if !rlp.allowsLocked(now) {
+		return false
+	}
+	rlp.spans += int64(trace.spanCount)
+	return true
+}
+
+// allowsLocked returns whether the spans per second are not reached yet, rlp.mu must be held.
+func (rlp *rateLimitingPolicy) allowsLocked(now time.Time) bool {
+	if second := now.Unix(); second != rlp.second {
+		rlp.second = second
+		rlp.spans = 0
+	}
+	return rlp.spans < rlp.spansPerSecond
+}
+
+// probabilisticPolicy samples the traces with a hash of their trace ID below the threshold.
+type probabilisticPolicy struct {
+	threshold uint64
+	hashSeed  uint32
+}
+
+func (pp *probabilisticPolicy) evaluate(traceID pdata.TraceID, _ *traceData, _ time.Time) bool {
+	if pp.threshold == math.MaxUint64 {
+		return true
+	}
+	var seed [4]byte
+	binary.BigEndian.PutUint32(seed[:], pp.hashSeed)
+	tid := traceID.Bytes()
+	h := fnv.New64a()
+	_, _ = h.Write(seed[:])
+	_, _ = h.Write(tid[:])
+	return h.Sum64() < pp.threshold
+}
+
+// andPolicy samples the traces sampled by all its policies. The rate limiters are kept apart and evaluated last,
+// they only count the traces sampled by all the other policies and rate limiters.
+type andPolicy struct {
+	policies     []policy
+	rateLimiters []*rateLimitingPolicy
+}
+
+func (ap *andPolicy) evaluate(traceID pdata.TraceID, trace *traceData, now time.Time) bool {
+	for _, p := range ap.policies {
+		if !p.evaluate(traceID, trace, now) {
+			return false
+		}
+	}
+	for _, rlp := range ap.rateLimiters {
+		rlp.mu.Lock()
+		defer rlp.mu.Unlock()
+	}
+	for _, rlp := range ap.rateLimiters {
+		if !rlp.allowsLocked(now) {
+			return false
+		}
+	}
+	for _, rlp := range ap.rateLimiters {
+		rlp.spans += int64(trace.spanCount)
+	}
+	return true
+}
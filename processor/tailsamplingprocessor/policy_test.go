@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterconfig"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+func newTestTrace(spans ...func(span pdata.Span)) *traceData {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+	for _, fn := range spans {
+		span := ils.Spans().AppendEmpty()
+		span.SetTraceID(testTraceID(1))
+		fn(span)
+	}
+	return &traceData{spans: td, spanCount: len(spans)}
+}
+
+func withTimestamps(start, end time.Duration) func(span pdata.Span) {
+	return func(span pdata.Span) {
+		span.SetStartTimestamp(pdata.TimestampFromTime(time.Unix(0, 0).Add(start)))
+		span.SetEndTimestamp(pdata.TimestampFromTime(time.Unix(0, 0).Add(end)))
+	}
+}
+
+func TestStatusCodePolicy(t *testing.T) {
+	p, err := newPolicy(&SubPolicyCfg{Type: StatusCode, StatusCode: StatusCodeCfg{StatusCodes: []string{"ERROR", "UNSET"}}})
+	require.NoError(t, err)
+
+	ok := func(span pdata.Span) { span.Status().SetCode(pdata.StatusCodeOk) }
+	failed := func(span pdata.Span) { span.Status().SetCode(pdata.StatusCodeError) }
+	assert.False(t, p.evaluate(testTraceID(1), newTestTrace(ok, ok), time.Now()))
+	assert.True(t, p.evaluate(testTraceID(1), newTestTrace(ok, failed), time.Now()))
+	assert.True(t, p.evaluate(testTraceID(1), newTestTrace(func(pdata.Span) {}), time.Now()))
+}
+
+func TestLatencyPolicy(t *testing.T) {
+	p, err := newPolicy(&SubPolicyCfg{Type: Latency, Latency: LatencyCfg{Threshold: 2 * time.Second}})
+	require.NoError(t, err)
+
+	assert.False(t, p.evaluate(testTraceID(1), newTestTrace(withTimestamps(time.Second, 2*time.Second)), time.Now()))
+	// The trace lasts from the start of the first span to the end of the last one.
+	assert.True(t, p.evaluate(testTraceID(1), newTestTrace(
+		withTimestamps(2*time.Second, 3*time.Second),
+		withTimestamps(time.Second, 2*time.Second),
+		withTimestamps(2500*time.Millisecond, 3500*time.Millisecond),
+	), time.Now()))
+}
+
+func TestAttributePolicy(t *testing.T) {
+	p, err := newPolicy(&SubPolicyCfg{Type: Attribute, Attribute: filterconfig.MatchProperties{
+		Config:     filterset.Config{MatchType: filterset.Strict},
+		Services:   []string{"checkout"},
+		Attributes: []filterconfig.Attribute{{Key: "http.status_code", Value: 500}},
+	}})
+	require.NoError(t, err)
+
+	assert.False(t, p.evaluate(testTraceID(1), newTestTrace(func(span pdata.Span) {
+		span.Attributes().InsertInt("http.status_code", 200)
+	}), time.Now()))
+	assert.True(t, p.evaluate(testTraceID(1), newTestTrace(
+		func(span pdata.Span) { span.Attributes().InsertInt("http.status_code", 200) },
+		func(span pdata.Span) { span.Attributes().InsertInt("http.status_code", 500) },
+	), time.Now()))
+}
+
+func TestRateLimitingPolicy(t *testing.T) {
+	p, err := newPolicy(&SubPolicyCfg{Type: RateLimiting, RateLimiting: RateLimitingCfg{SpansPerSecond: 3}})
+	require.NoError(t, err)
+
+	trace := newTestTrace(func(pdata.Span) {}, func(pdata.Span) {})
+	now := time.Unix(1000, 0)
+	assert.True(t, p.evaluate(testTraceID(1), trace, now))
+	assert.True(t, p.evaluate(testTraceID(1), trace, now.Add(500*time.Millisecond)))
+	assert.False(t, p.evaluate(testTraceID(1), trace, now.Add(900*time.Millisecond)))
+	// A new second.
+	assert.True(t, p.evaluate(testTraceID(1), trace, now.Add(time.Second)))
+}
+
+func TestProbabilisticPolicy(t *testing.T) {
+	p, err := newPolicy(&SubPolicyCfg{Type: Probabilistic, Probabilistic: ProbabilisticCfg{SamplingPercentage: 25, HashSeed: 42}})
+	require.NoError(t, err)
+
+	sampled := 0
+	for i := 0; i < 10000; i++ {
+		if p.evaluate(pdata.NewTraceID([16]byte{byte(i), byte(i >> 8)}), nil, time.Now()) {
+			sampled++
+		}
+	}
+	assert.InDelta(t, 2500, sampled, 250)
+
+	p, err = newPolicy(&SubPolicyCfg{Type: Probabilistic, Probabilistic: ProbabilisticCfg{SamplingPercentage: 100}})
+	require.NoError(t, err)
+	assert.True(t, p.evaluate(testTraceID(1), nil, time.Now()))
+}
+
+func TestAndPolicy(t *testing.T) {
+	p, err := newTopLevelPolicy(&PolicyCfg{SubPolicyCfg: SubPolicyCfg{Type: And}, And: AndCfg{Policies: []SubPolicyCfg{
+		{Type: StatusCode, StatusCode: StatusCodeCfg{StatusCodes: []string{"ERROR"}}},
+		{Type: RateLimiting, RateLimiting: RateLimitingCfg{SpansPerSecond: 1}},
+	}}})
+	require.NoError(t, err)
+
+	ok := newTestTrace(func(span pdata.Span) { span.Status().SetCode(pdata.StatusCodeOk) })
+	failed := newTestTrace(func(span pdata.Span) { span.Status().SetCode(pdata.StatusCodeError) })
+	now := time.Unix(1000, 0)
+	// The rate limit only counts the traces with an error.
+	assert.False(t, p.evaluate(testTraceID(1), ok, now))
+	assert.True(t, p.evaluate(testTraceID(1), failed, now))
+	assert.False(t, p.evaluate(testTraceID(1), failed, now))
+}
+
+func TestAndPolicyRateLimitingFirst(t *testing.T) {
+	p, err := newTopLevelPolicy(&PolicyCfg{SubPolicyCfg: SubPolicyCfg{Type: And}, And: AndCfg{Policies: []SubPolicyCfg{
+		{Type: RateLimiting, RateLimiting: RateLimitingCfg{SpansPerSecond: 2}},
+		{Type: StatusCode, StatusCode: StatusCodeCfg{StatusCodes: []string{"ERROR"}}},
+		{Type: RateLimiting, RateLimiting: RateLimitingCfg{SpansPerSecond: 1}},
+	}}})
+	require.NoError(t, err)
+	first := p.(*andPolicy).rateLimiters[0]
+
+	ok := newTestTrace(func(span pdata.Span) { span.Status().SetCode(pdata.StatusCodeOk) })
+	failed := newTestTrace(func(span pdata.Span) { span.Status().SetCode(pdata.StatusCodeError) })
+	now := time.Unix(1000, 0)
+	// The traces rejected by the status code policy listed after the rate limiter do not count.
+	assert.False(t, p.evaluate(testTraceID(1), ok, now))
+	assert.False(t, p.evaluate(testTraceID(1), ok, now))
+	assert.True(t, p.evaluate(testTraceID(1), failed, now))
+	assert.EqualValues(t, 1, first.spans)
+	// Neither do the traces rejected by the last rate limiter.
+	assert.False(t, p.evaluate(testTraceID(1), failed, now))
+	assert.EqualValues(t, 1, first.spans)
+}
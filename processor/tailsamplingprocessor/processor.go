@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/batchpertrace"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+// decision is the sampling decision of a trace.
+type decision int
+
+const (
+	pending decision = iota
+	sampled
+	notSampled
+)
+
+// traceData is a trace waiting for its decision, or the decision of a trace for its late spans.
+type traceData struct {
+	arrival  time.Time
+	decision decision
+	// spans are the spans received before the decision.
+	spans     pdata.Traces
+	spanCount int
+	// elem is the element of the trace in the pending or decided list.
+	elem *list.Element
+}
+
+// tailSamplingProcessor buffers the spans by trace ID for DecisionWait, then sends the traces sampled by any
+// of the policies to the next consumer. The decision is kept for the spans arriving later, as long as the number
+// of traces in memory is below NumTraces.
+type tailSamplingProcessor struct {
+	nextConsumer consumer.Traces
+	logger       *zap.Logger
+	decisionWait time.Duration
+	numTraces    int
+	policies     []namedPolicy
+	obsrep       *obsreport.Processor
+	// metricsCtx holds the processor tag of the custom metrics.
+	metricsCtx   context.Context
+	tickInterval time.Duration
+	now          func() time.Time
+
+	mu     sync.Mutex
+	traces map[pdata.TraceID]*traceData
+	// pending holds the IDs of the traces waiting for a decision, in arrival order.
+	pending *list.List
+	// decided holds the IDs of the decided traces, in decision order.
+	decided *list.List
+
+	stopCh chan struct{}
+	stopWg sync.WaitGroup
+}
+
+var _ component.TracesProcessor = (*tailSamplingProcessor)(nil)
+
+func newTailSamplingProcessor(params component.ProcessorCreateParams, nextConsumer consumer.Traces, cfg *Config) (*tailSamplingProcessor, error) {
+	policies, err := newPolicies(cfg.Policies)
+	if err != nil {
+		return nil, err
+	}
+	metricsCtx, err := tag.New(context.Background(), tag.Insert(processorTagKey, cfg.ID().String()))
+	if err != nil {
+		return nil, err
+	}
+	return &tailSamplingProcessor{
+		nextConsumer: nextConsumer,
+		logger:       params.Logger,
+		decisionWait: cfg.DecisionWait,
+		numTraces:    cfg.NumTraces,
+		policies:     policies,
+		obsrep: obsreport.NewProcessor(obsreport.ProcessorSettings{
+			Level:       configtelemetry.GetMetricsLevelFlagValue(),
+			ProcessorID: cfg.ID(),
+		}),
+		metricsCtx:   metricsCtx,
+		tickInterval: time.Second,
+		now:          time.Now,
+		traces:       make(map[pdata.TraceID]*traceData),
+		pending:      list.New(),
+		decided:      list.New(),
+		stopCh:       make(chan struct{}),
+	}, nil
+}
+
+// Start starts the decisions of the traces after their decision wait.
+func (tsp *tailSamplingProcessor) Start(context.Context, component.Host) error {
+	tsp.stopWg.Add(1)
+	go func() {
+		defer tsp.stopWg.Done()
+		ticker := time.NewTicker(tsp.tickInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				tsp.forward(context.Background(), tsp.decideExpired(tsp.now()))
+			case <-tsp.stopCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown decides all the pending traces without waiting, and sends the sampled ones.
+func (tsp *tailSamplingProcessor) Shutdown(ctx context.Context) error {
+	close(tsp.stopCh)
+	tsp.stopWg.Wait()
+
+	tsp.mu.Lock()
+	var toForward []pdata.Traces
+	now := tsp.now()
+	for front := tsp.pending.Front(); front != nil; front = tsp.pending.Front() {
+		if td, ok := tsp.decide(front.Value.(pdata.TraceID), now); ok {
+			toForward = append(toForward, td)
+		}
+	}
+	tsp.mu.Unlock()
+
+	return tsp.forward(ctx, toForward)
+}
+
+// Capabilities returns the consumer capabilities of the processor, the spans are copied.
+func (tsp *tailSamplingProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces buffers the spans of the pending traces, and applies the decision to the spans of the decided traces.
+// The traces decided early to make room are not part of td, they are sent without the context of the caller and
+// their errors are not returned.
+func (tsp *tailSamplingProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	now := tsp.now()
+	var toForward, early []pdata.Traces
+	lateSpans, droppedSpans := 0, 0
+
+	tsp.mu.Lock()
+	for traceID, batch := range batchpertrace.Split(td) {
+		trace, ok := tsp.traces[traceID]
+		if !ok {
+			if decided, ok := tsp.makeRoom(now); ok {
+				early = append(early, decided)
+			}
+			trace = &traceData{arrival: now, spans: pdata.NewTraces()}
+			trace.elem = tsp.pending.PushBack(traceID)
+			tsp.traces[traceID] = trace
+		}
+
+		spanCount := batch.SpanCount()
+		switch trace.decision {
+		case pending:
+			batch.ResourceSpans().MoveAndAppendTo(trace.spans.ResourceSpans())
+			trace.spanCount += spanCount
+		case sampled:
+			lateSpans += spanCount
+			toForward = append(toForward, batch)
+		case notSampled:
+			lateSpans += spanCount
+			droppedSpans += spanCount
+		}
+	}
+	tsp.mu.Unlock()
+
+	if lateSpans > 0 {
+		stats.Record(tsp.metricsCtx, statLateSpans.M(int64(lateSpans)))
+	}
+	if droppedSpans > 0 {
+		tsp.obsrep.TracesDropped(ctx, droppedSpans)
+	}
+	// The failures are logged and counted by forward.
+	_ = tsp.forward(context.Background(), early)
+	return tsp.forward(ctx, toForward)
+}
+
+// makeRoom frees a trace when NumTraces is reached, the oldest decided trace or else the oldest pending trace
+// after deciding it early. It returns the spans of the pending trace if it is sampled.
+func (tsp *tailSamplingProcessor) makeRoom(now time.Time) (pdata.Traces, bool) {
+	if len(tsp.traces) < tsp.numTraces {
+		return pdata.Traces{}, false
+	}
+	if front := tsp.decided.Front(); front != nil {
+		tsp.decided.Remove(front)
+		delete(tsp.traces, front.Value.(pdata.TraceID))
+		return pdata.Traces{}, false
+	}
+
+	stats.Record(tsp.metricsCtx, statEarlyDecisions.M(1))
+	traceID := tsp.pending.Front().Value.(pdata.TraceID)
+	td, ok := tsp.decide(traceID, now)
+	tsp.decided.Remove(tsp.traces[traceID].elem)
+	delete(tsp.traces, traceID)
+	return td, ok
+}
+
+// decideExpired decides the traces waiting for DecisionWait or more, and returns the sampled ones.
+func (tsp *tailSamplingProcessor) decideExpired(now time.Time) []pdata.Traces {
+	tsp.mu.Lock()
+	defer tsp.mu.Unlock()
+
+	var toForward []pdata.Traces
+	for front := tsp.pending.Front(); front != nil; front = tsp.pending.Front() {
+		traceID := front.Value.(pdata.TraceID)
+		if now.Sub(tsp.traces[traceID].arrival) < tsp.decisionWait {
+			break
+		}
+		if td, ok := tsp.decide(traceID, now); ok {
+			toForward = append(toForward, td)
+		}
+	}
+	return toForward
+}
+
+// decide evaluates the policies for a pending trace, moves it to the decided list, and returns its spans
+// if it is sampled.
+func (tsp *tailSamplingProcessor) decide(traceID pdata.TraceID, now time.Time) (pdata.Traces, bool) {
+	trace := tsp.traces[traceID]
+	tsp.pending.Remove(trace.elem)
+	trace.elem = tsp.decided.PushBack(traceID)
+
+	trace.decision = notSampled
+	for _, p := range tsp.policies {
+		if p.policy.evaluate(traceID, trace, now) {
+			trace.decision = sampled
+			_ = stats.RecordWithTags(tsp.metricsCtx, []tag.Mutator{tag.Upsert(policyTagKey, p.name)}, statTracesSampled.M(1))
+			break
+		}
+	}
+	if trace.decision == notSampled {
+		stats.Record(tsp.metricsCtx, statTracesNotSampled.M(1))
+		tsp.obsrep.TracesDropped(tsp.metricsCtx, trace.spanCount)
+	}
+
+	// Only the decision is kept for the late spans.
+	td := trace.spans
+	trace.spans = pdata.Traces{}
+	return td, trace.decision == sampled
+}
+
+// forward sends the spans of the sampled traces to the next consumer.
+func (tsp *tailSamplingProcessor) forward(ctx context.Context, tds []pdata.Traces) error {
+	if len(tds) == 0 {
+		return nil
+	}
+	td := tds[0]
+	for _, other := range tds[1:] {
+		other.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	}
+
+	spanCount := td.SpanCount()
+	if err := tsp.nextConsumer.ConsumeTraces(ctx, td); err != nil {
+		tsp.obsrep.TracesRefused(ctx, spanCount)
+		tsp.logger.Warn("Failed to send the sampled traces.", zap.Int("spans", spanCount), zap.Error(err))
+		return err
+	}
+	tsp.obsrep.TracesAccepted(ctx, spanCount)
+	return nil
+}
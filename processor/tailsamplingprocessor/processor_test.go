@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// testTraceID returns the trace ID with the given first byte.
+func testTraceID(i byte) pdata.TraceID {
+	return pdata.NewTraceID([16]byte{i})
+}
+
+// generateSpans returns a span with the given status for every trace ID.
+func generateSpans(code pdata.StatusCode, traceIDs ...pdata.TraceID) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+	for _, traceID := range traceIDs {
+		span := ils.Spans().AppendEmpty()
+		span.SetTraceID(traceID)
+		span.Status().SetCode(code)
+	}
+	return td
+}
+
+// newTestProcessor returns a processor sampling the traces with an error, with a controlled clock.
+func newTestProcessor(t *testing.T, numTraces int) (*tailSamplingProcessor, *consumertest.TracesSink, *time.Time) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DecisionWait = 10 * time.Second
+	cfg.NumTraces = numTraces
+	cfg.Policies = []PolicyCfg{
+		{Name: "errors", SubPolicyCfg: SubPolicyCfg{Type: StatusCode, StatusCode: StatusCodeCfg{StatusCodes: []string{"ERROR"}}}},
+	}
+	sink := new(consumertest.TracesSink)
+	tsp, err := newTailSamplingProcessor(component.ProcessorCreateParams{Logger: zap.NewNop()}, sink, cfg)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	tsp.now = func() time.Time { return now }
+	return tsp, sink, &now
+}
+
+func TestTailSampling_DecisionAfterWait(t *testing.T) {
+	tsp, sink, now := newTestProcessor(t, 100)
+
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeOk, testTraceID(1), testTraceID(2))))
+	*now = now.Add(5 * time.Second)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeError, testTraceID(1), testTraceID(3))))
+
+	// Trace 1 and 2 wait for 10s from their first span.
+	require.NoError(t, tsp.forward(context.Background(), tsp.decideExpired(now.Add(4*time.Second))))
+	assert.Equal(t, 0, sink.SpansCount())
+
+	require.NoError(t, tsp.forward(context.Background(), tsp.decideExpired(now.Add(5*time.Second))))
+	assert.Equal(t, 2, sink.SpansCount())
+	assertTraceIDs(t, sink, testTraceID(1))
+	assert.Equal(t, 2, tsp.decided.Len())
+	assert.Equal(t, 1, tsp.pending.Len())
+
+	sink.Reset()
+	require.NoError(t, tsp.forward(context.Background(), tsp.decideExpired(now.Add(10*time.Second))))
+	assertTraceIDs(t, sink, testTraceID(3))
+}
+
+func TestTailSampling_LateSpans(t *testing.T) {
+	tsp, sink, now := newTestProcessor(t, 100)
+
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeError, testTraceID(1))))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeOk, testTraceID(2))))
+	*now = now.Add(10 * time.Second)
+	require.NoError(t, tsp.forward(context.Background(), tsp.decideExpired(*now)))
+	sink.Reset()
+
+	// The late spans follow the decision of their trace, even with an error.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeOk, testTraceID(1))))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeError, testTraceID(2))))
+	assertTraceIDs(t, sink, testTraceID(1))
+	assert.Equal(t, 1, sink.SpansCount())
+}
+
+func TestTailSampling_NumTraces(t *testing.T) {
+	tsp, sink, now := newTestProcessor(t, 2)
+
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeError, testTraceID(1))))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeError, testTraceID(2))))
+	// The oldest pending trace is decided early.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeError, testTraceID(3))))
+	assertTraceIDs(t, sink, testTraceID(1))
+	assert.Len(t, tsp.traces, 2)
+
+	*now = now.Add(10 * time.Second)
+	require.NoError(t, tsp.forward(context.Background(), tsp.decideExpired(*now)))
+	assert.Equal(t, 3, sink.SpansCount())
+
+	// The oldest decided trace is forgotten first, its late spans start a new trace.
+	sink.Reset()
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeOk, testTraceID(4))))
+	assert.Len(t, tsp.traces, 2)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeOk, testTraceID(3))))
+	assert.Equal(t, 1, sink.SpansCount())
+	assert.Equal(t, 1, tsp.pending.Len())
+	assert.Equal(t, 1, tsp.decided.Len())
+}
+
+func TestTailSampling_ShutdownDecidesPendingTraces(t *testing.T) {
+	tsp, sink, _ := newTestProcessor(t, 100)
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeError, testTraceID(1))))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeOk, testTraceID(2))))
+	require.NoError(t, tsp.Shutdown(context.Background()))
+	assertTraceIDs(t, sink, testTraceID(1))
+}
+
+func TestTailSampling_Ticker(t *testing.T) {
+	tsp, sink, _ := newTestProcessor(t, 100)
+	tsp.now = time.Now
+	tsp.decisionWait = 10 * time.Millisecond
+	tsp.tickInterval = 5 * time.Millisecond
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeError, testTraceID(1))))
+	assert.Eventually(t, func() bool {
+		return sink.SpansCount() == 1
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestTailSampling_NextConsumerError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Policies = []PolicyCfg{{Name: "all", SubPolicyCfg: SubPolicyCfg{Type: Probabilistic, Probabilistic: ProbabilisticCfg{SamplingPercentage: 100}}}}
+	tsp, err := newTailSamplingProcessor(component.ProcessorCreateParams{Logger: zap.NewNop()}, consumertest.NewErr(errors.New("failed")), cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeOk, testTraceID(1))))
+	assert.EqualError(t, tsp.Shutdown(context.Background()), "failed")
+}
+
+// failingTraces is a consumer failing for the spans of a trace.
+type failingTraces struct {
+	consumertest.TracesSink
+	traceID pdata.TraceID
+}
+
+func (ft *failingTraces) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if forEachSpan(td, func(span pdata.Span, _ pdata.Resource, _ pdata.InstrumentationLibrary) bool {
+		return span.TraceID() == ft.traceID
+	}) {
+		return errors.New("failed")
+	}
+	return ft.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func TestTailSampling_EarlyDecisionError(t *testing.T) {
+	tsp, _, _ := newTestProcessor(t, 1)
+	next := &failingTraces{traceID: testTraceID(1)}
+	tsp.nextConsumer = next
+
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeError, testTraceID(1))))
+	// The failure of the trace 1 decided early is not returned for the spans of the trace 2.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeError, testTraceID(2))))
+	assert.Equal(t, 0, next.SpansCount())
+	assert.Len(t, tsp.traces, 1)
+
+	// The late spans of the trace 1 start a new trace, the trace 2 decided early fails.
+	next.traceID = testTraceID(2)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeError, testTraceID(1))))
+	assert.Equal(t, 0, next.SpansCount())
+}
+
+func TestTailSampling_Metrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	tsp, _, now := newTestProcessor(t, 1)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeError, testTraceID(1))))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeOk, testTraceID(2))))
+	*now = now.Add(10 * time.Second)
+	require.NoError(t, tsp.forward(context.Background(), tsp.decideExpired(*now)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), generateSpans(pdata.StatusCodeOk, testTraceID(2))))
+
+	for name, want := range map[string]int64{
+		statTracesSampled.Name():    1,
+		statTracesNotSampled.Name(): 1,
+		statEarlyDecisions.Name():   1,
+		statLateSpans.Name():        1,
+	} {
+		viewData, err := view.RetrieveData("processor/tail_sampling/" + name)
+		require.NoError(t, err)
+		require.Len(t, viewData, 1, name)
+		assert.Equal(t, float64(want), viewData[0].Data.(*view.SumData).Value, name)
+	}
+}
+
+// assertTraceIDs checks that the sink only received spans of the given traces.
+func assertTraceIDs(t *testing.T, sink *consumertest.TracesSink, want ...pdata.TraceID) {
+	got := make(map[pdata.TraceID]bool)
+	for _, td := range sink.AllTraces() {
+		forEachSpan(td, func(span pdata.Span, _ pdata.Resource, _ pdata.InstrumentationLibrary) bool {
+			got[span.TraceID()] = true
+			return false
+		})
+	}
+	wantSet := make(map[pdata.TraceID]bool)
+	for _, traceID := range want {
+		wantSet[traceID] = true
+	}
+	assert.Equal(t, wantSet, got)
+}
@@ -32,6 +32,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/fileexporter"
 	"go.opentelemetry.io/collector/exporter/jaegerexporter"
 	"go.opentelemetry.io/collector/exporter/kafkaexporter"
+	"go.opentelemetry.io/collector/exporter/loadbalancingexporter"
 	"go.opentelemetry.io/collector/exporter/opencensusexporter"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
@@ -52,6 +53,11 @@ func TestDefaultExporters(t *testing.T) {
 		getConfigFn   getExporterConfigFn
 		skipLifecycle bool
 	}{
+		{
+			exporter: "failover",
+			// The exporters to fail over are created from the factories of the host, that has none.
+			skipLifecycle: true,
+		},
 		{
 			exporter: "file",
 			getConfigFn: func() config.Exporter {
@@ -85,6 +91,16 @@ func TestDefaultExporters(t *testing.T) {
 			exporter:      "logging",
 			skipLifecycle: runtime.GOOS == "darwin", // TODO: investigate why this fails on darwin.
 		},
+		{
+			exporter: "loadbalancing",
+			getConfigFn: func() config.Exporter {
+				cfg := expFactories["loadbalancing"].CreateDefaultConfig().(*loadbalancingexporter.Config)
+				cfg.Endpoints = []string{endpoint}
+				// Supported by traces and metrics.
+				cfg.RoutingKey = "service"
+				return cfg
+			},
+		},
 		{
 			exporter: "opencensus",
 			getConfigFn: func() config.Exporter {
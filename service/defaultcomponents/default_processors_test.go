@@ -30,9 +30,13 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/processor/attributesprocessor"
 	"go.opentelemetry.io/collector/processor/memorylimiter"
+	"go.opentelemetry.io/collector/processor/metricstransformprocessor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/ratelimiterprocessor"
+	"go.opentelemetry.io/collector/processor/redactionprocessor"
 	"go.opentelemetry.io/collector/processor/resourceprocessor"
 	"go.opentelemetry.io/collector/processor/spanprocessor"
+	"go.opentelemetry.io/collector/processor/tailsamplingprocessor"
 )
 
 func TestDefaultProcessors(t *testing.T) {
@@ -42,8 +46,9 @@ func TestDefaultProcessors(t *testing.T) {
 	procFactories := allFactories.Processors
 
 	tests := []struct {
-		processor   config.Type
-		getConfigFn getProcessorConfigFn
+		processor     config.Type
+		getConfigFn   getProcessorConfigFn
+		skipLifecycle bool
 	}{
 		{
 			processor: "attributes",
@@ -58,9 +63,24 @@ func TestDefaultProcessors(t *testing.T) {
 		{
 			processor: "batch",
 		},
+		{
+			processor: "cumulativetodelta",
+		},
+		{
+			processor: "dedup",
+		},
+		{
+			processor: "deltatocumulative",
+		},
 		{
 			processor: "filter",
 		},
+		{
+			processor: "groupbytrace",
+		},
+		{
+			processor: "logparser",
+		},
 		{
 			processor: "memory_limiter",
 			getConfigFn: func() config.Processor {
@@ -70,9 +90,35 @@ func TestDefaultProcessors(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			processor: "metricstransform",
+			getConfigFn: func() config.Processor {
+				cfg := procFactories["metricstransform"].CreateDefaultConfig().(*metricstransformprocessor.Config)
+				cfg.Transforms = []metricstransformprocessor.Transform{
+					{Include: "metric", Action: metricstransformprocessor.Update, NewName: "new_metric"},
+				}
+				return cfg
+			},
+		},
 		{
 			processor: "probabilistic_sampler",
 		},
+		{
+			processor: "rate_limiter",
+			getConfigFn: func() config.Processor {
+				cfg := procFactories["rate_limiter"].CreateDefaultConfig().(*ratelimiterprocessor.Config)
+				cfg.Rate = 1000
+				return cfg
+			},
+		},
+		{
+			processor: "redaction",
+			getConfigFn: func() config.Processor {
+				cfg := procFactories["redaction"].CreateDefaultConfig().(*redactionprocessor.Config)
+				cfg.AllowedKeys = []string{"http.method"}
+				return cfg
+			},
+		},
 		{
 			processor: "resource",
 			getConfigFn: func() config.Processor {
@@ -83,6 +129,14 @@ func TestDefaultProcessors(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			processor: "resourcedetection",
+		},
+		{
+			processor: "routing",
+			// The exporters of the routing table are looked up from the exporters of the host, that has none.
+			skipLifecycle: true,
+		},
 		{
 			processor: "span",
 			getConfigFn: func() config.Processor {
@@ -91,6 +145,27 @@ func TestDefaultProcessors(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			processor: "spanmetrics",
+			// The metrics exporter is looked up from the exporters of the host, that has none.
+			skipLifecycle: true,
+		},
+		{
+			processor: "tail_sampling",
+			getConfigFn: func() config.Processor {
+				cfg := procFactories["tail_sampling"].CreateDefaultConfig().(*tailsamplingprocessor.Config)
+				cfg.Policies = []tailsamplingprocessor.PolicyCfg{
+					{
+						Name: "all",
+						SubPolicyCfg: tailsamplingprocessor.SubPolicyCfg{
+							Type:          tailsamplingprocessor.Probabilistic,
+							Probabilistic: tailsamplingprocessor.ProbabilisticCfg{SamplingPercentage: 100},
+						},
+					},
+				}
+				return cfg
+			},
+		},
 	}
 
 	assert.Equal(t, len(tests), len(procFactories))
@@ -101,6 +176,11 @@ func TestDefaultProcessors(t *testing.T) {
 			assert.Equal(t, tt.processor, factory.Type())
 			assert.EqualValues(t, config.NewID(tt.processor), factory.CreateDefaultConfig().ID())
 
+			if tt.skipLifecycle {
+				t.Log("Skipping lifecycle test", tt.processor)
+				return
+			}
+
 			verifyProcessorLifecycle(t, factory, tt.getConfigFn)
 		})
 	}
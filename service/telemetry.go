@@ -29,6 +29,9 @@ import (
 	"go.opentelemetry.io/collector/internal/collector/telemetry"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/processor/batchprocessor"
+	"go.opentelemetry.io/collector/processor/dedupprocessor"
+	"go.opentelemetry.io/collector/processor/groupbytraceprocessor"
+	"go.opentelemetry.io/collector/processor/tailsamplingprocessor"
 	"go.opentelemetry.io/collector/receiver/kafkareceiver"
 	telemetry2 "go.opentelemetry.io/collector/service/internal/telemetry"
 	"go.opentelemetry.io/collector/translator/conventions"
@@ -64,6 +67,9 @@ func (tel *appTelemetry) init(asyncErrorChannel chan<- error, ballastSizeBytes u
 	views = append(views, batchprocessor.MetricViews()...)
 	views = append(views, jaegerexporter.MetricViews()...)
 	views = append(views, kafkareceiver.MetricViews()...)
+	views = append(views, tailsamplingprocessor.MetricViews()...)
+	views = append(views, groupbytraceprocessor.MetricViews()...)
+	views = append(views, dedupprocessor.MetricViews()...)
 	views = append(views, obsreport.Configure(level)...)
 	views = append(views, processMetricsViews.Views()...)
 
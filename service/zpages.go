@@ -73,11 +73,46 @@ func (srv *service) handlePipelinezRequest(w http.ResponseWriter, r *http.Reques
 		zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 			Name: componentKind + ": " + fullName,
 		})
-		// TODO: Add config + status info.
+		if componentKind == "exporter" {
+			for _, data := range srv.getExporterStatusTablesData(componentName) {
+				zpages.WriteHTMLPropertiesTable(w, data)
+			}
+		}
+		// TODO: Add config info.
 	}
 	zpages.WriteHTMLFooter(w)
 }
 
+// zPagesStatusReporter is implemented by the components that report their internal status in the zPages,
+// e.g. the exporters built with the exporterhelper report their sending queue and circuit breaker.
+type zPagesStatusReporter interface {
+	ZPagesStatus() [][2]string
+}
+
+// getExporterStatusTablesData returns one status table per data type handled by the exporter.
+func (srv *service) getExporterStatusTablesData(exporterName string) []zpages.PropertiesTableData {
+	var data []zpages.PropertiesTableData
+	for dataType, exporters := range srv.GetExporters() {
+		for id, exp := range exporters {
+			if id.String() != exporterName {
+				continue
+			}
+			reporter, ok := exp.(zPagesStatusReporter)
+			if !ok {
+				continue
+			}
+			if status := reporter.ZPagesStatus(); len(status) > 0 {
+				data = append(data, zpages.PropertiesTableData{Name: "Status (" + string(dataType) + ")", Properties: status})
+			}
+		}
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].Name < data[j].Name
+	})
+	return data
+}
+
 func (srv *service) getPipelinesSummaryTableData() zpages.SummaryPipelinesTableData {
 	data := zpages.SummaryPipelinesTableData{
 		ComponentEndpoint: pipelinezPath,